@@ -0,0 +1,64 @@
+package allies
+
+import (
+	"slices"
+	"strings"
+	"sync"
+)
+
+type memoryStore struct {
+	mu     sync.RWMutex
+	allies map[string]Ally
+}
+
+var _ Store = (*memoryStore)(nil)
+
+// NewMemoryStore returns a Store that keeps allies in memory. Used for tests
+func NewMemoryStore() Store {
+	return &memoryStore{allies: map[string]Ally{}}
+}
+
+func (s *memoryStore) Get(sid string) (*Ally, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	a, ok := s.allies[sid]
+	if !ok {
+		return nil, ErrAllyNotFound
+	}
+
+	return &a, nil
+}
+
+func (s *memoryStore) List() ([]Ally, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	allies := make([]Ally, 0, len(s.allies))
+	for _, a := range s.allies {
+		allies = append(allies, a)
+	}
+	slices.SortFunc(allies, func(a, b Ally) int { return strings.Compare(a.SID, b.SID) })
+
+	return allies, nil
+}
+
+func (s *memoryStore) Save(a *Ally) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.allies[a.SID] = *a
+	return nil
+}
+
+func (s *memoryStore) Delete(sid string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.allies[sid]; !ok {
+		return ErrAllyNotFound
+	}
+
+	delete(s.allies, sid)
+	return nil
+}
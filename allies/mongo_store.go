@@ -0,0 +1,65 @@
+package allies
+
+import (
+	"context"
+	"errors"
+
+	"github.com/sol-armada/sol-bot/stores"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type mongoStore struct {
+	store *stores.AlliesStore
+}
+
+var _ Store = (*mongoStore)(nil)
+
+// NewMongoStore wraps the mongo allies collection as a Store
+func NewMongoStore(store *stores.AlliesStore) Store {
+	if store == nil {
+		return nil
+	}
+	return &mongoStore{store: store}
+}
+
+func (s *mongoStore) Get(sid string) (*Ally, error) {
+	a := &Ally{}
+	if err := s.store.Get(sid).Decode(a); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrAllyNotFound
+		}
+		return nil, err
+	}
+
+	return a, nil
+}
+
+func (s *mongoStore) List() ([]Ally, error) {
+	cur, err := s.store.List()
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(context.Background())
+
+	allies := []Ally{}
+	if err := cur.All(context.Background(), &allies); err != nil {
+		return nil, err
+	}
+
+	return allies, nil
+}
+
+func (s *mongoStore) Save(a *Ally) error {
+	return s.store.Upsert(a.SID, a)
+}
+
+func (s *mongoStore) Delete(sid string) error {
+	deleted, err := s.store.Delete(sid)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrAllyNotFound
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package allies
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// Ally is an org we're friendly with. Verified members whose primary org is an ally get the ally role and tag
+type Ally struct {
+	SID string `json:"sid" bson:"_id"`
+	// ContactId is the Discord member we talk to at the org, if any
+	ContactId string    `json:"contact_id" bson:"contact_id"`
+	Notes     string    `json:"notes" bson:"notes"`
+	AddedBy   string    `json:"added_by" bson:"added_by"`
+	CreatedAt time.Time `json:"created_at" bson:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" bson:"updated_at"`
+}
+
+var (
+	ErrAllyNotFound = errors.New("ally not found")
+	ErrNotSetup     = errors.New("allies store not set up")
+)
+
+var alliesStore Store
+
+func Setup(store Store) error {
+	if store == nil {
+		return errors.New("allies store not found")
+	}
+	alliesStore = store
+	return nil
+}
+
+func New(sid, addedBy string) *Ally {
+	now := time.Now().UTC()
+	return &Ally{
+		SID:       NormalizeSID(sid),
+		AddedBy:   addedBy,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// NormalizeSID is how SIDs are stored. RSI SIDs are case insensitive
+func NormalizeSID(sid string) string {
+	return strings.ToUpper(strings.TrimSpace(sid))
+}
+
+func Get(sid string) (*Ally, error) {
+	return alliesStore.Get(NormalizeSID(sid))
+}
+
+func List() ([]Ally, error) {
+	return alliesStore.List()
+}
+
+// IsAlly reports if the org is in the registry
+func IsAlly(sid string) (bool, error) {
+	if sid == "" {
+		return false, nil
+	}
+	if alliesStore == nil {
+		return false, ErrNotSetup
+	}
+
+	if _, err := Get(sid); err != nil {
+		if errors.Is(err, ErrAllyNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+// Remove returns ErrAllyNotFound if the org is not an ally
+func Remove(sid string) error {
+	return alliesStore.Delete(NormalizeSID(sid))
+}
+
+func (a *Ally) Save() error {
+	a.UpdatedAt = time.Now().UTC()
+	return alliesStore.Save(a)
+}
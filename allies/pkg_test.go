@@ -0,0 +1,47 @@
+package allies
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRegistry(t *testing.T) {
+	if err := Setup(NewMemoryStore()); err != nil {
+		t.Fatal(err)
+	}
+
+	ally := New(" friendly ", "1")
+	ally.Notes = "met at CitizenCon"
+	if err := ally.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, sid := range []string{"FRIENDLY", "friendly"} {
+		ok, err := IsAlly(sid)
+		if err != nil || !ok {
+			t.Errorf("IsAlly(%q) = %v, %v, expected true", sid, ok, err)
+		}
+	}
+
+	if ok, _ := IsAlly("HOSTILE"); ok {
+		t.Error("IsAlly(HOSTILE) expected false")
+	}
+
+	list, err := List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 1 || list[0].SID != "FRIENDLY" || list[0].Notes != "met at CitizenCon" {
+		t.Errorf("List() = %+v", list)
+	}
+
+	if err := Remove("friendly"); err != nil {
+		t.Fatal(err)
+	}
+	if err := Remove("FRIENDLY"); !errors.Is(err, ErrAllyNotFound) {
+		t.Errorf("removing a missing ally expected ErrAllyNotFound, got %v", err)
+	}
+	if ok, _ := IsAlly("FRIENDLY"); ok {
+		t.Error("IsAlly(FRIENDLY) expected false after removal")
+	}
+}
@@ -0,0 +1,12 @@
+package allies
+
+// Store is the persistence layer the allies package works against
+type Store interface {
+	// Get returns ErrAllyNotFound if the org is not an ally
+	Get(sid string) (*Ally, error)
+	// List returns every ally ordered by SID
+	List() ([]Ally, error)
+	Save(ally *Ally) error
+	// Delete returns ErrAllyNotFound if the org is not an ally
+	Delete(sid string) error
+}
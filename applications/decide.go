@@ -0,0 +1,101 @@
+package applications
+
+import (
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/sol-armada/sol-bot/attendance"
+	"github.com/sol-armada/sol-bot/members"
+	"github.com/sol-armada/sol-bot/settings"
+)
+
+// decideMu keeps two officers from deciding the same application at once
+var decideMu sync.Mutex
+
+// Decide records an officer accepting or rejecting the application, updates the recruiting post and
+// lets the applicant know by DM. The decision still has to be made on RSI by hand
+func Decide(s *discordgo.Session, id string, status Status, officerId string) (*Application, error) {
+	a, err := decide(id, status, officerId)
+	if err != nil {
+		return a, err
+	}
+
+	if err := a.updateMessage(s); err != nil {
+		slog.Error("failed to update application message", "application_id", a.Id, "error", err)
+	}
+
+	if a.MemberId == "" {
+		return a, nil
+	}
+
+	channel, err := s.UserChannelCreate(a.MemberId)
+	if err != nil {
+		return a, err
+	}
+
+	_, err = s.ChannelMessageSend(channel.ID, a.decisionMessage())
+	return a, err
+}
+
+func decide(id string, status Status, officerId string) (*Application, error) {
+	decideMu.Lock()
+	defer decideMu.Unlock()
+
+	a, err := Get(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if a.Status != StatusPending {
+		return a, ErrAlreadyDecided
+	}
+
+	now := time.Now().UTC()
+	a.Status = status
+	a.DecidedBy = officerId
+	a.DecidedAt = &now
+
+	if err := a.Save(); err != nil {
+		return nil, err
+	}
+
+	return a, nil
+}
+
+func (a *Application) decisionMessage() string {
+	org := settings.GetString("rsi_org_sid")
+
+	if a.Status == StatusAccepted {
+		return fmt.Sprintf("Your application to join %s has been accepted! Welcome aboard, an officer will finish things up on RSI shortly.", org)
+	}
+
+	return fmt.Sprintf("Your application to join %s was not accepted this time. Reach out to an officer if you have any questions.", org)
+}
+
+func (a *Application) updateMessage(s *discordgo.Session) error {
+	if a.ChannelId == "" || a.MessageId == "" {
+		return nil
+	}
+
+	var member *members.Member
+	events := 0
+	if a.MemberId != "" {
+		m, err := members.Get(a.MemberId)
+		if err == nil {
+			member = m
+			events, _ = attendance.GetMemberAttendanceCount(m.Id)
+		}
+	}
+
+	components := a.Components()
+	_, err := s.ChannelMessageEditComplex(&discordgo.MessageEdit{
+		Channel:    a.ChannelId,
+		ID:         a.MessageId,
+		Embeds:     &[]*discordgo.MessageEmbed{a.Embed(member, events)},
+		Components: &components,
+	})
+	return err
+}
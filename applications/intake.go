@@ -0,0 +1,307 @@
+package applications
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/sol-armada/sol-bot/attendance"
+	"github.com/sol-armada/sol-bot/members"
+	"github.com/sol-armada/sol-bot/rsi"
+	"github.com/sol-armada/sol-bot/settings"
+	"github.com/sol-armada/sol-bot/utils"
+)
+
+// RequiredEvents is how many official events an applicant needs, from the onboarding rules.
+// Applicants under it are flagged, not rejected
+func RequiredEvents() int {
+	return settings.GetIntWithDefault("FEATURES.APPLICATIONS.REQUIRED_EVENTS", 3)
+}
+
+// Watch polls RSI for new applications until the context is done. It does nothing without a recruiting channel
+func Watch(ctx context.Context, s *discordgo.Session) {
+	logger := slog.Default().With("func", "applications.Watch")
+
+	if settings.GetString("FEATURES.APPLICATIONS.CHANNEL_ID") == "" {
+		logger.Debug("no recruiting channel set, not watching applications")
+		return
+	}
+
+	interval, err := utils.StringToDuration(settings.GetStringWithDefault("FEATURES.APPLICATIONS.INTERVAL", "15m"))
+	if err != nil || interval <= 0 {
+		logger.Error("invalid application poll interval", "error", err)
+		return
+	}
+
+	client, err := rsi.NewDefaultClient()
+	if err != nil {
+		logger.Error("failed to create rsi client", "error", err)
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		count, err := Intake(ctx, s, client)
+		if err != nil {
+			logger.Error("failed to take in applications", "error", err)
+		} else if count > 0 {
+			logger.Info("posted new applications", "count", count)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Intake posts every pending RSI application that hasn't been seen before to the recruiting channel
+// and returns how many were posted
+func Intake(ctx context.Context, s *discordgo.Session, client *rsi.RSIClient) (int, error) {
+	channelId := settings.GetString("FEATURES.APPLICATIONS.CHANNEL_ID")
+
+	pending, err := client.GetAllApplications(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var mmbrs []members.Member
+	posted := 0
+	for _, rsiApp := range pending {
+		if unseen, err := unseenApplication(rsiApp); err != nil {
+			return posted, err
+		} else if !unseen {
+			continue
+		}
+
+		// only load members once there is something new to match
+		if mmbrs == nil {
+			mmbrs, err = members.ListAll()
+			if err != nil {
+				return posted, err
+			}
+		}
+
+		a := New(rsiApp.Handle, rsiApp.Note, rsiApp.Date, rsiApp.Avatar)
+
+		member := MatchMember(rsiApp.Handle, mmbrs)
+		events := 0
+		if member != nil {
+			a.MemberId = member.Id
+
+			events, err = attendance.GetMemberAttendanceCount(member.Id)
+			if err != nil {
+				return posted, err
+			}
+		}
+
+		msg, err := s.ChannelMessageSendComplex(channelId, &discordgo.MessageSend{
+			Embeds:     []*discordgo.MessageEmbed{a.Embed(member, events)},
+			Components: a.Components(),
+		})
+		if err != nil {
+			return posted, err
+		}
+		a.ChannelId = msg.ChannelID
+		a.MessageId = msg.ID
+
+		if err := a.Save(); err != nil {
+			return posted, err
+		}
+		posted++
+	}
+
+	return posted, nil
+}
+
+// unseenApplication reports if the RSI application still has to be posted. RSI keeps showing applications after they
+// are decided here, so a decided handle is only posted again once RSI shows it applied on a different date
+func unseenApplication(rsiApp rsi.Application) (bool, error) {
+	existing, err := Get(applicationId(rsiApp.Handle))
+	if err != nil {
+		if errors.Is(err, ErrApplicationNotFound) {
+			return true, nil
+		}
+		return false, err
+	}
+
+	return existing.Status != StatusPending && existing.AppliedOn != rsiApp.Date, nil
+}
+
+// MatchMember finds the Discord member for an RSI handle. Members who went through onboarding and are still
+// on the server win over anyone else with the handle
+func MatchMember(handle string, mmbrs []members.Member) *members.Member {
+	handle = normalizeHandle(handle)
+
+	var best *members.Member
+	bestScore := -1
+	for i := range mmbrs {
+		member := &mmbrs[i]
+		if member.IsBot || normalizeHandle(member.Handle()) != handle {
+			continue
+		}
+
+		score := 0
+		if member.OnboardedAt != nil {
+			score += 2
+		}
+		if member.LeftAt == nil {
+			score++
+		}
+
+		if score > bestScore {
+			best = member
+			bestScore = score
+		}
+	}
+
+	return best
+}
+
+func normalizeHandle(handle string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(handle), ".", ""))
+}
+
+func (a *Application) Embed(member *members.Member, events int) *discordgo.MessageEmbed {
+	discord := "No matching Discord member"
+	if member != nil {
+		discord = fmt.Sprintf("<@%s>", member.Id)
+		if member.LeftAt != nil {
+			discord += " (left the server)"
+		}
+	}
+
+	eventsValue := fmt.Sprintf("%d", events)
+	if events < RequiredEvents() {
+		eventsValue += fmt.Sprintf("\n⚠️ Has not attended the %d official events required", RequiredEvents())
+	}
+
+	note := a.Note
+	if note == "" {
+		note = "None"
+	}
+
+	fields := []*discordgo.MessageEmbedField{
+		{Name: "RSI Handle", Value: fmt.Sprintf("[%s](%s)", a.Handle, rsi.UserProfileURL(a.Handle)), Inline: true},
+		{Name: "Discord", Value: discord, Inline: true},
+		{Name: "Applied", Value: a.AppliedOn, Inline: true},
+		{Name: "Events Attended", Value: eventsValue},
+		{Name: "Application Note", Value: truncate(note, 1024)},
+	}
+
+	if member != nil {
+		fields = append(fields, onboardingFields(member)...)
+	}
+
+	if a.Status != StatusPending {
+		decision := fmt.Sprintf("%s by <@%s>", strings.ToUpper(string(a.Status[:1]))+string(a.Status[1:]), a.DecidedBy)
+		if a.DecidedAt != nil {
+			decision += fmt.Sprintf(" <t:%d:R>", a.DecidedAt.Unix())
+		}
+		fields = append(fields, &discordgo.MessageEmbedField{Name: "Decision", Value: decision})
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:  "New Application: " + a.Handle,
+		Fields: fields,
+		Footer: &discordgo.MessageEmbedFooter{Text: "Application ID: " + a.Id},
+	}
+	if a.Avatar != "" {
+		embed.Thumbnail = &discordgo.MessageEmbedThumbnail{URL: a.Avatar}
+	}
+
+	return embed
+}
+
+// onboardingFields are the answers the member gave during onboarding, falling back to the legacy answers
+func onboardingFields(member *members.Member) []*discordgo.MessageEmbedField {
+	answers := []struct {
+		name  string
+		value string
+	}{
+		{"Age", firstNonEmpty(nonZero(member.Age), member.LegacyAge)},
+		{"Pronouns", member.Pronouns},
+		{"Playtime", firstNonEmpty(nonZero(member.Playtime), member.LegacyPlaytime)},
+		{"Gameplay", firstNonEmpty(gameplay(member.Gameplay), member.LegacyGameplay)},
+		{"Found Us By", member.FoundBy},
+		{"Time Zone", member.TimeZone},
+		{"Other", firstNonEmpty(member.Other, member.LegacyOther)},
+	}
+
+	fields := []*discordgo.MessageEmbedField{}
+	for _, answer := range answers {
+		if answer.value == "" {
+			continue
+		}
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name:   answer.name,
+			Value:  truncate(answer.value, 1024),
+			Inline: true,
+		})
+	}
+
+	return fields
+}
+
+func nonZero(n int) string {
+	if n == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d", n)
+}
+
+func gameplay(types []members.GameplayType) string {
+	names := make([]string, len(types))
+	for i, t := range types {
+		names[i] = t.String()
+	}
+	return strings.Join(names, ", ")
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+func (a *Application) Components() []discordgo.MessageComponent {
+	if a.Status != StatusPending {
+		return []discordgo.MessageComponent{}
+	}
+
+	return []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.Button{
+					Label:    "Accept",
+					Style:    discordgo.SuccessButton,
+					CustomID: "application:accept:" + a.Id,
+				},
+				discordgo.Button{
+					Label:    "Reject",
+					Style:    discordgo.DangerButton,
+					CustomID: "application:reject:" + a.Id,
+				},
+			},
+		},
+	}
+}
+
+// truncate keeps embed values under discord's length limits
+func truncate(s string, max int) string {
+	runes := []rune(s)
+	if len(runes) <= max {
+		return s
+	}
+	return string(runes[:max-3]) + "..."
+}
@@ -0,0 +1,51 @@
+package applications
+
+import (
+	"sync"
+)
+
+type memoryStore struct {
+	mu           sync.RWMutex
+	applications map[string]Application
+}
+
+var _ Store = (*memoryStore)(nil)
+
+// NewMemoryStore returns a Store that keeps applications in memory. Used for tests
+func NewMemoryStore() Store {
+	return &memoryStore{applications: map[string]Application{}}
+}
+
+func (s *memoryStore) Get(id string) (*Application, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	a, ok := s.applications[id]
+	if !ok {
+		return nil, ErrApplicationNotFound
+	}
+
+	return &a, nil
+}
+
+func (s *memoryStore) GetByStatus(status Status) ([]*Application, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	applications := []*Application{}
+	for _, a := range s.applications {
+		if a.Status == status {
+			applications = append(applications, &a)
+		}
+	}
+
+	return applications, nil
+}
+
+func (s *memoryStore) Save(a *Application) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.applications[a.Id] = *a
+	return nil
+}
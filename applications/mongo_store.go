@@ -0,0 +1,54 @@
+package applications
+
+import (
+	"context"
+	"errors"
+
+	"github.com/sol-armada/sol-bot/stores"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type mongoStore struct {
+	store *stores.ApplicationsStore
+}
+
+var _ Store = (*mongoStore)(nil)
+
+// NewMongoStore wraps the mongo applications collection as a Store
+func NewMongoStore(store *stores.ApplicationsStore) Store {
+	if store == nil {
+		return nil
+	}
+	return &mongoStore{store: store}
+}
+
+func (s *mongoStore) Get(id string) (*Application, error) {
+	a := &Application{}
+	if err := s.store.Get(id).Decode(a); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrApplicationNotFound
+		}
+		return nil, err
+	}
+
+	return a, nil
+}
+
+func (s *mongoStore) GetByStatus(status Status) ([]*Application, error) {
+	cur, err := s.store.GetByStatus(string(status))
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(context.Background())
+
+	applications := []*Application{}
+	if err := cur.All(context.Background(), &applications); err != nil {
+		return nil, err
+	}
+
+	return applications, nil
+}
+
+func (s *mongoStore) Save(a *Application) error {
+	return s.store.Upsert(a.Id, a)
+}
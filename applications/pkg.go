@@ -0,0 +1,80 @@
+package applications
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+type Status string
+
+const (
+	StatusPending  Status = "pending"
+	StatusAccepted Status = "accepted"
+	StatusRejected Status = "rejected"
+)
+
+// Application is an RSI org application picked up by the intake job along with the officers' decision.
+// RSI can't be written to, so accepting or rejecting here only records the decision and tells the applicant
+type Application struct {
+	Id     string `json:"id" bson:"_id"`
+	Handle string `json:"handle" bson:"handle"`
+	Note   string `json:"note" bson:"note"`
+	// AppliedOn is the date as RSI shows it
+	AppliedOn string `json:"applied_on" bson:"applied_on"`
+	Avatar    string `json:"avatar" bson:"avatar"`
+	// MemberId is the Discord member matched to the handle, if any
+	MemberId string `json:"member_id" bson:"member_id"`
+	Status   Status `json:"status" bson:"status"`
+
+	ChannelId string `json:"channel_id" bson:"channel_id"`
+	MessageId string `json:"message_id" bson:"message_id"`
+
+	DecidedBy string     `json:"decided_by" bson:"decided_by"`
+	DecidedAt *time.Time `json:"decided_at" bson:"decided_at"`
+	CreatedAt time.Time  `json:"created_at" bson:"created_at"`
+}
+
+var (
+	ErrApplicationNotFound = errors.New("application not found")
+	ErrAlreadyDecided      = errors.New("application has already been decided")
+)
+
+var applicationsStore Store
+
+func Setup(store Store) error {
+	if store == nil {
+		return errors.New("applications store not found")
+	}
+	applicationsStore = store
+	return nil
+}
+
+func New(handle, note, appliedOn, avatar string) *Application {
+	return &Application{
+		Id:        applicationId(handle),
+		Handle:    handle,
+		Note:      note,
+		AppliedOn: appliedOn,
+		Avatar:    avatar,
+		Status:    StatusPending,
+		CreatedAt: time.Now().UTC(),
+	}
+}
+
+// a handle only has one application, a new one replaces it once the last is decided. RSI handles are case insensitive
+func applicationId(handle string) string {
+	return strings.ToLower(handle)
+}
+
+func Get(id string) (*Application, error) {
+	return applicationsStore.Get(id)
+}
+
+func GetPending() ([]*Application, error) {
+	return applicationsStore.GetByStatus(StatusPending)
+}
+
+func (a *Application) Save() error {
+	return applicationsStore.Save(a)
+}
@@ -0,0 +1,103 @@
+package applications
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sol-armada/sol-bot/members"
+	"github.com/sol-armada/sol-bot/rsi"
+)
+
+func TestMatchMember(t *testing.T) {
+	onboarded := time.Now()
+	left := time.Now()
+
+	mmbrs := []members.Member{
+		{Id: "1", Name: "Some.Pilot"},
+		{Id: "2", Name: "somepilot", OnboardedAt: &onboarded},
+		{Id: "3", Name: "SomePilot", OnboardedAt: &onboarded, LeftAt: &left},
+		{Id: "4", Name: "Other"},
+		{Id: "5", Name: "Bot", IsBot: true},
+	}
+
+	tests := []struct {
+		handle string
+		want   string
+	}{
+		{handle: "SomePilot", want: "2"},
+		{handle: "other", want: "4"},
+		{handle: "bot", want: ""},
+		{handle: "Nobody", want: ""},
+	}
+
+	for _, tt := range tests {
+		got := MatchMember(tt.handle, mmbrs)
+		if tt.want == "" {
+			if got != nil {
+				t.Errorf("MatchMember(%q) expected no match, got %s", tt.handle, got.Id)
+			}
+			continue
+		}
+		if got == nil || got.Id != tt.want {
+			t.Errorf("MatchMember(%q) expected %s, got %+v", tt.handle, tt.want, got)
+		}
+	}
+}
+
+func TestDecide(t *testing.T) {
+	if err := Setup(NewMemoryStore()); err != nil {
+		t.Fatal(err)
+	}
+
+	a := New("SomePilot", "", "", "")
+	if err := a.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	decided, err := decide("somepilot", StatusAccepted, "officer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decided.Status != StatusAccepted || decided.DecidedBy != "officer" || decided.DecidedAt == nil {
+		t.Errorf("expected application to be accepted by officer, got %+v", decided)
+	}
+
+	again, err := decide("somepilot", StatusRejected, "other officer")
+	if !errors.Is(err, ErrAlreadyDecided) {
+		t.Errorf("expected ErrAlreadyDecided, got %v", err)
+	}
+	if again == nil || again.DecidedBy != "officer" {
+		t.Errorf("expected the first decision to stand, got %+v", again)
+	}
+}
+
+func TestUnseenApplication(t *testing.T) {
+	if err := Setup(NewMemoryStore()); err != nil {
+		t.Fatal(err)
+	}
+
+	first := rsi.Application{Handle: "SomePilot", Date: "Jan 2, 2026"}
+	if unseen, err := unseenApplication(first); err != nil || !unseen {
+		t.Fatalf("expected a new handle to be unseen, got %t, %v", unseen, err)
+	}
+
+	if err := New(first.Handle, "", first.Date, "").Save(); err != nil {
+		t.Fatal(err)
+	}
+	if unseen, _ := unseenApplication(first); unseen {
+		t.Error("expected a posted application to be seen")
+	}
+
+	if _, err := decide("somepilot", StatusRejected, "officer"); err != nil {
+		t.Fatal(err)
+	}
+	if unseen, _ := unseenApplication(first); unseen {
+		t.Error("expected a decided application RSI still shows to be seen")
+	}
+
+	again := rsi.Application{Handle: "somepilot", Date: "Mar 4, 2026"}
+	if unseen, _ := unseenApplication(again); !unseen {
+		t.Error("expected a re-application after a decision to be unseen")
+	}
+}
@@ -0,0 +1,9 @@
+package applications
+
+// Store is the persistence layer the applications package works against
+type Store interface {
+	// Get returns ErrApplicationNotFound if there is no application with the id
+	Get(id string) (*Application, error)
+	GetByStatus(status Status) ([]*Application, error)
+	Save(application *Application) error
+}
@@ -0,0 +1,46 @@
+package attendance
+
+import (
+	"testing"
+
+	"github.com/sol-armada/sol-bot/members"
+	"github.com/sol-armada/sol-bot/tokens"
+)
+
+func TestDistributeTokens(t *testing.T) {
+	if err := tokens.Setup(tokens.NewMemoryStore()); err != nil {
+		t.Fatal(err)
+	}
+
+	a := &Attendance{
+		Id:         "event",
+		Successful: true,
+		Members: []*members.Member{
+			{Id: "stayed"},
+			{Id: "left"},
+		},
+	}
+
+	if _, err := a.DistributeTokens([]string{"stayed"}); err != nil {
+		t.Fatalf("distributing tokens: %v", err)
+	}
+
+	// running again must not pay out twice
+	if _, err := a.DistributeTokens([]string{"stayed"}); err != nil {
+		t.Fatalf("distributing tokens again: %v", err)
+	}
+
+	want := map[string]int{
+		"stayed": 10 + 20 + 10,
+		"left":   10 + 20,
+	}
+	for memberId, expected := range want {
+		balance, err := tokens.GetBalanceByMemberId(memberId)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if balance != expected {
+			t.Errorf("expected %s to have %d tokens, got %d", memberId, expected, balance)
+		}
+	}
+}
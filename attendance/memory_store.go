@@ -0,0 +1,196 @@
+package attendance
+
+import (
+	"context"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sol-armada/sol-bot/members"
+)
+
+type memoryStore struct {
+	mu       sync.RWMutex
+	records  map[string]*Attendance
+	watchers []chan Attendance
+}
+
+var _ Store = (*memoryStore)(nil)
+
+// NewMemoryStore returns a Store that keeps attendance records in memory. Used for tests
+func NewMemoryStore() Store {
+	return &memoryStore{records: map[string]*Attendance{}}
+}
+
+func (s *memoryStore) Get(id string) (*Attendance, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	attendance, ok := s.records[id]
+	if !ok {
+		return nil, ErrAttendanceNotFound
+	}
+
+	return cloneAttendance(attendance), nil
+}
+
+func (s *memoryStore) List(filter ListFilter, limit, page int) ([]*Attendance, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var attendances []*Attendance
+	for _, attendance := range s.records {
+		if filter.matches(attendance) {
+			attendances = append(attendances, cloneAttendance(attendance))
+		}
+	}
+
+	sort.Slice(attendances, func(i, j int) bool {
+		return attendances[i].DateCreated.After(attendances[j].DateCreated)
+	})
+
+	if limit > 0 {
+		if page == 0 {
+			page = 1
+		}
+
+		start := (page - 1) * limit
+		if start >= len(attendances) {
+			return nil, nil
+		}
+		attendances = attendances[start:min(start+limit, len(attendances))]
+	}
+
+	return attendances, nil
+}
+
+// GetCount mirrors the mongo aggregation: recorded events after the member joined,
+// where events starting within 8 hours of the previous one are treated as the same event
+func (s *memoryStore) GetCount(memberId string) (int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var dates []time.Time
+	for _, attendance := range s.records {
+		if !attendance.Recorded {
+			continue
+		}
+
+		member, ok := attendance.GetMember(memberId)
+		if !ok || !attendance.DateCreated.After(member.Joined) {
+			continue
+		}
+
+		dates = append(dates, attendance.DateCreated.UTC())
+	}
+
+	slices.SortFunc(dates, func(a, b time.Time) int { return a.Compare(b) })
+
+	buckets := map[string]bool{}
+	for i, date := range dates {
+		key := date.Format("2006-01-02-15")
+		if i > 0 && math.RoundToEven(date.Sub(dates[i-1]).Hours()) <= 8 {
+			key += "-overlap"
+		}
+		buckets[key] = true
+	}
+
+	count := 0
+	for key := range buckets {
+		if !strings.Contains(key, "overlap") {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+func (s *memoryStore) GetUniqueMemberCount(days int) (int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	since := time.Now().Add(-time.Duration(days) * time.Hour * 24)
+
+	unique := map[string]bool{}
+	for _, attendance := range s.records {
+		if attendance.DateCreated.Before(since) {
+			continue
+		}
+		for _, member := range attendance.Members {
+			unique[member.Id] = true
+		}
+	}
+
+	return len(unique), nil
+}
+
+func (s *memoryStore) Save(attendance *Attendance) error {
+	s.mu.Lock()
+	s.records[attendance.Id] = cloneAttendance(attendance)
+	watchers := slices.Clone(s.watchers)
+	s.mu.Unlock()
+
+	for _, w := range watchers {
+		w <- *cloneAttendance(attendance)
+	}
+
+	return nil
+}
+
+func (s *memoryStore) Delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.records, id)
+	return nil
+}
+
+func (s *memoryStore) Watch(ctx context.Context, out chan Attendance) error {
+	s.mu.Lock()
+	s.watchers = append(s.watchers, out)
+	s.mu.Unlock()
+
+	<-ctx.Done()
+
+	s.mu.Lock()
+	s.watchers = slices.DeleteFunc(s.watchers, func(w chan Attendance) bool { return w == out })
+	s.mu.Unlock()
+
+	return ctx.Err()
+}
+
+func cloneAttendance(a *Attendance) *Attendance {
+	c := *a
+	c.Members = cloneMembers(a.Members)
+	c.WithIssues = cloneMembers(a.WithIssues)
+	c.FromStart = slices.Clone(a.FromStart)
+	c.Stayed = slices.Clone(a.Stayed)
+	if a.SubmittedBy != nil {
+		submittedBy := *a.SubmittedBy
+		c.SubmittedBy = &submittedBy
+	}
+	if a.Payouts != nil {
+		payouts := *a.Payouts
+		c.Payouts = &payouts
+	}
+	return &c
+}
+
+func cloneMembers(mmbrs []*members.Member) []*members.Member {
+	if mmbrs == nil {
+		return nil
+	}
+
+	cloned := make([]*members.Member, len(mmbrs))
+	for i, member := range mmbrs {
+		if member == nil {
+			continue
+		}
+		m := *member
+		cloned[i] = &m
+	}
+	return cloned
+}
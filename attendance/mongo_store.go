@@ -0,0 +1,169 @@
+package attendance
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/sol-armada/sol-bot/stores"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type mongoStore struct {
+	store *stores.AttendanceStore
+}
+
+var _ Store = (*mongoStore)(nil)
+
+// NewMongoStore wraps the mongo attendance collection as a Store
+func NewMongoStore(store *stores.AttendanceStore) Store {
+	if store == nil {
+		return nil
+	}
+	return &mongoStore{store: store}
+}
+
+func (s *mongoStore) Get(id string) (*Attendance, error) {
+	cur, err := s.store.Get(id)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrAttendanceNotFound
+		}
+
+		return nil, err
+	}
+	defer cur.Close(context.TODO())
+
+	attendance := &Attendance{}
+	for cur.Next(context.TODO()) {
+		if err := cur.Decode(attendance); err != nil {
+			return nil, err
+		}
+	}
+
+	if attendance.Id == "" {
+		return nil, ErrAttendanceNotFound
+	}
+
+	return attendance, nil
+}
+
+func (s *mongoStore) List(filter ListFilter, limit, page int) ([]*Attendance, error) {
+	cur, err := s.store.List(filterToBSON(filter), limit, page)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(context.TODO())
+
+	var attendances []*Attendance
+	for cur.Next(context.TODO()) {
+		attendance := &Attendance{}
+		if err := cur.Decode(attendance); err != nil {
+			return nil, err
+		}
+		attendances = append(attendances, attendance)
+	}
+
+	return attendances, nil
+}
+
+func (s *mongoStore) GetCount(memberId string) (int, error) {
+	return s.store.GetCount(memberId)
+}
+
+func (s *mongoStore) GetUniqueMemberCount(days int) (int, error) {
+	return s.store.GetUniqueMemberCount(days)
+}
+
+func (s *mongoStore) Save(a *Attendance) error {
+	attendanceMap := map[string]any{}
+	j, _ := json.Marshal(a)
+	_ = json.Unmarshal(j, &attendanceMap)
+
+	// convert members to just ids for mongo optimization
+	memberIds := make([]string, len(a.Members))
+	for i, member := range a.Members {
+		memberIds[i] = member.Id
+	}
+	attendanceMap["members"] = memberIds
+
+	// convert issues to just ids for mongo optimization
+	issues, _ := attendanceMap["with_issues"].([]any)
+	for i := range issues {
+		issue, _ := issues[i].(map[string]any)
+		issues[i] = issue["id"]
+	}
+	attendanceMap["with_issues"] = issues
+
+	// convert submitted by to just id for mongo optimization
+	attendanceMap["submitted_by"] = a.SubmittedBy.Id
+
+	// convert date_created to mongo datetime
+	attendanceMap["date_created"] = a.DateCreated.UTC()
+
+	// convert date_updated to mongo datetime
+	attendanceMap["date_updated"] = a.DateUpdated.UTC()
+
+	return s.store.Upsert(a.Id, attendanceMap)
+}
+
+func (s *mongoStore) Delete(id string) error {
+	return s.store.Delete(id)
+}
+
+func (s *mongoStore) Watch(ctx context.Context, out chan Attendance) error {
+	stream, err := s.store.Watch(ctx, bson.D{})
+	if err != nil {
+		return err
+	}
+	defer stream.Close(ctx)
+
+	for stream.Next(ctx) {
+		var event struct {
+			DocumentKey struct {
+				Id string `bson:"_id"`
+			} `bson:"documentKey"`
+		}
+		if err := stream.Decode(&event); err != nil {
+			return err
+		}
+
+		// the stored document only holds member ids, so load the populated record
+		attendance, err := s.Get(event.DocumentKey.Id)
+		if err != nil {
+			if errors.Is(err, ErrAttendanceNotFound) {
+				continue
+			}
+			return err
+		}
+
+		out <- *attendance
+	}
+	return ctx.Err()
+}
+
+func filterToBSON(f ListFilter) bson.D {
+	filter := bson.D{}
+
+	or := bson.A{}
+	if f.IncludeUnrecorded {
+		or = append(or, bson.M{"recorded": bson.M{"$eq": false}})
+	}
+	if len(f.Statuses) > 0 {
+		or = append(or, bson.M{"status": bson.M{"$in": f.Statuses}})
+	}
+	if len(or) > 0 {
+		filter = append(filter, bson.E{Key: "$or", Value: or})
+	}
+
+	if f.MemberId != "" {
+		filter = append(filter, bson.E{Key: "members._id", Value: f.MemberId})
+	}
+
+	if !f.Since.IsZero() {
+		filter = append(filter, bson.E{Key: "date_created", Value: bson.M{"$gte": f.Since}})
+	}
+
+	return filter
+}
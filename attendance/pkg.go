@@ -1,8 +1,6 @@
 package attendance
 
 import (
-	"context"
-	"encoding/json"
 	"errors"
 	"regexp"
 	"strings"
@@ -12,9 +10,6 @@ import (
 	"github.com/rs/xid"
 	"github.com/sol-armada/sol-bot/members"
 	"github.com/sol-armada/sol-bot/settings"
-	"github.com/sol-armada/sol-bot/stores"
-	"go.mongodb.org/mongo-driver/bson"
-	"go.mongodb.org/mongo-driver/mongo"
 )
 
 type Status string
@@ -59,15 +54,13 @@ var (
 	ErrAttendanceNotFound = errors.New("attendance not found")
 )
 
-var attendanceStore *stores.AttendanceStore
+var attendanceStore Store
 
-func Setup() error {
-	storesClient := stores.Get()
-	as, ok := storesClient.GetAttendanceStore()
-	if !ok {
+func Setup(store Store) error {
+	if store == nil {
 		return errors.New("attendance store not found")
 	}
-	attendanceStore = as
+	attendanceStore = store
 	return nil
 }
 
@@ -89,28 +82,7 @@ func New(name string, submittedBy *members.Member) *Attendance {
 }
 
 func Get(id string) (*Attendance, error) {
-	cur, err := attendanceStore.Get(id)
-	if err != nil {
-		if errors.Is(err, mongo.ErrNoDocuments) {
-			return nil, ErrAttendanceNotFound
-		}
-
-		return nil, err
-	}
-
-	attendance := &Attendance{}
-
-	for cur.Next(context.TODO()) {
-		if err := cur.Decode(attendance); err != nil {
-			return nil, err
-		}
-	}
-
-	if attendance.Id == "" {
-		return nil, ErrAttendanceNotFound
-	}
-
-	return attendance, nil
+	return attendanceStore.Get(id)
 }
 
 func GetFromMessage(message *discordgo.Message) (*Attendance, error) {
@@ -119,17 +91,8 @@ func GetFromMessage(message *discordgo.Message) (*Attendance, error) {
 	reg := regexp.MustCompile(`Last Updated .*?\((.*?)\)`)
 
 	attendanceId := reg.FindStringSubmatch(message.Embeds[0].Footer.Text)[1]
-	cur, err := attendanceStore.Get(attendanceId)
-	if err != nil {
-		return nil, err
-	}
-
-	attendance := &Attendance{}
-	if err := cur.Decode(attendance); err != nil {
-		return nil, err
-	}
 
-	return attendance, nil
+	return attendanceStore.Get(attendanceId)
 }
 
 func NewFromThreadMessages(threadMessages []*discordgo.Message) (*Attendance, error) {
@@ -170,26 +133,31 @@ func GetMemberAttendanceCount(memberId string) (int, error) {
 	return attendanceStore.GetCount(memberId)
 }
 
-func GetMemberAttendanceRecords(memberId string) ([]*Attendance, error) {
-	records := []*Attendance{}
-
-	cur, err := attendanceStore.List(bson.D{}, 0, 0)
+// GetMemberAttendanceCountSince counts the recorded events the member attended since the time
+func GetMemberAttendanceCountSince(memberId string, since time.Time) (int, error) {
+	records, err := attendanceStore.List(ListFilter{MemberId: memberId, Since: since}, 0, 0)
 	if err != nil {
-		return nil, err
+		return 0, err
 	}
 
-	for cur.Next(context.TODO()) {
-		attendance := &Attendance{}
-		if err := cur.Decode(attendance); err != nil {
-			return nil, err
+	count := 0
+	for _, record := range records {
+		if record.Recorded {
+			count++
 		}
+	}
 
-		for _, member := range attendance.Members {
-			if member.Id == memberId {
-				records = append(records, attendance)
-				break
-			}
-		}
+	return count, nil
+}
+
+func GetMemberAttendanceRecords(memberId string) ([]*Attendance, error) {
+	records, err := attendanceStore.List(ListFilter{MemberId: memberId}, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	if records == nil {
+		records = []*Attendance{}
 	}
 
 	return records, nil
@@ -214,35 +182,7 @@ func (a *Attendance) Save() error {
 	}
 	a.DateUpdated = time.Now().UTC()
 
-	attendanceMap := map[string]any{}
-	j, _ := json.Marshal(a)
-	_ = json.Unmarshal(j, &attendanceMap)
-
-	// convert members to just ids for mongo optimization
-	memberIds := make([]string, len(a.Members))
-	for i, member := range a.Members {
-		memberIds[i] = member.Id
-	}
-	attendanceMap["members"] = memberIds
-
-	// convert issues to just ids for mongo optimization
-	issues, _ := attendanceMap["with_issues"].([]any)
-	for i := range issues {
-		issue, _ := issues[i].(map[string]any)
-		issues[i] = issue["id"]
-	}
-	attendanceMap["with_issues"] = issues
-
-	// convert submitted by to just id for mongo optimization
-	attendanceMap["submitted_by"] = a.SubmittedBy.Id
-
-	// convert date_created to mongo datetime
-	attendanceMap["date_created"] = a.DateCreated.UTC()
-
-	// convert date_updated to mongo datetime
-	attendanceMap["date_updated"] = a.DateUpdated.UTC()
-
-	return attendanceStore.Upsert(a.Id, attendanceMap)
+	return attendanceStore.Save(a)
 }
 
 func (a *Attendance) removeDuplicates() {
@@ -0,0 +1,56 @@
+package attendance
+
+import (
+	"github.com/sol-armada/sol-bot/members"
+	"github.com/sol-armada/sol-bot/ranks"
+)
+
+type Promotion struct {
+	Member   members.Member
+	NextRank ranks.Rank
+	Count    int
+}
+
+// NextRank returns the rank a member has earned with the given attendance count,
+// or ranks.None if they are not due a promotion
+func NextRank(rank ranks.Rank, count int) ranks.Rank {
+	switch {
+	case rank == ranks.Recruit && count >= 3:
+		return ranks.Member
+	case rank == ranks.Member && count >= 10:
+		return ranks.Technician
+	case rank == ranks.Technician && count >= 20:
+		return ranks.Specialist
+	}
+
+	return ranks.None
+}
+
+// ListPromotions returns every ranked member who is due a promotion
+func ListPromotions() ([]Promotion, error) {
+	membersList, err := members.List(0)
+	if err != nil {
+		return nil, err
+	}
+
+	promotions := []Promotion{}
+	for _, member := range membersList {
+		if !member.IsRanked() || member.IsGuest || member.IsAlly || member.IsAffiliate {
+			continue
+		}
+
+		count, err := GetMemberAttendanceCount(member.Id)
+		if err != nil {
+			return nil, err
+		}
+
+		next := NextRank(member.Rank, count)
+		if next == ranks.None {
+			continue
+		}
+
+		promotions = append(promotions, Promotion{Member: member, NextRank: next, Count: count})
+	}
+
+	return promotions, nil
+}
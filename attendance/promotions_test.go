@@ -0,0 +1,107 @@
+package attendance
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/sol-armada/sol-bot/members"
+	"github.com/sol-armada/sol-bot/ranks"
+)
+
+func TestNextRank(t *testing.T) {
+	tests := []struct {
+		name  string
+		rank  ranks.Rank
+		count int
+		want  ranks.Rank
+	}{
+		{name: "recruit not enough", rank: ranks.Recruit, count: 2, want: ranks.None},
+		{name: "recruit to member", rank: ranks.Recruit, count: 3, want: ranks.Member},
+		{name: "member to technician", rank: ranks.Member, count: 10, want: ranks.Technician},
+		{name: "technician to specialist", rank: ranks.Technician, count: 20, want: ranks.Specialist},
+		{name: "specialist is manual", rank: ranks.Specialist, count: 100, want: ranks.None},
+		{name: "guest", rank: ranks.Guest, count: 100, want: ranks.None},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NextRank(tt.rank, tt.count); got != tt.want {
+				t.Errorf("NextRank() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestListPromotions(t *testing.T) {
+	if err := members.Setup(members.NewMemoryStore()); err != nil {
+		t.Fatal(err)
+	}
+	if err := Setup(NewMemoryStore()); err != nil {
+		t.Fatal(err)
+	}
+
+	joined := time.Now().Add(-365 * 24 * time.Hour)
+	recruit := &members.Member{Id: "recruit", Rank: ranks.Recruit, Joined: joined}
+	newRecruit := &members.Member{Id: "new", Rank: ranks.Recruit, Joined: joined}
+	ally := &members.Member{Id: "ally", Rank: ranks.Recruit, IsAlly: true, Joined: joined}
+	for _, m := range []*members.Member{recruit, newRecruit, ally} {
+		if err := m.Save(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	start := time.Now().Add(-30 * 24 * time.Hour)
+	for i := range 3 {
+		a := &Attendance{
+			Id:          fmt.Sprintf("event-%d", i),
+			Recorded:    true,
+			Status:      AttendanceStatusRecorded,
+			DateCreated: start.Add(time.Duration(i) * 24 * time.Hour),
+			Members:     []*members.Member{recruit, ally},
+		}
+		if err := a.Save(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// an event shortly after another one counts as the same event
+	overlap := &Attendance{
+		Id:          "overlap",
+		Recorded:    true,
+		Status:      AttendanceStatusRecorded,
+		DateCreated: start.Add(2 * time.Hour),
+		Members:     []*members.Member{newRecruit},
+	}
+	first := &Attendance{
+		Id:          "first",
+		Recorded:    true,
+		Status:      AttendanceStatusRecorded,
+		DateCreated: start,
+		Members:     []*members.Member{newRecruit},
+	}
+	for _, a := range []*Attendance{overlap, first} {
+		if err := a.Save(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	promotions, err := ListPromotions()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(promotions) != 1 {
+		t.Fatalf("expected 1 promotion, got %d", len(promotions))
+	}
+	if promotions[0].Member.Id != "recruit" || promotions[0].NextRank != ranks.Member || promotions[0].Count != 3 {
+		t.Errorf("unexpected promotion %+v", promotions[0])
+	}
+
+	count, err := GetMemberAttendanceCount("new")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 1 {
+		t.Errorf("expected overlapping events to count once, got %d", count)
+	}
+}
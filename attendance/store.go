@@ -0,0 +1,51 @@
+package attendance
+
+import (
+	"context"
+	"slices"
+	"time"
+)
+
+// Store is the persistence layer the attendance package works against.
+// Records returned by the store have their members, issues and submitter populated.
+type Store interface {
+	Get(id string) (*Attendance, error)
+	List(filter ListFilter, limit, page int) ([]*Attendance, error)
+	GetCount(memberId string) (int, error)
+	GetUniqueMemberCount(days int) (int, error)
+	Save(attendance *Attendance) error
+	Delete(id string) error
+	Watch(ctx context.Context, out chan Attendance) error
+}
+
+// ListFilter narrows the records returned by Store.List. Zero values match everything.
+type ListFilter struct {
+	// Statuses matches records in any of the statuses
+	Statuses []Status
+	// IncludeUnrecorded also matches records that have not been recorded, regardless of status
+	IncludeUnrecorded bool
+	// MemberId matches records the member attended
+	MemberId string
+	// Since matches records created at or after the time
+	Since time.Time
+}
+
+func (f ListFilter) matches(a *Attendance) bool {
+	if len(f.Statuses) > 0 || f.IncludeUnrecorded {
+		if !(f.IncludeUnrecorded && !a.Recorded) && !slices.Contains(f.Statuses, a.Status) {
+			return false
+		}
+	}
+
+	if f.MemberId != "" {
+		if _, ok := a.GetMember(f.MemberId); !ok {
+			return false
+		}
+	}
+
+	if !f.Since.IsZero() && a.DateCreated.Before(f.Since) {
+		return false
+	}
+
+	return true
+}
@@ -1,6 +1,9 @@
 package attendance
 
 import (
+	"slices"
+	"strings"
+
 	"github.com/sol-armada/sol-bot/members"
 	"github.com/sol-armada/sol-bot/ranks"
 	"github.com/sol-armada/sol-bot/settings"
@@ -18,6 +21,15 @@ func Issues(member *members.Member) []string {
 		return issues
 	}
 
+	// enemy affiliations are always reported, whatever else is wrong
+	if member.RSIMember && member.BadAffiliation {
+		issue := "bad affiliation"
+		if len(member.EnemyAffiliations) > 0 {
+			issue += " (" + strings.Join(member.EnemyAffiliations, ", ") + ")"
+		}
+		issues = append(issues, issue)
+	}
+
 	// if member.OnboardedAt == nil {
 	// 	issues = append(issues, "not onboarded")
 	// 	return issues
@@ -43,10 +55,6 @@ func Issues(member *members.Member) []string {
 		return issues
 	}
 
-	if member.RSIMember && member.BadAffiliation {
-		issues = append(issues, "bad affiliation")
-	}
-
 	if member.RSIMember && member.PrimaryOrg == "REDACTED" {
 		issues = append(issues, "redacted org")
 		return issues
@@ -58,7 +66,7 @@ func Issues(member *members.Member) []string {
 	}
 
 	if member.RSIMember && member.IsAlly {
-		issues = []string{}
+		issues = slices.DeleteFunc(issues, func(issue string) bool { return !strings.HasPrefix(issue, "bad affiliation") })
 	}
 
 	// attendedEvents, err := GetMemberAttendanceCount(member.Id)
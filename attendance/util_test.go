@@ -0,0 +1,45 @@
+package attendance
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/sol-armada/sol-bot/members"
+	"github.com/sol-armada/sol-bot/ranks"
+)
+
+func TestIssuesBadAffiliation(t *testing.T) {
+	tests := []struct {
+		name   string
+		member *members.Member
+		want   []string
+	}{
+		{
+			name:   "member in an enemy org",
+			member: &members.Member{RSIMember: true, Rank: ranks.Recruit, BadAffiliation: true, EnemyAffiliations: []string{"BADGUYS"}},
+			want:   []string{"bad affiliation (BADGUYS)"},
+		},
+		{
+			name:   "guest in an enemy org",
+			member: &members.Member{RSIMember: true, IsGuest: true, BadAffiliation: true, EnemyAffiliations: []string{"BADGUYS", "WORSE"}},
+			want:   []string{"bad affiliation (BADGUYS, WORSE)", "guest"},
+		},
+		{
+			name:   "ally in an enemy org",
+			member: &members.Member{RSIMember: true, IsAlly: true, BadAffiliation: true},
+			want:   []string{"bad affiliation"},
+		},
+		{
+			name:   "clean ally",
+			member: &members.Member{RSIMember: true, IsAlly: true},
+			want:   []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Issues(tt.member); !slices.Equal(got, tt.want) {
+				t.Errorf("Issues() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
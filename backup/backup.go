@@ -0,0 +1,149 @@
+package backup
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Backup writes every collection in Collections to w as a gzipped tar of JSON-lines files.
+// Documents are written as canonical extended JSON so types like dates and int64 survive the round trip
+func Backup(ctx context.Context, db *mongo.Database, w io.Writer) (*Manifest, error) {
+	specs, err := db.ListCollectionSpecifications(ctx, bson.D{})
+	if err != nil {
+		return nil, fmt.Errorf("listing collections: %w", err)
+	}
+
+	options := map[string]bson.Raw{}
+	for _, spec := range specs {
+		options[spec.Name] = spec.Options
+	}
+
+	manifest := &Manifest{
+		Version:   VERSION,
+		CreatedAt: time.Now().UTC(),
+		Database:  db.Name(),
+	}
+
+	// the manifest goes first so restores can stream, so collections are staged in temp files
+	staged := map[string]*os.File{}
+	defer func() {
+		for _, f := range staged {
+			_ = f.Close()
+			_ = os.Remove(f.Name())
+		}
+	}()
+
+	for _, name := range Collections {
+		f, err := os.CreateTemp("", "solbot-backup-*.jsonl")
+		if err != nil {
+			return nil, err
+		}
+		staged[name] = f
+
+		count, err := dumpCollection(ctx, db.Collection(name), f)
+		if err != nil {
+			return nil, fmt.Errorf("dumping %s: %w", name, err)
+		}
+
+		c := CollectionManifest{
+			Name:      name,
+			File:      name + ".jsonl",
+			Documents: count,
+		}
+
+		if opts, ok := options[name]; ok && len(opts) > 0 {
+			ext, err := bson.MarshalExtJSON(opts, true, false)
+			if err != nil {
+				return nil, fmt.Errorf("encoding %s options: %w", name, err)
+			}
+			c.Options = string(ext)
+		}
+
+		manifest.Collections = append(manifest.Collections, c)
+	}
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+
+	manifestJSON, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := writeFile(tw, manifestFile, int64(len(manifestJSON)), bytes.NewReader(manifestJSON)); err != nil {
+		return nil, err
+	}
+
+	for _, c := range manifest.Collections {
+		f := staged[c.Name]
+		info, err := f.Stat()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := f.Seek(0, io.SeekStart); err != nil {
+			return nil, err
+		}
+		if err := writeFile(tw, c.File, info.Size(), f); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+
+	return manifest, nil
+}
+
+func dumpCollection(ctx context.Context, c *mongo.Collection, w io.Writer) (int, error) {
+	cur, err := c.Find(ctx, bson.D{})
+	if err != nil {
+		return 0, err
+	}
+	defer cur.Close(ctx)
+
+	bw := bufio.NewWriter(w)
+	count := 0
+	for cur.Next(ctx) {
+		line, err := bson.MarshalExtJSON(cur.Current, true, false)
+		if err != nil {
+			return count, err
+		}
+		if _, err := bw.Write(append(line, '\n')); err != nil {
+			return count, err
+		}
+		count++
+	}
+	if err := cur.Err(); err != nil {
+		return count, err
+	}
+
+	return count, bw.Flush()
+}
+
+func writeFile(tw *tar.Writer, name string, size int64, r io.Reader) error {
+	if err := tw.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    0o644,
+		Size:    size,
+		ModTime: time.Now().UTC(),
+	}); err != nil {
+		return err
+	}
+
+	_, err := io.Copy(tw, r)
+	return err
+}
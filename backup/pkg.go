@@ -0,0 +1,57 @@
+package backup
+
+import (
+	"time"
+
+	"github.com/sol-armada/sol-bot/migrations"
+	"github.com/sol-armada/sol-bot/stores"
+)
+
+// VERSION is the archive format version. Bump it when the layout of the archive changes
+const VERSION = 1
+
+const manifestFile = "manifest.json"
+
+// Collections is every collection included in a backup, in the order they are written
+var Collections = []string{
+	string(stores.MEMBERS),
+	string(stores.ATTENDANCE),
+	string(stores.TOKENS),
+	string(stores.TOKEN_HOLDS),
+	string(stores.RAFFLES),
+	string(stores.GIVEAWAYS),
+	string(stores.APPLICATIONS),
+	string(stores.ALLIES),
+	string(stores.CONFIGS),
+	string(stores.COMMANDS),
+	string(stores.ACTIVITY),
+	string(stores.BLUEPRINT),
+	string(stores.KANBAN),
+	string(stores.SOS),
+	migrations.SCHEMA_MIGRATIONS,
+}
+
+// Manifest describes the contents of an archive. It is always the first file
+type Manifest struct {
+	Version     int                  `json:"version"`
+	CreatedAt   time.Time            `json:"created_at"`
+	Database    string               `json:"database"`
+	Collections []CollectionManifest `json:"collections"`
+}
+
+type CollectionManifest struct {
+	Name      string `json:"name"`
+	File      string `json:"file"`
+	Documents int    `json:"documents"`
+	// Options are the collection creation options as extended JSON, used to recreate time series collections
+	Options string `json:"options,omitempty"`
+}
+
+func (m *Manifest) collection(name string) (*CollectionManifest, bool) {
+	for i := range m.Collections {
+		if m.Collections[i].Name == name {
+			return &m.Collections[i], true
+		}
+	}
+	return nil, false
+}
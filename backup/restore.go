@@ -0,0 +1,182 @@
+package backup
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const insertBatchSize = 500
+
+var (
+	ErrMissingManifest     = errors.New("archive does not start with a manifest")
+	ErrUnsupportedVersion  = errors.New("archive version is not supported")
+	ErrUnknownCollection   = errors.New("collection is not in the archive")
+	ErrDocumentCountDiffer = errors.New("document count does not match the manifest")
+)
+
+type RestoreOptions struct {
+	// DryRun reads and validates the archive without touching the database
+	DryRun bool
+	// Collections limits the restore to the named collections. Empty restores everything
+	Collections []string
+}
+
+type RestoredCollection struct {
+	Name      string
+	Documents int
+}
+
+// Restore reads an archive written by Backup. Each restored collection is dropped and
+// recreated with its original options before the documents are inserted
+func Restore(ctx context.Context, db *mongo.Database, r io.Reader, opts RestoreOptions) (*Manifest, []RestoredCollection, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, nil, fmt.Errorf("opening archive: %w", err)
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+
+	header, err := tr.Next()
+	if err != nil || header.Name != manifestFile {
+		return nil, nil, ErrMissingManifest
+	}
+
+	manifest := &Manifest{}
+	if err := json.NewDecoder(tr).Decode(manifest); err != nil {
+		return nil, nil, fmt.Errorf("reading manifest: %w", err)
+	}
+
+	if manifest.Version > VERSION {
+		return manifest, nil, fmt.Errorf("%w: %d", ErrUnsupportedVersion, manifest.Version)
+	}
+
+	selected := opts.Collections
+	if len(selected) == 0 {
+		for _, c := range manifest.Collections {
+			selected = append(selected, c.Name)
+		}
+	}
+	for _, name := range selected {
+		if _, ok := manifest.collection(name); !ok {
+			return manifest, nil, fmt.Errorf("%w: %s", ErrUnknownCollection, name)
+		}
+	}
+
+	restored := []RestoredCollection{}
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return manifest, restored, err
+		}
+
+		name := strings.TrimSuffix(header.Name, ".jsonl")
+		if !slices.Contains(selected, name) {
+			continue
+		}
+
+		c, _ := manifest.collection(name)
+
+		var coll *mongo.Collection
+		if !opts.DryRun {
+			if coll, err = recreateCollection(ctx, db, c); err != nil {
+				return manifest, restored, fmt.Errorf("recreating %s: %w", name, err)
+			}
+		}
+
+		count, err := loadCollection(ctx, coll, tr)
+		if err != nil {
+			return manifest, restored, fmt.Errorf("restoring %s: %w", name, err)
+		}
+
+		if count != c.Documents {
+			return manifest, restored, fmt.Errorf("%w: %s has %d, expected %d", ErrDocumentCountDiffer, name, count, c.Documents)
+		}
+
+		restored = append(restored, RestoredCollection{Name: name, Documents: count})
+	}
+
+	return manifest, restored, nil
+}
+
+func recreateCollection(ctx context.Context, db *mongo.Database, c *CollectionManifest) (*mongo.Collection, error) {
+	if err := db.Collection(c.Name).Drop(ctx); err != nil {
+		return nil, err
+	}
+
+	create := bson.D{{Key: "create", Value: c.Name}}
+	if c.Options != "" {
+		var options bson.D
+		if err := bson.UnmarshalExtJSON([]byte(c.Options), true, &options); err != nil {
+			return nil, err
+		}
+		create = append(create, options...)
+	}
+
+	if err := db.RunCommand(ctx, create).Err(); err != nil {
+		return nil, err
+	}
+
+	return db.Collection(c.Name), nil
+}
+
+// loadCollection parses every line of r and inserts it into coll in batches. A nil coll only validates
+func loadCollection(ctx context.Context, coll *mongo.Collection, r io.Reader) (int, error) {
+	br := bufio.NewReader(r)
+
+	count := 0
+	batch := []any{}
+	flush := func() error {
+		if coll == nil || len(batch) == 0 {
+			batch = batch[:0]
+			return nil
+		}
+		if _, err := coll.InsertMany(ctx, batch); err != nil {
+			return err
+		}
+		batch = batch[:0]
+		return nil
+	}
+
+	for {
+		line, err := br.ReadBytes('\n')
+		if len(line) > 0 && strings.TrimSpace(string(line)) != "" {
+			var doc bson.D
+			if err := bson.UnmarshalExtJSON(line, true, &doc); err != nil {
+				return count, fmt.Errorf("line %d: %w", count+1, err)
+			}
+
+			batch = append(batch, doc)
+			count++
+
+			if len(batch) >= insertBatchSize {
+				if err := flush(); err != nil {
+					return count, err
+				}
+			}
+		}
+
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return count, err
+		}
+	}
+
+	return count, flush()
+}
@@ -0,0 +1,94 @@
+package backup
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func testArchive(t *testing.T, docs map[string][]bson.D) []byte {
+	t.Helper()
+
+	manifest := Manifest{Version: VERSION, CreatedAt: time.Now().UTC(), Database: "test"}
+	files := map[string][]byte{}
+	for _, name := range Collections {
+		var buf bytes.Buffer
+		for _, doc := range docs[name] {
+			line, err := bson.MarshalExtJSON(doc, true, false)
+			if err != nil {
+				t.Fatal(err)
+			}
+			buf.Write(append(line, '\n'))
+		}
+		files[name] = buf.Bytes()
+		manifest.Collections = append(manifest.Collections, CollectionManifest{
+			Name:      name,
+			File:      name + ".jsonl",
+			Documents: len(docs[name]),
+		})
+	}
+
+	var out bytes.Buffer
+	gz := gzip.NewWriter(&out)
+	tw := tar.NewWriter(gz)
+
+	m, _ := json.Marshal(manifest)
+	if err := writeFile(tw, manifestFile, int64(len(m)), bytes.NewReader(m)); err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range manifest.Collections {
+		if err := writeFile(tw, c.File, int64(len(files[c.Name])), bytes.NewReader(files[c.Name])); err != nil {
+			t.Fatal(err)
+		}
+	}
+	_ = tw.Close()
+	_ = gz.Close()
+
+	return out.Bytes()
+}
+
+func TestRestoreDryRun(t *testing.T) {
+	archive := testArchive(t, map[string][]bson.D{
+		"members": {
+			{{Key: "_id", Value: "1"}, {Key: "joined", Value: time.Now().UTC()}},
+			{{Key: "_id", Value: "2"}, {Key: "dkp_spent", Value: int64(5)}},
+		},
+		"tokens": {
+			{{Key: "_id", Value: "t1"}, {Key: "member_id", Value: "1"}, {Key: "amount", Value: 10}},
+		},
+	})
+
+	manifest, restored, err := Restore(context.Background(), nil, bytes.NewReader(archive), RestoreOptions{
+		DryRun:      true,
+		Collections: []string{"members"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if manifest.Database != "test" {
+		t.Errorf("expected database test, got %s", manifest.Database)
+	}
+	if len(restored) != 1 || restored[0].Name != "members" || restored[0].Documents != 2 {
+		t.Errorf("unexpected restore result %+v", restored)
+	}
+}
+
+func TestRestoreUnknownCollection(t *testing.T) {
+	archive := testArchive(t, nil)
+
+	_, _, err := Restore(context.Background(), nil, bytes.NewReader(archive), RestoreOptions{
+		DryRun:      true,
+		Collections: []string{"nope"},
+	})
+	if !errors.Is(err, ErrUnknownCollection) {
+		t.Errorf("expected ErrUnknownCollection, got %v", err)
+	}
+}
@@ -0,0 +1,30 @@
+package bot
+
+import (
+	"log/slog"
+
+	"github.com/sol-armada/sol-bot/members"
+)
+
+// reconcileAlly records the ally org of verified members whose primary org is a registered ally, and clears it once
+// they no longer qualify. The ally role follows AllyOrg through roleSync and the {SID} nick tag through normalizeNickname,
+// both under their configured mode
+func reconcileAlly(member *members.Member, allyRoleID string, logger *slog.Logger) {
+	if allyRoleID == "" {
+		return
+	}
+
+	qualifies := member.Validated && member.RSIMember && member.IsAlly && !member.IsAffiliate
+
+	sid := ""
+	if qualifies {
+		sid = member.PrimaryOrg
+	}
+
+	if sid != member.AllyOrg {
+		logger.Info("ally org changed", "from", member.AllyOrg, "to", sid)
+	}
+
+	member.AllyOrg = sid
+	member.IsAlly = qualifies
+}
@@ -0,0 +1,71 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/sol-armada/sol-bot/applications"
+	"github.com/sol-armada/sol-bot/customerrors"
+	"github.com/sol-armada/sol-bot/utils"
+)
+
+var applicationButtonHandlers = map[string]Handler{
+	"accept": acceptApplicationButtonHandler,
+	"reject": rejectApplicationButtonHandler,
+}
+
+func acceptApplicationButtonHandler(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	return decideApplication(ctx, s, i, applications.StatusAccepted)
+}
+
+func rejectApplicationButtonHandler(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	return decideApplication(ctx, s, i, applications.StatusRejected)
+}
+
+func decideApplication(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate, status applications.Status) error {
+	logger := utils.GetLoggerFromContext(ctx)
+
+	if !utils.Allowed(i.Member, "APPLICATIONS") {
+		return InvalidPermissions
+	}
+
+	applicationId := strings.Split(i.MessageComponentData().CustomID, ":")[2]
+	logger.Debug("deciding application", "application_id", applicationId, "status", status)
+
+	a, err := applications.Decide(s, applicationId, status, i.Member.User.ID)
+	switch {
+	case errors.Is(err, applications.ErrAlreadyDecided):
+		customerrors.ErrorResponse(s, i.Interaction, fmt.Sprintf("<@%s> already %s this application", a.DecidedBy, a.Status), nil)
+		return nil
+	case errors.Is(err, applications.ErrApplicationNotFound):
+		customerrors.ErrorResponse(s, i.Interaction, "That application no longer exists", nil)
+		return nil
+	case err != nil && a == nil:
+		return err
+	}
+
+	verb := "accept"
+	if a.Status == applications.StatusRejected {
+		verb = "reject"
+	}
+
+	content := fmt.Sprintf("Application %s. Remember to %s it on RSI too.", a.Status, verb)
+	if err != nil {
+		// the decision was saved, only the DM failed
+		logger.Warn("failed to message applicant", "application_id", a.Id, "error", err)
+		content += " I couldn't DM the applicant, please let them know."
+	} else if a.MemberId == "" {
+		content += " They aren't on Discord, so they were not messaged."
+	}
+
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: content,
+			Flags:   discordgo.MessageFlagsEphemeral,
+		},
+	})
+}
@@ -0,0 +1,279 @@
+package bot
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/pkg/errors"
+)
+
+// canonicalCommand is the part of a command definition we control. Registered commands come back
+// from discord with ids, versions and defaults filled in, so both sides are reduced to this before diffing
+type canonicalCommand struct {
+	Type                     discordgo.ApplicationCommandType `json:"type"`
+	Name                     string                           `json:"name"`
+	Description              string                           `json:"description,omitempty"`
+	DefaultMemberPermissions *int64                           `json:"default_member_permissions,omitempty"`
+	NSFW                     bool                             `json:"nsfw,omitempty"`
+	Options                  []canonicalOption                `json:"options,omitempty"`
+}
+
+type canonicalOption struct {
+	Type         discordgo.ApplicationCommandOptionType `json:"type"`
+	Name         string                                 `json:"name"`
+	Description  string                                 `json:"description,omitempty"`
+	Required     bool                                   `json:"required,omitempty"`
+	Autocomplete bool                                   `json:"autocomplete,omitempty"`
+	ChannelTypes []discordgo.ChannelType                `json:"channel_types,omitempty"`
+	Choices      []canonicalChoice                      `json:"choices,omitempty"`
+	MinValue     *float64                               `json:"min_value,omitempty"`
+	MaxValue     float64                                `json:"max_value,omitempty"`
+	MinLength    *int                                   `json:"min_length,omitempty"`
+	MaxLength    int                                    `json:"max_length,omitempty"`
+	Options      []canonicalOption                      `json:"options,omitempty"`
+}
+
+type canonicalChoice struct {
+	Name string `json:"name"`
+	// discord returns numbers as floats, so values are compared as text
+	Value string `json:"value"`
+}
+
+type commandDiff struct {
+	Added   []string
+	Removed []string
+	// Changed maps a command name to the paths that differ
+	Changed map[string][]string
+}
+
+func (d commandDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// desiredCommands returns every command and alias definition, sorted by name. Commands whose setup returns nil
+// are left out, which removes them from discord on the next overwrite
+func desiredCommands(logger *slog.Logger) ([]*discordgo.ApplicationCommand, error) {
+	desired := []*discordgo.ApplicationCommand{}
+
+	for _, cmd := range commands {
+		cmdData, err := cmd.Setup()
+		if err != nil {
+			return nil, errors.Wrap(err, "setting up command "+cmd.Name())
+		}
+
+		if cmdData == nil {
+			logger.Warn("command setup returned nil", "command", cmd.Name())
+			continue
+		}
+
+		desired = append(desired, cmdData)
+
+		cmdAliases, err := cmd.SetupAliases()
+		if err != nil {
+			return nil, errors.Wrap(err, "setting up command aliases "+cmd.Name())
+		}
+
+		desired = append(desired, cmdAliases...)
+	}
+
+	sort.Slice(desired, func(i, j int) bool { return desired[i].Name < desired[j].Name })
+
+	return desired, nil
+}
+
+// syncCommands registers the desired commands, only overwriting them when they differ from what discord has
+func (b *Bot) syncCommands() error {
+	desired, err := desiredCommands(b.logger)
+	if err != nil {
+		return err
+	}
+
+	registered, err := b.ApplicationCommands(b.ClientId, b.GuildId)
+	if err != nil {
+		return errors.Wrap(err, "getting registered commands")
+	}
+
+	diff := diffCommands(desired, registered)
+	if diff.Empty() {
+		b.logger.Info("slash commands up to date", "count", len(desired))
+		return nil
+	}
+
+	for _, name := range diff.Added {
+		b.logger.Info("slash command added", "command", name)
+	}
+	for _, name := range diff.Removed {
+		b.logger.Info("slash command removed", "command", name)
+	}
+	for name, paths := range diff.Changed {
+		b.logger.Info("slash command changed", "command", name, "fields", paths)
+	}
+
+	if _, err := b.ApplicationCommandBulkOverwrite(b.ClientId, b.GuildId, desired); err != nil {
+		return errors.Wrap(err, "overwriting commands")
+	}
+
+	b.logger.Info("slash commands synced", "count", len(desired))
+	return nil
+}
+
+func diffCommands(desired, registered []*discordgo.ApplicationCommand) commandDiff {
+	diff := commandDiff{Changed: map[string][]string{}}
+
+	have := map[string]canonicalCommand{}
+	for _, cmd := range registered {
+		have[cmd.Name] = canonicalize(cmd)
+	}
+
+	want := map[string]canonicalCommand{}
+	for _, cmd := range desired {
+		want[cmd.Name] = canonicalize(cmd)
+	}
+
+	for name, w := range want {
+		h, ok := have[name]
+		if !ok {
+			diff.Added = append(diff.Added, name)
+			continue
+		}
+
+		if !reflect.DeepEqual(w, h) {
+			diff.Changed[name] = diffPaths("", toGeneric(w), toGeneric(h))
+		}
+	}
+
+	for name := range have {
+		if _, ok := want[name]; !ok {
+			diff.Removed = append(diff.Removed, name)
+		}
+	}
+
+	slices.Sort(diff.Added)
+	slices.Sort(diff.Removed)
+
+	return diff
+}
+
+func canonicalize(cmd *discordgo.ApplicationCommand) canonicalCommand {
+	c := canonicalCommand{
+		Type:                     cmd.Type,
+		Name:                     cmd.Name,
+		Description:              cmd.Description,
+		DefaultMemberPermissions: cmd.DefaultMemberPermissions,
+		Options:                  canonicalizeOptions(cmd.Options),
+	}
+
+	if c.Type == 0 {
+		c.Type = discordgo.ChatApplicationCommand
+	}
+
+	if cmd.NSFW != nil {
+		c.NSFW = *cmd.NSFW
+	}
+
+	return c
+}
+
+func canonicalizeOptions(options []*discordgo.ApplicationCommandOption) []canonicalOption {
+	if len(options) == 0 {
+		return nil
+	}
+
+	out := make([]canonicalOption, 0, len(options))
+	for _, o := range options {
+		if o == nil {
+			continue
+		}
+
+		co := canonicalOption{
+			Type:         o.Type,
+			Name:         o.Name,
+			Description:  o.Description,
+			Required:     o.Required,
+			Autocomplete: o.Autocomplete,
+			MinValue:     o.MinValue,
+			MaxValue:     o.MaxValue,
+			MinLength:    o.MinLength,
+			MaxLength:    o.MaxLength,
+			Options:      canonicalizeOptions(o.Options),
+		}
+
+		if len(o.ChannelTypes) > 0 {
+			co.ChannelTypes = slices.Clone(o.ChannelTypes)
+		}
+
+		for _, choice := range o.Choices {
+			co.Choices = append(co.Choices, canonicalChoice{Name: choice.Name, Value: fmt.Sprint(choice.Value)})
+		}
+
+		out = append(out, co)
+	}
+
+	return out
+}
+
+func toGeneric(v any) any {
+	b, _ := json.Marshal(v)
+	var out any
+	_ = json.Unmarshal(b, &out)
+	return out
+}
+
+// diffPaths returns the paths where want and have differ, like options[0].description
+func diffPaths(path string, want, have any) []string {
+	if reflect.DeepEqual(want, have) {
+		return nil
+	}
+
+	join := func(key string) string {
+		if path == "" {
+			return key
+		}
+		return path + "." + key
+	}
+
+	switch w := want.(type) {
+	case map[string]any:
+		h, ok := have.(map[string]any)
+		if !ok {
+			break
+		}
+
+		keys := map[string]bool{}
+		for k := range w {
+			keys[k] = true
+		}
+		for k := range h {
+			keys[k] = true
+		}
+
+		paths := []string{}
+		for k := range keys {
+			paths = append(paths, diffPaths(join(k), w[k], h[k])...)
+		}
+		sort.Strings(paths)
+		return paths
+	case []any:
+		h, ok := have.([]any)
+		if !ok || len(w) != len(h) {
+			break
+		}
+
+		paths := []string{}
+		for i := range w {
+			paths = append(paths, diffPaths(fmt.Sprintf("%s[%d]", path, i), w[i], h[i])...)
+		}
+		return paths
+	}
+
+	if path == "" {
+		return []string{"(root)"}
+	}
+	return []string{strings.TrimPrefix(path, ".")}
+}
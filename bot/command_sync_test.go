@@ -0,0 +1,56 @@
+package bot
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+func TestDiffCommands(t *testing.T) {
+	desired := []*discordgo.ApplicationCommand{
+		{
+			Name:        "raffle",
+			Description: "Start a raffle",
+			Options: []*discordgo.ApplicationCommandOption{
+				{Type: discordgo.ApplicationCommandOptionInteger, Name: "tickets", Description: "Tickets", Choices: []*discordgo.ApplicationCommandOptionChoice{{Name: "one", Value: 1}}},
+			},
+		},
+		{Name: "profile", Description: "Show a profile"},
+		{Name: "bp", Description: "Blueprints"},
+	}
+
+	registered := []*discordgo.ApplicationCommand{
+		{
+			ID:          "1",
+			Version:     "10",
+			Type:        discordgo.ChatApplicationCommand,
+			Name:        "raffle",
+			Description: "Start a raffle",
+			Options: []*discordgo.ApplicationCommandOption{
+				{Type: discordgo.ApplicationCommandOptionInteger, Name: "tickets", Description: "Tickets", Choices: []*discordgo.ApplicationCommandOptionChoice{{Name: "one", Value: float64(1)}}},
+			},
+		},
+		{ID: "2", Type: discordgo.ChatApplicationCommand, Name: "profile", Description: "Show your profile"},
+		{ID: "3", Type: discordgo.ChatApplicationCommand, Name: "old", Description: "Removed"},
+	}
+
+	diff := diffCommands(desired, registered)
+
+	if !slices.Equal(diff.Added, []string{"bp"}) {
+		t.Errorf("Added = %v, want [bp]", diff.Added)
+	}
+	if !slices.Equal(diff.Removed, []string{"old"}) {
+		t.Errorf("Removed = %v, want [old]", diff.Removed)
+	}
+	if _, ok := diff.Changed["raffle"]; ok {
+		t.Errorf("raffle should be unchanged, got %v", diff.Changed["raffle"])
+	}
+	if got := diff.Changed["profile"]; !slices.Equal(got, []string{"description"}) {
+		t.Errorf("Changed[profile] = %v, want [description]", got)
+	}
+
+	if !diffCommands(desired, desired).Empty() {
+		t.Error("diffing a set against itself should be empty")
+	}
+}
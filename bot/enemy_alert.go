@@ -0,0 +1,75 @@
+package bot
+
+import (
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/sol-armada/sol-bot/members"
+	"github.com/sol-armada/sol-bot/rsi"
+	"github.com/sol-armada/sol-bot/settings"
+)
+
+// checkEnemyAffiliations flags the member if they are in an enemy org and alerts the officers
+// when they joined or affiliated with one since the last check. A nil enemy list skips the check
+func checkEnemyAffiliations(member *members.Member, enemies []string, logger *slog.Logger) {
+	if enemies == nil || !member.RSIMember {
+		return
+	}
+
+	current := member.FindEnemyOrgs(enemies)
+
+	joined := []string{}
+	for _, org := range current {
+		if !slices.Contains(member.EnemyAffiliations, org) {
+			joined = append(joined, org)
+		}
+	}
+
+	member.EnemyAffiliations = current
+	member.BadAffiliation = len(current) > 0
+
+	if len(joined) == 0 {
+		return
+	}
+
+	logger.Info("member joined an enemy org", "orgs", joined)
+	if err := alertEnemyAffiliation(member, joined); err != nil {
+		logger.Error("sending enemy affiliation alert", "error", err)
+	}
+}
+
+func alertEnemyAffiliation(member *members.Member, orgs []string) error {
+	channelId := settings.GetString("ENEMY_ALERT_CHANNEL_ID")
+	if channelId == "" || bot == nil {
+		return nil
+	}
+
+	primary := member.PrimaryOrg
+	if primary == "" {
+		primary = "None"
+	}
+
+	affiliations := strings.Join(member.Affilations, ", ")
+	if affiliations == "" {
+		affiliations = "None"
+	}
+
+	_, err := bot.ChannelMessageSendComplex(channelId, &discordgo.MessageSend{
+		Embeds: []*discordgo.MessageEmbed{
+			{
+				Title:       "Enemy Affiliation",
+				Description: fmt.Sprintf("<@%s> is in %s", member.Id, strings.Join(orgs, ", ")),
+				Color:       0xFF0000,
+				Fields: []*discordgo.MessageEmbedField{
+					{Name: "RSI Profile", Value: rsi.UserProfileURL(member.Handle())},
+					{Name: "Primary Org", Value: primary, Inline: true},
+					{Name: "Affiliations", Value: affiliations, Inline: true},
+				},
+			},
+		},
+	})
+	return err
+}
@@ -17,7 +17,15 @@ func end(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCrea
 
 	giveawayId := strings.Split(i.MessageComponentData().CustomID, ":")[2]
 
-	g := giveaway.GetGiveaway(giveawayId)
+	g, err := giveaway.Get(giveawayId)
+	if err != nil {
+		return err
+	}
+
+	if g.Ended {
+		return endedResponse(s, i)
+	}
+
 	if err := g.End(); err != nil {
 		return err
 	}
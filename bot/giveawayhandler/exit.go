@@ -2,6 +2,7 @@ package giveawayhandler
 
 import (
 	"context"
+	"errors"
 	"strings"
 
 	"github.com/bwmarrin/discordgo"
@@ -15,8 +16,17 @@ func exit(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCre
 
 	giveawayId := strings.Split(i.MessageComponentData().CustomID, ":")[2]
 
-	g := giveaway.GetGiveaway(giveawayId)
-	g.RemoveMemberFromItems(i.Member.User.ID)
+	g, err := giveaway.Get(giveawayId)
+	if err != nil {
+		return err
+	}
+
+	if _, err := g.RemoveMemberFromItems(i.Member.User.ID); err != nil {
+		if errors.Is(err, giveaway.ErrGiveawayEnded) {
+			return endedResponse(s, i)
+		}
+		return err
+	}
 
 	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseChannelMessageWithSource,
@@ -2,6 +2,7 @@ package giveawayhandler
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"strings"
@@ -156,7 +157,7 @@ func (g *GiveawayCommand) Setup() (*discordgo.ApplicationCommand, error) {
 		option := &discordgo.ApplicationCommandOption{
 			Type:         discordgo.ApplicationCommandOptionString,
 			Name:         fmt.Sprintf("item-%d", i+1),
-			Description:  "The item to add. Use colon to separate the name and amount. Example: item:amount",
+			Description:  "Item with amount and optional rules. Example: item:2:rank=member,events=3/30,rsi,members,cost=10",
 			Autocomplete: true,
 		}
 
@@ -167,6 +168,25 @@ func (g *GiveawayCommand) Setup() (*discordgo.ApplicationCommand, error) {
 		options = append(options, option)
 	}
 
+	options = append(options,
+		&discordgo.ApplicationCommandOption{
+			Type:        discordgo.ApplicationCommandOptionString,
+			Name:        "weighting",
+			Description: "How entries are weighted in the draw. Defaults to equal odds",
+			Choices: []*discordgo.ApplicationCommandOptionChoice{
+				{Name: "Equal", Value: string(giveaway.WeightEqual)},
+				{Name: "Events attended", Value: string(giveaway.WeightAttendance)},
+				{Name: "Tokens spent", Value: string(giveaway.WeightTokens)},
+			},
+		},
+		&discordgo.ApplicationCommandOption{
+			Type:        discordgo.ApplicationCommandOptionInteger,
+			Name:        "weighting-days",
+			Description: "Only count events or tokens from the last N days. Defaults to all time",
+			MinValue:    new(1.0),
+		},
+	)
+
 	return &discordgo.ApplicationCommand{
 		Name:        "giveaway",
 		Description: "Start a giveaway",
@@ -186,5 +206,12 @@ func checkExists(ctx context.Context, i *discordgo.InteractionCreate) bool {
 	data := i.MessageComponentData()
 	giveawayId := strings.Split(data.CustomID, ":")[2]
 
-	return giveaway.GetGiveaway(giveawayId) != nil
+	_, err := giveaway.Get(giveawayId)
+	return !errors.Is(err, giveaway.ErrGiveawayNotFound)
+}
+
+// endedResponse tells the member they clicked on a giveaway that has already been drawn
+func endedResponse(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	customerrors.ErrorResponse(s, i.Interaction, "This giveaway has ended", nil)
+	return nil
 }
@@ -2,6 +2,7 @@ package giveawayhandler
 
 import (
 	"context"
+	"fmt"
 	"strconv"
 	"strings"
 
@@ -26,7 +27,18 @@ func start(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCr
 	items := make([]*giveaway.Item, 0)
 	name := ""
 	timer := 0
+	weighting := giveaway.Weighting{}
 	for _, opt := range data.Options {
+		if opt.Name == "weighting" {
+			weighting.Mode = giveaway.WeightMode(opt.StringValue())
+			continue
+		}
+
+		if opt.Name == "weighting-days" {
+			weighting.Days = int(opt.IntValue())
+			continue
+		}
+
 		if opt.Name == "name" {
 			name = opt.StringValue()
 			continue
@@ -49,9 +61,9 @@ func start(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCr
 			continue
 		}
 
-		itemValue := strings.Split(opt.StringValue(), ":")
+		itemValue := strings.SplitN(opt.StringValue(), ":", 3)
 		amount := 1
-		if len(itemValue) == 2 {
+		if len(itemValue) >= 2 {
 			a, err := strconv.Atoi(itemValue[1])
 			if err != nil {
 				customerrors.ErrorResponse(s, i.Interaction, "Invalid giveaway item count. Please use whole numbers only", nil)
@@ -60,10 +72,21 @@ func start(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCr
 			amount = a
 		}
 
+		eligibility := giveaway.Eligibility{}
+		if len(itemValue) == 3 {
+			e, err := giveaway.ParseEligibility(itemValue[2])
+			if err != nil {
+				customerrors.ErrorResponse(s, i.Interaction, fmt.Sprintf("Invalid rules for %s: %s", itemValue[0], err), nil)
+				return nil
+			}
+			eligibility = e
+		}
+
 		item := &giveaway.Item{
-			Id:     xid.New().String(),
-			Name:   itemValue[0],
-			Amount: amount,
+			Id:          xid.New().String(),
+			Name:        itemValue[0],
+			Amount:      amount,
+			Eligibility: eligibility,
 		}
 
 		if item.Name == "NONE" {
@@ -88,7 +111,13 @@ func start(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCr
 		customerrors.ErrorResponse(s, i.Interaction, "Invalid giveaway", nil)
 		return nil
 	}
+	if err := weighting.Validate(); err != nil {
+		customerrors.ErrorResponse(s, i.Interaction, "Invalid weighting", nil)
+		return nil
+	}
+
 	g.SetEndTime(timer)
+	g.Weighting = weighting
 	g.ChannelId = i.ChannelID
 
 	msg, err := s.ChannelMessageSendComplex(i.ChannelID, &discordgo.MessageSend{
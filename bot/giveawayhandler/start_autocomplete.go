@@ -10,7 +10,6 @@ import (
 	"github.com/lithammer/fuzzysearch/fuzzy"
 	"github.com/sol-armada/sol-bot/attendance"
 	"github.com/sol-armada/sol-bot/utils"
-	"go.mongodb.org/mongo-driver/bson"
 )
 
 func startAutocomplete(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) error {
@@ -24,7 +23,7 @@ func startAutocomplete(ctx context.Context, s *discordgo.Session, i *discordgo.I
 	items := utils.GetItemNames()
 	for _, option := range data.Options {
 		if option.Name == "name" && option.Focused {
-			attendanceRecords, err := attendance.List(bson.D{}, 10, 0)
+			attendanceRecords, err := attendance.List(attendance.ListFilter{}, 10, 0)
 			if err != nil {
 				return errors.Join(err, errors.New("getting active attendance records"))
 			}
@@ -46,7 +45,7 @@ func startAutocomplete(ctx context.Context, s *discordgo.Session, i *discordgo.I
 
 				for _, name := range matches {
 					if len(typedS) > 1 {
-						name = name + ":" + typedS[1]
+						name = name + ":" + strings.Join(typedS[1:], ":")
 					}
 
 					choices = append(choices, &discordgo.ApplicationCommandOptionChoice{
@@ -2,12 +2,16 @@ package giveawayhandler
 
 import (
 	"context"
+	"errors"
+	"maps"
+	"slices"
 	"strings"
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/sol-armada/sol-bot/customerrors"
 	"github.com/sol-armada/sol-bot/giveaway"
 	"github.com/sol-armada/sol-bot/members"
+	"github.com/sol-armada/sol-bot/tokens"
 	"github.com/sol-armada/sol-bot/utils"
 )
 
@@ -18,14 +22,34 @@ func updateEntry(ctx context.Context, s *discordgo.Session, i *discordgo.Interac
 
 	member := utils.GetMemberFromContext(ctx).(*members.Member)
 
-	g := giveaway.GetGiveaway(giveawayId)
+	g, err := giveaway.Get(giveawayId)
+	if err != nil {
+		return err
+	}
 
 	if !g.CanParticipate(member.Id) {
 		customerrors.ErrorResponse(s, i.Interaction, "You did not attend this event! You don't qualify for this giveaway.", nil)
 		return nil
 	}
 
-	if _, err := g.AddMemberToItems(entries, member.Id); err != nil {
+	ineligible, err := g.CheckEligibility(member, entries)
+	if err != nil {
+		return err
+	}
+	if len(ineligible) > 0 {
+		customerrors.ErrorResponse(s, i.Interaction, ineligibleMessage(ineligible), nil)
+		return nil
+	}
+
+	g, err = g.AddMemberToItems(entries, member.Id)
+	if err != nil {
+		if errors.Is(err, giveaway.ErrGiveawayEnded) {
+			return endedResponse(s, i)
+		}
+		if errors.Is(err, tokens.ErrInsufficientTokens) {
+			customerrors.ErrorResponse(s, i.Interaction, "You don't have enough available tokens for those items", nil)
+			return nil
+		}
 		return err
 	}
 
@@ -42,3 +66,18 @@ func updateEntry(ctx context.Context, s *discordgo.Session, i *discordgo.Interac
 		},
 	})
 }
+
+// ineligibleMessage explains which of the picked items the member can't enter and why
+func ineligibleMessage(ineligible map[string][]string) string {
+	var msg strings.Builder
+	msg.WriteString("You can't enter some of the items you picked, so your entries were not changed.\n")
+
+	for _, name := range slices.Sorted(maps.Keys(ineligible)) {
+		msg.WriteString("\n**" + name + "**\n")
+		for _, reason := range ineligible[name] {
+			msg.WriteString("- " + reason + "\n")
+		}
+	}
+
+	return msg.String()
+}
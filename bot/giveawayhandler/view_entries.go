@@ -2,6 +2,7 @@ package giveawayhandler
 
 import (
 	"context"
+	"errors"
 	"strings"
 
 	"github.com/bwmarrin/discordgo"
@@ -16,10 +17,12 @@ func viewEntries(ctx context.Context, s *discordgo.Session, i *discordgo.Interac
 
 	giveawayId := strings.Split(i.MessageComponentData().CustomID, ":")[2]
 
-	g := giveaway.GetGiveaway(giveawayId)
-
-	if g == nil {
-		return customerrors.InvalidGiveaway
+	g, err := giveaway.Get(giveawayId)
+	if err != nil {
+		if errors.Is(err, giveaway.ErrGiveawayNotFound) {
+			return customerrors.InvalidGiveaway
+		}
+		return err
 	}
 
 	if !g.CanParticipate(member.Id) {
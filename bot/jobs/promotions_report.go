@@ -7,8 +7,6 @@ import (
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/sol-armada/sol-bot/attendance"
-	"github.com/sol-armada/sol-bot/members"
-	"github.com/sol-armada/sol-bot/ranks"
 	"github.com/sol-armada/sol-bot/settings"
 	"github.com/sol-armada/sol-bot/utils"
 )
@@ -23,6 +21,10 @@ var Jobs = []job{
 		Name: "Promotions Report",
 		Run:  promotionsReport,
 	},
+	{
+		Name: "RSI Roster Report",
+		Run:  rosterReport,
+	},
 }
 
 func promotionsReport(ctx context.Context, s *discordgo.Session) error {
@@ -56,48 +58,12 @@ func promotionsReport(ctx context.Context, s *discordgo.Session) error {
 		}
 	}
 
-	// get members
-	membersList, err := members.List(0)
+	// check if any members need to rank up
+	needsRankUp, err := attendance.ListPromotions()
 	if err != nil {
 		return err
 	}
 
-	// check if any members need to rank up
-	type t struct {
-		Member   members.Member
-		NextRank ranks.Rank
-		Count    int
-	}
-	needsRankUp := []t{}
-	for _, member := range membersList {
-		if !member.IsRanked() || member.IsGuest || member.IsAlly || member.IsAffiliate {
-			continue
-		}
-
-		logger.Debug("checking if member needs rank up", "member", member.Id)
-
-		count, err := attendance.GetMemberAttendanceCount(member.Id)
-		if err != nil {
-			return err
-		}
-
-		tt := t{Member: member, Count: count}
-
-		if member.Rank == ranks.Recruit && count >= 3 {
-			tt.NextRank = ranks.Member
-		}
-		if member.Rank == ranks.Member && count >= 10 {
-			tt.NextRank = ranks.Technician
-		}
-		if member.Rank == ranks.Technician && count >= 20 {
-			tt.NextRank = ranks.Specialist
-		}
-
-		if tt.NextRank != 0 {
-			needsRankUp = append(needsRankUp, tt)
-		}
-	}
-
 	if len(needsRankUp) == 0 {
 		_, err = s.ChannelMessageSend(channelId, "No promotion actions needed today")
 		return err
@@ -0,0 +1,98 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/sol-armada/sol-bot/members"
+	"github.com/sol-armada/sol-bot/rsi"
+	"github.com/sol-armada/sol-bot/settings"
+	"github.com/sol-armada/sol-bot/utils"
+)
+
+// embed field values are capped at 1024 characters
+const maxFieldLength = 1024
+
+func rosterReport(ctx context.Context, s *discordgo.Session) error {
+	logger := utils.GetLoggerFromContext(ctx)
+	logger.Debug("rsi roster report job")
+
+	channelId := settings.GetString("ROSTER_CHANNEL_ID")
+	if channelId == "" {
+		logger.Debug("roster channel id not set, skipping roster report")
+		return nil
+	}
+
+	client, err := rsi.NewDefaultClient()
+	if err != nil {
+		return err
+	}
+
+	roster, err := client.GetRoster(ctx)
+	if err != nil {
+		return err
+	}
+
+	mmbrs, err := members.ListAll()
+	if err != nil {
+		return err
+	}
+
+	report := rsi.Reconcile(settings.GetString("rsi_org_sid"), roster, mmbrs)
+
+	_, err = s.ChannelMessageSendEmbed(channelId, rosterReportEmbed(len(roster), report))
+	return err
+}
+
+func rosterReportEmbed(rosterSize int, report *rsi.RosterReport) *discordgo.MessageEmbed {
+	notOnDiscord := make([]string, len(report.NotOnDiscord))
+	for i, rm := range report.NotOnDiscord {
+		notOnDiscord[i] = fmt.Sprintf("[%s](%s) (%s)", rm.Handle, rsi.UserProfileURL(rm.Handle), rm.Rank)
+	}
+
+	notOnRoster := make([]string, len(report.NotOnRoster))
+	for i, member := range report.NotOnRoster {
+		notOnRoster[i] = fmt.Sprintf("<@%s> (%s)", member.Id, member.Name)
+	}
+
+	mismatches := make([]string, len(report.RankMismatches))
+	for i, mismatch := range report.RankMismatches {
+		mismatches[i] = fmt.Sprintf("<@%s> is %s, RSI says %s", mismatch.Member.Id, mismatch.Member.Rank.String(), mismatch.RosterRank)
+	}
+
+	description := fmt.Sprintf("%d members on the RSI roster.", rosterSize)
+	if report.Unlisted > 0 {
+		description += fmt.Sprintf(" %d are redacted or hidden and could not be matched, so some members below may be among them.", report.Unlisted)
+	}
+
+	return &discordgo.MessageEmbed{
+		Title:       "RSI Roster Report",
+		Description: description,
+		Fields: []*discordgo.MessageEmbedField{
+			{Name: fmt.Sprintf("In the org, not on Discord (%d)", len(notOnDiscord)), Value: listValue(notOnDiscord)},
+			{Name: fmt.Sprintf("On Discord, not in the org (%d)", len(notOnRoster)), Value: listValue(notOnRoster)},
+			{Name: fmt.Sprintf("Rank mismatches (%d)", len(mismatches)), Value: listValue(mismatches)},
+		},
+	}
+}
+
+// listValue joins the lines to fit in a field, noting how many were left off
+func listValue(lines []string) string {
+	if len(lines) == 0 {
+		return "None"
+	}
+
+	var value strings.Builder
+	for i, line := range lines {
+		more := fmt.Sprintf("...and %d more", len(lines)-i)
+		if value.Len()+len(line)+1 > maxFieldLength-len(more) {
+			value.WriteString(more)
+			break
+		}
+		value.WriteString(line + "\n")
+	}
+
+	return value.String()
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/pkg/errors"
+	"github.com/sol-armada/sol-bot/config"
 	"github.com/sol-armada/sol-bot/health"
 	"github.com/sol-armada/sol-bot/members"
 	"github.com/sol-armada/sol-bot/rsi"
@@ -167,10 +168,18 @@ func updateMembers(ctx context.Context, logger *slog.Logger, discordMembers []*d
 	// Cache role IDs for efficiency - moved outside loop
 	recruitRoleID := settings.GetString("DISCORD.ROLE_IDS.RECRUIT")
 	allyRoleID := settings.GetString("DISCORD.ROLE_IDS.ALLY")
+	nickMode := nicknameMode()
+	roles := newRoleSync()
 
 	// Track processing errors
 	var processingErrors []error
 
+	// without the enemy list the affiliation check is skipped rather than clearing everyone's flag
+	enemies, err := config.GetEnemyOrgs()
+	if err != nil {
+		logger.Error("getting enemy orgs", "error", err)
+	}
+
 	// Create RSI backoff for rate limiting with skip condition for user not found errors
 	rsiBackoff := utils.NewExponentialBackoffWithSkipCondition(
 		1*time.Second,  // initial delay
@@ -179,8 +188,8 @@ func updateMembers(ctx context.Context, logger *slog.Logger, discordMembers []*d
 		rsiAPIRetries,  // max retries for RSI
 		logger,
 		func(err error) bool {
-			// Skip retries for 404/user not found errors or 403/forbidden errors
-			return errors.Is(err, rsi.ErrUserNotFound) || errors.Is(err, rsi.ErrForbidden)
+			// Skip retries for 404/user not found errors, 403/forbidden errors or while RSI is backing off
+			return errors.Is(err, rsi.ErrUserNotFound) || errors.Is(err, rsi.ErrForbidden) || errors.Is(err, rsi.ErrRateLimited)
 		},
 	)
 
@@ -207,7 +216,7 @@ func updateMembers(ctx context.Context, logger *slog.Logger, discordMembers []*d
 		upsertStatusMessage("member_monitor", fmt.Sprintf("Updating members... (%d/%d)", chunkEnd, len(discordMembers)))
 
 		// Process each member in the chunk
-		processedMembers := processChunkMembers(ctx, chunk, chunkStart, recruitRoleID, allyRoleID, rsiBackoff, logger, &processingErrors)
+		processedMembers := processChunkMembers(ctx, chunk, chunkStart, recruitRoleID, allyRoleID, enemies, nickMode, roles, rsiBackoff, logger, &processingErrors)
 		// chunkMembersToSave = append(chunkMembersToSave, processedMembers...)
 
 		// Save chunk in batch
@@ -370,6 +379,9 @@ func processChunkMembers(
 	chunk []*discordgo.Member,
 	chunkStart int,
 	recruitRoleID, allyRoleID string,
+	enemies []string,
+	nickMode string,
+	roles *roleSync,
 	rsiBackoff *utils.ExponentialBackoff,
 	logger *slog.Logger,
 	processingErrors *[]error,
@@ -413,6 +425,12 @@ func processChunkMembers(
 			mlogger.Error("updating RSI info", "error", err)
 			*processingErrors = append(*processingErrors, err)
 		} else {
+			recheckVerification(discordMember, member, mlogger)
+			checkEnemyAffiliations(member, enemies, mlogger)
+			reconcileAlly(member, allyRoleID, mlogger)
+			normalizeNickname(discordMember, member, nickMode, mlogger)
+			roles.reconcile(discordMember, member, mlogger)
+
 			// Add to chunk batch for saving
 			mlogger.Debug("adding member to chunk save batch")
 			chunkMembers = append(chunkMembers, *member)
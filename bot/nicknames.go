@@ -0,0 +1,102 @@
+package bot
+
+import (
+	"log/slog"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/sol-armada/sol-bot/members"
+	"github.com/sol-armada/sol-bot/settings"
+)
+
+const (
+	nicknamesOff     = "off"
+	nicknamesReport  = "report"
+	nicknamesEnforce = "enforce"
+)
+
+// NicknameChange is a nickname that differs from what the member should have
+type NicknameChange struct {
+	MemberId string
+	Current  string
+	Want     string
+	// TooLong is set when even without the suffix the nickname is over Discord's limit and can't be set
+	TooLong bool
+}
+
+// nicknameMode is how the member monitor handles nicknames. Report only logs what would change
+func nicknameMode() string {
+	mode := strings.ToLower(settings.GetStringWithDefault("FEATURES.NICKNAMES.MODE", nicknamesReport))
+	switch mode {
+	case nicknamesOff, nicknamesReport, nicknamesEnforce:
+		return mode
+	}
+
+	return nicknamesReport
+}
+
+// nicknameChange returns what would change about the member's nickname, nil if nothing or they opted out
+func nicknameChange(discordMember *discordgo.Member, member *members.Member) *NicknameChange {
+	if member.KeepNickname {
+		return nil
+	}
+
+	current := discordMember.Nick
+	if current == "" {
+		current = discordMember.User.Username
+	}
+
+	want, fits := member.CanonicalNick(discordMember)
+	if want == current {
+		return nil
+	}
+
+	return &NicknameChange{
+		MemberId: member.Id,
+		Current:  current,
+		Want:     want,
+		TooLong:  !fits,
+	}
+}
+
+// normalizeNickname sets the member's nickname to their canonical one when enforcing, otherwise it logs the difference
+func normalizeNickname(discordMember *discordgo.Member, member *members.Member, mode string, logger *slog.Logger) {
+	if mode == nicknamesOff {
+		return
+	}
+
+	change := nicknameChange(discordMember, member)
+	if change == nil {
+		return
+	}
+
+	logger = logger.With("current", change.Current, "want", change.Want)
+	if change.TooLong {
+		logger.Warn("canonical nickname too long")
+		return
+	}
+
+	if mode != nicknamesEnforce {
+		logger.Info("nickname differs")
+		return
+	}
+
+	logger.Info("setting nickname")
+	if err := bot.GuildMemberNickname(bot.GuildId, discordMember.User.ID, change.Want); err != nil {
+		logger.Error("setting nickname", "error", err)
+		return
+	}
+	discordMember.Nick = change.Want
+}
+
+// NicknameReport lists the nicknames the member monitor would change without changing any
+func NicknameReport(logger *slog.Logger) ([]NicknameChange, error) {
+	changes := []NicknameChange{}
+	err := forEachStoredMember(logger, func(discordMember *discordgo.Member, member *members.Member) {
+		if change := nicknameChange(discordMember, member); change != nil {
+			changes = append(changes, *change)
+		}
+	})
+
+	return changes, err
+}
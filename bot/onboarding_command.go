@@ -0,0 +1,407 @@
+package bot
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/pkg/errors"
+	"github.com/sol-armada/sol-bot/bot/internal/command"
+	"github.com/sol-armada/sol-bot/config"
+	"github.com/sol-armada/sol-bot/customerrors"
+	"github.com/sol-armada/sol-bot/settings"
+	"github.com/sol-armada/sol-bot/utils"
+)
+
+// maxFlowFileSize keeps officers from handing the bot something that isn't a flow
+const maxFlowFileSize = 64 * 1024
+
+type onboardingCommand struct{}
+
+var _ command.ApplicationCommand = (*onboardingCommand)(nil)
+
+var onboardingSubCommands = map[string]Handler{
+	"edit": onboardingEditHandler,
+}
+
+// AutocompleteHandler implements [command.ApplicationCommand].
+func (c *onboardingCommand) AutocompleteHandler(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	return nil
+}
+
+// ButtonHandler implements [command.ApplicationCommand].
+func (c *onboardingCommand) ButtonHandler(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	action := strings.Split(i.MessageComponentData().CustomID, ":")[1]
+	if h, ok := onboardingButtonHanlders[action]; ok {
+		return h(ctx, s, i)
+	}
+
+	return customerrors.InvalidButton
+}
+
+// CommandHandler implements [command.ApplicationCommand].
+func (c *onboardingCommand) CommandHandler(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	if h, ok := onboardingSubCommands[i.ApplicationCommandData().Options[0].Name]; ok {
+		return h(ctx, s, i)
+	}
+
+	return InvalidSubcommand
+}
+
+// ModalHandler implements [command.ApplicationCommand].
+func (c *onboardingCommand) ModalHandler(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	action := strings.Split(i.ModalSubmitData().CustomID, ":")[1]
+	if h, ok := onboardingModalHandlers[action]; ok {
+		return h(ctx, s, i)
+	}
+
+	return customerrors.InvalidModal
+}
+
+// Name implements [command.ApplicationCommand].
+func (c *onboardingCommand) Name() string {
+	return "onboarding"
+}
+
+// OnAfter implements [command.ApplicationCommand].
+func (c *onboardingCommand) OnAfter(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	return nil
+}
+
+// OnBefore implements [command.ApplicationCommand].
+func (c *onboardingCommand) OnBefore(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	return nil
+}
+
+// OnError implements [command.ApplicationCommand].
+func (c *onboardingCommand) OnError(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate, err error) {
+	logger := utils.GetLoggerFromContext(ctx)
+	logger.Error("handling onboarding command", "error", err)
+}
+
+// SelectMenuHandler implements [command.ApplicationCommand].
+func (c *onboardingCommand) SelectMenuHandler(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	return nil
+}
+
+// Setup implements [command.ApplicationCommand].
+func (c *onboardingCommand) Setup() (*discordgo.ApplicationCommand, error) {
+	return &discordgo.ApplicationCommand{
+		Name:        c.Name(),
+		Description: "Manage onboarding",
+		Type:        discordgo.ChatApplicationCommand,
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "edit",
+				Description: "Download the onboarding flow, or upload a new one to preview",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionAttachment,
+						Name:        "flow",
+						Description: "The flow as JSON, leave empty to download the current one",
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+func (c *onboardingCommand) SetupAliases() ([]*discordgo.ApplicationCommand, error) {
+	return nil, nil
+}
+
+// onboardingEditHandler hands out the current flow, or stores the uploaded one as a draft and previews it
+func onboardingEditHandler(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	logger := utils.GetLoggerFromContext(ctx)
+
+	if !utils.Allowed(i.Member, "ONBOARDING") {
+		return InvalidPermissions
+	}
+
+	var attachment *discordgo.MessageAttachment
+	for _, opt := range i.ApplicationCommandData().Options[0].Options {
+		if opt.Name == "flow" {
+			attachment = i.ApplicationCommandData().Resolved.Attachments[opt.Value.(string)]
+		}
+	}
+
+	if attachment == nil {
+		flow, err := config.GetOnboardingDraft()
+		name := "onboarding_draft.json"
+		if err != nil {
+			if !errors.Is(err, config.ErrConfigNotFound) {
+				return errors.Wrap(err, "getting onboarding draft")
+			}
+			flow = onboardingFlow()
+			name = "onboarding.json"
+		}
+
+		b, err := json.MarshalIndent(flow, "", "  ")
+		if err != nil {
+			return errors.Wrap(err, "encoding onboarding flow")
+		}
+
+		_, err = s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+			Content: new("Edit this file and upload it with `/onboarding edit flow:` to preview it"),
+			Files:   []*discordgo.File{{Name: name, ContentType: "application/json", Reader: bytes.NewReader(b)}},
+		})
+		return err
+	}
+
+	if attachment.Size > maxFlowFileSize {
+		return onboardingRespond(s, i, fmt.Sprintf("That file is too big, flows can be at most %d KB", maxFlowFileSize/1024))
+	}
+
+	flow, err := downloadOnboardingFlow(attachment.URL)
+	if err != nil {
+		logger.Debug("reading uploaded onboarding flow", "error", err)
+		return onboardingRespond(s, i, "I couldn't read that flow: "+err.Error())
+	}
+
+	if err := config.SetOnboardingDraft(flow); err != nil {
+		return onboardingRespond(s, i, truncate("That flow can't be used yet:\n"+err.Error(), 2000))
+	}
+
+	logger.Info("onboarding draft saved", "by", i.Member.User.ID)
+
+	// the welcome is shown as members will see it, the buttons open the draft questions
+	if _, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+		Content:    &flow.Welcome,
+		Components: new(onboardingButtons(flow, "onboarding:preview:")),
+	}); err != nil {
+		return errors.Wrap(err, "previewing onboarding welcome")
+	}
+
+	if _, err := s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
+		Content: "Above is the draft welcome, try its buttons to answer the questions without saving anything. Publish it when it looks right.",
+		Flags:   discordgo.MessageFlagsEphemeral,
+		Embeds:  []*discordgo.MessageEmbed{onboardingSummary(flow)},
+		Components: []discordgo.MessageComponent{
+			discordgo.ActionsRow{
+				Components: []discordgo.MessageComponent{
+					discordgo.Button{
+						Label:    "Publish",
+						CustomID: "onboarding:publish",
+						Style:    discordgo.SuccessButton,
+					},
+					discordgo.Button{
+						Label:    "Discard",
+						CustomID: "onboarding:discard",
+						Style:    discordgo.DangerButton,
+					},
+				},
+			},
+		},
+	}); err != nil {
+		return errors.Wrap(err, "previewing onboarding questions")
+	}
+
+	return nil
+}
+
+func onboardingPreviewButtonHandler(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	if !utils.Allowed(i.Member, "ONBOARDING") {
+		return InvalidPermissions
+	}
+
+	choiceId := strings.Split(i.MessageComponentData().CustomID, ":")[2]
+
+	draft, err := config.GetOnboardingDraft()
+	if err != nil && !errors.Is(err, config.ErrConfigNotFound) {
+		return errors.Wrap(err, "getting onboarding draft")
+	}
+
+	var choice *config.OnboardingChoice
+	ok := false
+	if draft != nil {
+		choice, ok = draft.Choice(choiceId)
+	}
+	if !ok {
+		customerrors.ErrorResponse(s, i.Interaction, "That draft was published or replaced, upload it again to preview it", nil)
+		return nil
+	}
+
+	return s.InteractionRespond(i.Interaction, onboardingModal("onboarding:preview:"+choice.Id, choice))
+}
+
+// onboardingPreviewModalHandler shows where the answers would be stored without saving them
+func onboardingPreviewModalHandler(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	if !utils.Allowed(i.Member, "ONBOARDING") {
+		return InvalidPermissions
+	}
+
+	data := i.ModalSubmitData()
+	choiceId := strings.Split(data.CustomID, ":")[2]
+
+	draft, err := config.GetOnboardingDraft()
+	if err != nil && !errors.Is(err, config.ErrConfigNotFound) {
+		return errors.Wrap(err, "getting onboarding draft")
+	}
+
+	var choice *config.OnboardingChoice
+	ok := false
+	if draft != nil {
+		choice, ok = draft.Choice(choiceId)
+	}
+	if !ok {
+		customerrors.ErrorResponse(s, i.Interaction, "That draft was published or replaced, upload it again to preview it", nil)
+		return nil
+	}
+
+	content := ""
+	answers, problems := onboardingAnswers(choice, data)
+	if problems != "" {
+		content = "A member would be asked to try again.\n" + problems
+	} else {
+		lines := []string{"A member's answers would be stored as:"}
+		for _, answer := range answers {
+			field := answer.Field
+			if field == "" {
+				field = "only shown to officers"
+			}
+			lines = append(lines, fmt.Sprintf("- %s → `%s`: %s", answer.Question, field, answer.Answer))
+		}
+		content = strings.Join(lines, "\n")
+	}
+
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: truncate(content, 2000),
+			Flags:   discordgo.MessageFlagsEphemeral,
+		},
+	})
+}
+
+func onboardingPublishButtonHandler(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	logger := utils.GetLoggerFromContext(ctx)
+
+	if !utils.Allowed(i.Member, "ONBOARDING") {
+		return InvalidPermissions
+	}
+
+	if _, err := config.PublishOnboardingDraft(); err != nil {
+		if errors.Is(err, config.ErrConfigNotFound) {
+			return onboardingUpdate(s, i, "There is no draft to publish, it may have already been published")
+		}
+		return errors.Wrap(err, "publishing onboarding draft")
+	}
+
+	logger.Info("onboarding flow published", "by", i.Member.User.ID)
+
+	if settings.GetBool("FEATURES.ONBOARDING.ENABLE") {
+		if err := setupOnboarding(); err != nil {
+			return errors.Wrap(err, "updating onboarding message")
+		}
+	}
+
+	return onboardingUpdate(s, i, "Published! New members will see the new onboarding flow")
+}
+
+func onboardingDiscardButtonHandler(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	if !utils.Allowed(i.Member, "ONBOARDING") {
+		return InvalidPermissions
+	}
+
+	if err := config.DiscardOnboardingDraft(); err != nil {
+		return errors.Wrap(err, "discarding onboarding draft")
+	}
+
+	return onboardingUpdate(s, i, "Draft discarded")
+}
+
+func downloadOnboardingFlow(url string) (*config.OnboardingFlow, error) {
+	client := &http.Client{Timeout: 10 * time.Second}
+	res, err := client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("downloading the file returned %s", res.Status)
+	}
+
+	// unknown fields are rejected so typos don't silently drop a rule
+	decoder := json.NewDecoder(io.LimitReader(res.Body, maxFlowFileSize))
+	decoder.DisallowUnknownFields()
+
+	flow := &config.OnboardingFlow{}
+	if err := decoder.Decode(flow); err != nil {
+		return nil, err
+	}
+
+	return flow, nil
+}
+
+// onboardingSummary lists every choice's questions and where their answers go
+func onboardingSummary(flow *config.OnboardingFlow) *discordgo.MessageEmbed {
+	embed := &discordgo.MessageEmbed{Title: "Onboarding Draft"}
+	for _, choice := range flow.Choices {
+		lines := []string{}
+		for _, q := range choice.Questions {
+			rules := []string{}
+			if q.Required {
+				rules = append(rules, "required")
+			}
+			if q.MinLength > 0 || q.MaxLength > 0 {
+				rules = append(rules, fmt.Sprintf("%d-%d characters", q.MinLength, q.MaxLength))
+			}
+			if q.Pattern != "" {
+				rules = append(rules, "matches `"+q.Pattern+"`")
+			}
+
+			field := q.Field
+			if field == "" {
+				field = "officers only"
+			}
+
+			line := fmt.Sprintf("%s → `%s`", q.Label, field)
+			if len(rules) > 0 {
+				line += " (" + strings.Join(rules, ", ") + ")"
+			}
+			lines = append(lines, line)
+		}
+
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:  strings.TrimSpace(choice.Emoji + " " + choice.Label),
+			Value: truncate(strings.Join(lines, "\n"), 1024),
+		})
+	}
+
+	return embed
+}
+
+func onboardingRespond(s *discordgo.Session, i *discordgo.InteractionCreate, content string) error {
+	_, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+		Content: &content,
+	})
+	return err
+}
+
+// onboardingUpdate replaces the draft summary, removing its buttons
+func onboardingUpdate(s *discordgo.Session, i *discordgo.InteractionCreate, content string) error {
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Content:    content,
+			Embeds:     []*discordgo.MessageEmbed{},
+			Components: []discordgo.MessageComponent{},
+		},
+	})
+}
+
+func truncate(s string, max int) string {
+	r := []rune(s)
+	if len(r) <= max {
+		return s
+	}
+	return string(r[:max-1]) + "…"
+}
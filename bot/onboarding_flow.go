@@ -0,0 +1,195 @@
+package bot
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/pkg/errors"
+	"github.com/sol-armada/sol-bot/config"
+	"github.com/sol-armada/sol-bot/members"
+)
+
+// defaultOnboardingFlow is used until a flow is published with /onboarding edit
+var defaultOnboardingFlow = config.OnboardingFlow{
+	Welcome: `# Welcome to Sol Armada!
+
+Let us know how you found us by clicking one of the buttons below. This will help us improve our recruitment efforts and better understand our community.
+If you run into any issues, please reach out in <#223290459726807040> for assistance.
+
+## If you are here to join Sol Armada
+After applying to the Org on RSI and paticipating in a brief verbal onboarding, with an <@&398414253171671041> or <@&1109958022362562672>, we will mark you as a Recruit, granting access to more channels here on Discord. We don't accept applications until you attend 3 official Org events.
+
+## If you are an ambassador or content creator
+Let's chat! Message <@91622043040124928>, Sol Armada's Diplomat and Admiral.
+
+— Sol Armada Org Administration
+
+### [Sol Armada Handbook - Please read!](https://www.solarmada.space/fullhandbook)
+
+### [Join the Org!](https://www.solarmada.space/new-recruits)
+`,
+	Finished: "Thank you for answering our questions! Your Discord nickname has been set to your RSI handle. You can contact someone in <#223290459726807040> to get verbally onboarded!",
+	Choices: []config.OnboardingChoice{
+		{
+			Id:    "recruited",
+			Label: "A member recruited me",
+			Emoji: "🤝",
+			Questions: append(defaultOnboardingQuestions(), config.OnboardingQuestion{
+				Id:          "recruiter",
+				Label:       "Who recruited you?",
+				Placeholder: "The recruiter's RSI handle",
+				Required:    true,
+				Field:       "recruiter",
+			}),
+		},
+		{
+			Id:        "rsi",
+			Label:     "Found Sol Armada on RSI",
+			Emoji:     "🔍",
+			Questions: defaultOnboardingQuestions(),
+		},
+		{
+			Id:    "other",
+			Label: "Some other way",
+			Emoji: "❔",
+			Questions: append(defaultOnboardingQuestions(), config.OnboardingQuestion{
+				Id:        "other",
+				Label:     "How did you find us?",
+				Paragraph: true,
+				Required:  true,
+				Field:     "other",
+			}),
+		},
+	},
+}
+
+func defaultOnboardingQuestions() []config.OnboardingQuestion {
+	return []config.OnboardingQuestion{
+		{Id: "rsi_handle", Label: "Your RSI Handle", Required: true, Field: members.OnboardingRSIHandle},
+		{Id: "play_time", Label: "How long have you been playing Star Citizen?", Placeholder: "Example: 2 years", Required: true, Field: "playtime"},
+		{Id: "gameplay", Label: "What gameplay are you most interested in?", Placeholder: "Combat, Rescue, Mining, etc", Required: true, Field: "gameplay"},
+		{Id: "age", Label: "How old are you?", Required: true, Field: "age"},
+	}
+}
+
+// onboardingFlow returns the published flow, or the default when nothing was published yet
+func onboardingFlow() *config.OnboardingFlow {
+	flow, err := config.GetOnboardingFlow()
+	if err != nil {
+		if !errors.Is(err, config.ErrConfigNotFound) {
+			slog.Default().Warn("getting onboarding flow, using the default", "error", err)
+		}
+		return &defaultOnboardingFlow
+	}
+
+	return flow
+}
+
+// onboardingButtons are the flow's choices as rows of buttons, prefix is the custom id before the choice id
+func onboardingButtons(flow *config.OnboardingFlow, prefix string) []discordgo.MessageComponent {
+	rows := []discordgo.MessageComponent{}
+	row := discordgo.ActionsRow{}
+	for _, choice := range flow.Choices {
+		button := discordgo.Button{
+			Label:    choice.Label,
+			CustomID: prefix + choice.Id,
+			Style:    discordgo.PrimaryButton,
+		}
+		if choice.Emoji != "" {
+			button.Emoji = &discordgo.ComponentEmoji{Name: choice.Emoji}
+		}
+
+		row.Components = append(row.Components, button)
+		if len(row.Components) == 5 {
+			rows = append(rows, row)
+			row = discordgo.ActionsRow{}
+		}
+	}
+
+	if len(row.Components) > 0 {
+		rows = append(rows, row)
+	}
+
+	return rows
+}
+
+// onboardingModal asks the choice's questions
+func onboardingModal(customId string, choice *config.OnboardingChoice) *discordgo.InteractionResponse {
+	questions := []discordgo.MessageComponent{}
+	for _, q := range choice.Questions {
+		style := discordgo.TextInputShort
+		if q.Paragraph {
+			style = discordgo.TextInputParagraph
+		}
+
+		questions = append(questions, discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.TextInput{
+					CustomID:    q.Id,
+					Label:       q.Label,
+					Style:       style,
+					Placeholder: q.Placeholder,
+					Required:    new(q.Required || q.Field == members.OnboardingRSIHandle),
+					MinLength:   q.MinLength,
+					MaxLength:   q.MaxLength,
+				},
+			},
+		})
+	}
+
+	return &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseModal,
+		Data: &discordgo.InteractionResponseData{
+			CustomID:   customId,
+			Title:      "Onboarding",
+			Components: questions,
+		},
+	}
+}
+
+// onboardingAnswers pairs the submitted values with the choice's questions.
+// Problems are returned as one message for the member
+func onboardingAnswers(choice *config.OnboardingChoice, data discordgo.ModalSubmitInteractionData) ([]members.OnboardingAnswer, string) {
+	values := map[string]string{}
+	for _, c := range data.Components {
+		row, ok := c.(*discordgo.ActionsRow)
+		if !ok {
+			continue
+		}
+		for _, rc := range row.Components {
+			if input, ok := rc.(*discordgo.TextInput); ok {
+				values[input.CustomID] = strings.TrimSpace(input.Value)
+			}
+		}
+	}
+
+	answers := []members.OnboardingAnswer{}
+	problems := []string{}
+	for _, q := range choice.Questions {
+		value := values[q.Id]
+		if err := q.Check(value); err != nil {
+			problems = append(problems, "- "+err.Error())
+			continue
+		}
+
+		answers = append(answers, members.OnboardingAnswer{Question: q.Label, Field: q.Field, Answer: value})
+	}
+
+	if len(problems) > 0 {
+		return nil, fmt.Sprintf("Some of your answers need another look:\n%s", strings.Join(problems, "\n"))
+	}
+
+	return answers, ""
+}
+
+// rsiHandleAnswer is the answer to the flow's RSI handle question
+func rsiHandleAnswer(answers []members.OnboardingAnswer) string {
+	for _, a := range answers {
+		if a.Field == members.OnboardingRSIHandle {
+			return a.Answer
+		}
+	}
+	return ""
+}
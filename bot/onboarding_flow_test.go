@@ -0,0 +1,9 @@
+package bot
+
+import "testing"
+
+func TestDefaultOnboardingFlow(t *testing.T) {
+	if err := defaultOnboardingFlow.Validate(); err != nil {
+		t.Errorf("default onboarding flow is invalid: %v", err)
+	}
+}
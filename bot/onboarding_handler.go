@@ -50,52 +50,11 @@ func setupOnboarding() error {
 
 CONTINUE:
 
-	content := `# Welcome to Sol Armada!
-
-Let us know how you found us by clicking one of the buttons below. This will help us improve our recruitment efforts and better understand our community.
-If you run into any issues, please reach out in <#223290459726807040> for assistance.
-
-## If you are here to join Sol Armada
-After applying to the Org on RSI and paticipating in a brief verbal onboarding, with an <@&398414253171671041> or <@&1109958022362562672>, we will mark you as a Recruit, granting access to more channels here on Discord. We don't accept applications until you attend 3 official Org events.
-
-## If you are an ambassador or content creator
-Let's chat! Message <@91622043040124928>, Sol Armada's Diplomat and Admiral.
-
-— Sol Armada Org Administration
-
-### [Sol Armada Handbook - Please read!](https://www.solarmada.space/fullhandbook)
-
-### [Join the Org!](https://www.solarmada.space/new-recruits)
-`
-
-	components := []discordgo.MessageComponent{
-		discordgo.ActionsRow{
-			Components: []discordgo.MessageComponent{
-				discordgo.Button{
-					Label:    "A member recruited me",
-					CustomID: "onboarding:choice:recruited",
-					Style:    discordgo.PrimaryButton,
-					Emoji:    &discordgo.ComponentEmoji{Name: "🤝"},
-				},
-				discordgo.Button{
-					Label:    "Found Sol Armada on RSI",
-					CustomID: "onboarding:choice:rsi",
-					Style:    discordgo.PrimaryButton,
-					Emoji:    &discordgo.ComponentEmoji{Name: "🔍"},
-				},
-				discordgo.Button{
-					Label:    "Some other way",
-					CustomID: "onboarding:choice:other",
-					Style:    discordgo.PrimaryButton,
-					Emoji:    &discordgo.ComponentEmoji{Name: "❔"},
-				},
-			},
-		},
-	}
+	flow := onboardingFlow()
 
 	msg := &discordgo.MessageSend{
-		Content:    content,
-		Components: components,
+		Content:    flow.Welcome,
+		Components: onboardingButtons(flow, "onboarding:choice:"),
 		Flags:      discordgo.MessageFlagsSuppressEmbeds,
 	}
 
@@ -154,87 +113,20 @@ func onboardingButtonHandler(ctx context.Context, s *discordgo.Session, i *disco
 	}
 
 	data := i.MessageComponentData()
-	choice := strings.Split(data.CustomID, ":")[2]
-
-	questions := []discordgo.MessageComponent{
-		discordgo.ActionsRow{
-			Components: []discordgo.MessageComponent{
-				discordgo.TextInput{
-					CustomID: "rsi_handle",
-					Label:    "Your RSI Handle",
-					Style:    discordgo.TextInputShort,
-					Required: new(true),
-				},
-			},
-		},
-		discordgo.ActionsRow{
-			Components: []discordgo.MessageComponent{
-				discordgo.TextInput{
-					CustomID:    "play_time",
-					Label:       "How long have you been playing Star Citizen?",
-					Style:       discordgo.TextInputShort,
-					Placeholder: "Example: 2 years",
-					Required:    new(true),
-				},
-			},
-		},
-		discordgo.ActionsRow{
-			Components: []discordgo.MessageComponent{
-				discordgo.TextInput{
-					CustomID:    "gameplay",
-					Label:       "What gameplay are you most interested in?",
-					Style:       discordgo.TextInputShort,
-					Placeholder: "Combat, Rescue, Mining, etc",
-					Required:    new(true),
-				},
-			},
-		},
-		discordgo.ActionsRow{
-			Components: []discordgo.MessageComponent{
-				discordgo.TextInput{
-					CustomID: "age",
-					Label:    "How old are you?",
-					Style:    discordgo.TextInputShort,
-					Required: new(true),
-				},
-			},
-		},
-	}
+	choiceId := strings.Split(data.CustomID, ":")[2]
 
-	switch choice {
-	case "recruited":
-		questions = append(questions, discordgo.ActionsRow{
-			Components: []discordgo.MessageComponent{
-				discordgo.TextInput{
-					CustomID:    "recruiter",
-					Label:       "Who recruited you?",
-					Style:       discordgo.TextInputShort,
-					Placeholder: "The recruiter's RSI handle",
-					Required:    new(true),
-				},
-			},
-		})
-	case "other":
-		questions = append(questions, discordgo.ActionsRow{
-			Components: []discordgo.MessageComponent{
-				discordgo.TextInput{
-					CustomID: "other",
-					Label:    "How did you find us?",
-					Style:    discordgo.TextInputParagraph,
-					Required: new(true),
-				},
+	choice, ok := onboardingFlow().Choice(choiceId)
+	if !ok {
+		return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{
+				Content: "The onboarding questions have changed, please pick again from the onboarding message",
+				Flags:   discordgo.MessageFlagsEphemeral,
 			},
 		})
 	}
 
-	if err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-		Type: discordgo.InteractionResponseModal,
-		Data: &discordgo.InteractionResponseData{
-			CustomID:   "onboarding:onboard",
-			Title:      "Onboarding",
-			Components: questions,
-		},
-	}); err != nil {
+	if err := s.InteractionRespond(i.Interaction, onboardingModal("onboarding:onboard:"+choice.Id, choice)); err != nil {
 		return err
 	}
 
@@ -256,67 +148,65 @@ func onboardingModalHandler(ctx context.Context, s *discordgo.Session, i *discor
 		return errors.Wrap(err, "responding")
 	}
 
-	rsiHandle := data.Components[0].(*discordgo.ActionsRow).Components[0].(*discordgo.TextInput).Value
-	playTime := data.Components[1].(*discordgo.ActionsRow).Components[0].(*discordgo.TextInput).Value
-	gameplay := data.Components[2].(*discordgo.ActionsRow).Components[0].(*discordgo.TextInput).Value
-	age := data.Components[3].(*discordgo.ActionsRow).Components[0].(*discordgo.TextInput).Value
-	recruiter := ""
-	other := ""
-
-	if len(data.Components) > 4 {
-		if actionRow, ok := data.Components[4].(*discordgo.ActionsRow); ok {
-			if comp, ok := actionRow.Components[0].(*discordgo.TextInput); ok {
-				switch comp.CustomID {
-				case "recruiter":
-					recruiter = comp.Value
-				case "other":
-					other = comp.Value
-				}
-			}
-		}
+	choiceId := ""
+	if parts := strings.Split(data.CustomID, ":"); len(parts) > 2 {
+		choiceId = parts[2]
 	}
 
-	member.LegacyPlaytime = playTime
-	member.LegacyGameplay = gameplay
-	member.LegacyAge = age
-
-	if recruiter != "" {
-		member.LegacyRecruiter = recruiter
-	}
-
-	if other != "" {
-		member.LegacyOther = other
-	}
-
-	if err := member.Save(); err != nil {
-		return errors.Wrap(err, "onboarding modal handler: failed to save member first")
+	choice, ok := onboardingFlow().Choice(choiceId)
+	if !ok {
+		_, err := s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
+			Content: "The onboarding questions have changed, please pick again from the onboarding message",
+			Flags:   discordgo.MessageFlagsEphemeral,
+		})
+		return err
 	}
 
-	// validate rsi handle
-	if !rsi.ValidHandle(rsiHandle) {
-		logger.Debug("invalid RSI handle")
+	answers, problems := onboardingAnswers(choice, data)
+	if problems != "" {
+		logger.Debug("invalid onboarding answers")
 
 		if _, err := s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
-			Content: "I couldn't find that RSI handle!\n\nPlease make sure it is correct and try again.\nYour RSI handle can be found on your public RSI profile page or in your settings here: https://robertsspaceindustries.com/account/settings",
+			Content: problems,
 			Flags:   discordgo.MessageFlagsEphemeral,
 			Components: []discordgo.MessageComponent{
 				discordgo.ActionsRow{
 					Components: []discordgo.MessageComponent{
 						discordgo.Button{
 							Label:    "Try Again",
-							CustomID: "onboarding:tryagain:" + i.Interaction.Member.User.ID,
+							CustomID: "onboarding:choice:" + choice.Id,
 						},
 					},
 				},
 			},
 		}); err != nil {
-			return errors.Wrap(err, "onboarding modal handler: responding to invalid rsi handle")
+			return errors.Wrap(err, "onboarding modal handler: responding to invalid answers")
 		}
 
 		return nil
 	}
 
+	for _, answer := range answers {
+		if err := member.SetOnboardingAnswer(answer.Field, answer.Answer); err != nil {
+			return errors.Wrap(err, "onboarding modal handler")
+		}
+	}
+	member.OnboardingAnswers = answers
+	member.FoundBy = choice.Label
+
+	if err := member.Save(); err != nil {
+		return errors.Wrap(err, "onboarding modal handler: failed to save member first")
+	}
+
+	rsiHandle := rsiHandleAnswer(answers)
+
+	// validate rsi handle
+	if ok, err := checkOnboardingHandle(ctx, s, i, rsiHandle); !ok {
+		return err
+	}
+
 	member.Name = rsiHandle
+	member.SetRSIHandle(rsiHandle)
 
 	if err := member.Save(); err != nil {
 		return errors.Wrap(err, "onboarding modal handler: saving member second")
@@ -374,28 +264,12 @@ func onboardingTryAgainModalHandler(ctx context.Context, s *discordgo.Session, i
 		return errors.Wrap(err, "responding")
 	}
 
-	if !rsi.ValidHandle(rsiHandle) {
-		logger.Debug("invalid RSI handle")
-
-		if _, err := s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
-			Content: "I couldn't find that RSI handle!\n\nPlease make sure it is correct and try again.\nYour RSI handle can be found on your public RSI profile page or in your settings here: https://robertsspaceindustries.com/account/settings",
-			Flags:   discordgo.MessageFlagsEphemeral,
-			Components: []discordgo.MessageComponent{
-				discordgo.ActionsRow{
-					Components: []discordgo.MessageComponent{
-						discordgo.Button{
-							Label:    "Try Again",
-							CustomID: "onboarding:tryagain:" + i.Interaction.Member.User.ID,
-						},
-					},
-				},
-			},
-		}); err != nil {
-			return errors.Wrap(err, "onboarding modal handler: responding to invalid rsi handle")
-		}
+	if ok, err := checkOnboardingHandle(ctx, s, i, rsiHandle); !ok {
+		return err
 	}
 
 	member.Name = rsiHandle
+	member.SetRSIHandle(rsiHandle)
 
 	if err := member.Save(); err != nil {
 		return errors.Wrap(err, "onboarding try again modal handler: saving member")
@@ -427,28 +301,39 @@ func finishOnboarding(ctx context.Context, s *discordgo.Session, i *discordgo.In
 	}
 
 SKIP:
+	finished := onboardingFlow().Finished
+	if finished == "" {
+		finished = "Thank you for answering our questions! Your Discord nickname has been set to your RSI handle."
+	}
+
 	if _, err := s.FollowupMessageCreate(i.Interaction, false, &discordgo.WebhookParams{
-		Content: "Thank you for answering our questions! Your Discord nickname has been set to your RSI handle. You can contact someone in <#223290459726807040> to get verbally onboarded!",
+		Content: finished,
 		Flags:   discordgo.MessageFlagsEphemeral,
 	}); err != nil {
 		return errors.Wrap(err, "finishing onboarding: responding")
 	}
 
 	fields := []*discordgo.MessageEmbedField{
-		{Name: "RSI Profile", Value: "https://robertsspaceindustries.com/citizens/" + member.Name},
+		{Name: "RSI Profile", Value: rsi.UserProfileURL(member.Handle())},
 		{Name: "Primary Org", Value: "https://robertsspaceindustries.com/orgs/" + member.PrimaryOrg},
 		{Name: "Affiliate Orgs", Value: strings.Join(member.Affilations, ", ")},
-		{Name: "Playtime", Value: member.LegacyPlaytime},
-		{Name: "Gameplay", Value: member.LegacyGameplay},
-		{Name: "Age", Value: member.LegacyAge},
 	}
 
-	if member.LegacyRecruiter != "" {
-		fields = append(fields, &discordgo.MessageEmbedField{Name: "Recruiter", Value: member.LegacyRecruiter})
+	for _, answer := range member.OnboardingAnswers {
+		if answer.Field == members.OnboardingRSIHandle || answer.Answer == "" {
+			continue
+		}
+		fields = append(fields, &discordgo.MessageEmbedField{Name: answer.Question, Value: answer.Answer})
 	}
 
-	if member.LegacyOther != "" {
-		fields = append(fields, &discordgo.MessageEmbedField{Name: "How they found us", Value: member.LegacyOther})
+	// recruiters use the dossier to spot brand new or suspicious accounts
+	var thumbnail *discordgo.MessageEmbedThumbnail
+	rsi.UpdateDossier(member, logger)
+	if member.Dossier != nil {
+		fields = append(fields, member.Dossier.EmbedFields(time.Now())...)
+		if member.Dossier.Avatar != "" {
+			thumbnail = &discordgo.MessageEmbedThumbnail{URL: member.Dossier.Avatar}
+		}
 	}
 
 	if member.MessageId != "" {
@@ -458,6 +343,7 @@ SKIP:
 			Embeds: &[]*discordgo.MessageEmbed{
 				{
 					Fields:    fields,
+					Thumbnail: thumbnail,
 					Timestamp: member.Joined.Format(time.RFC3339),
 				},
 			},
@@ -474,6 +360,7 @@ CREATE:
 		Embeds: []*discordgo.MessageEmbed{
 			{
 				Fields:    fields,
+				Thumbnail: thumbnail,
 				Timestamp: member.Joined.Format(time.RFC3339),
 			},
 		},
@@ -489,6 +376,43 @@ CREATE:
 	return member.Save()
 }
 
+// checkOnboardingHandle makes sure the RSI handle exists, offering to try again when it doesn't or RSI can't be reached
+func checkOnboardingHandle(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate, rsiHandle string) (bool, error) {
+	content := "I couldn't find that RSI handle!\n\nPlease make sure it is correct and try again.\nYour RSI handle can be found on your public RSI profile page or in your settings here: https://robertsspaceindustries.com/account/settings"
+
+	logger := utils.GetLoggerFromContext(ctx)
+
+	valid, err := rsi.ValidHandle(rsiHandle)
+	switch {
+	case err != nil:
+		logger.Warn("checking onboarding rsi handle", "handle", rsiHandle, "error", err)
+		content = "I couldn't reach RSI to check your handle right now. Please try again in a few minutes."
+	case valid:
+		return true, nil
+	default:
+		logger.Debug("invalid RSI handle")
+	}
+
+	if _, err := s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
+		Content: content,
+		Flags:   discordgo.MessageFlagsEphemeral,
+		Components: []discordgo.MessageComponent{
+			discordgo.ActionsRow{
+				Components: []discordgo.MessageComponent{
+					discordgo.Button{
+						Label:    "Try Again",
+						CustomID: "onboarding:tryagain:" + i.Interaction.Member.User.ID,
+					},
+				},
+			},
+		},
+	}); err != nil {
+		return false, errors.Wrap(err, "responding to unchecked rsi handle")
+	}
+
+	return false, nil
+}
+
 func deferInteraction(s *discordgo.Session, i *discordgo.InteractionCreate) error {
 	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
@@ -0,0 +1,141 @@
+package orgshandler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/sol-armada/sol-bot/allies"
+	"github.com/sol-armada/sol-bot/utils"
+)
+
+func allyAddHandler(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	var sid, contactId, notes string
+	notesSet := false
+	for _, opt := range options(i) {
+		switch opt.Name {
+		case "sid":
+			sid = allies.NormalizeSID(opt.StringValue())
+		case "contact":
+			contactId = opt.UserValue(nil).ID
+		case "notes":
+			notes = opt.StringValue()
+			notesSet = true
+		}
+	}
+
+	ally, err := allies.Get(sid)
+	if err != nil && !errors.Is(err, allies.ErrAllyNotFound) {
+		return err
+	}
+
+	action := "Updated"
+	if ally == nil {
+		action = "Added"
+		ally = allies.New(sid, i.Member.User.ID)
+	}
+
+	// only overwrite what was given so updating the notes keeps the contact and the other way around
+	if contactId != "" {
+		ally.ContactId = contactId
+	}
+	if notesSet {
+		ally.Notes = notes
+	}
+
+	if err := ally.Save(); err != nil {
+		return err
+	}
+
+	return respond(s, i, fmt.Sprintf("%s ally %s\nVerified members with %s as their primary org get the ally role on the next member update", action, sid, sid))
+}
+
+func allyRemoveHandler(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	sid := allies.NormalizeSID(options(i)[0].StringValue())
+
+	if err := allies.Remove(sid); err != nil {
+		if errors.Is(err, allies.ErrAllyNotFound) {
+			return respond(s, i, sid+" is not an ally")
+		}
+		return err
+	}
+
+	return respond(s, i, fmt.Sprintf("Removed %s from the allies\nTheir members lose the ally role on the next member update", sid))
+}
+
+func allyListHandler(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	allyList, err := allies.List()
+	if err != nil {
+		return err
+	}
+
+	if len(allyList) == 0 {
+		return respond(s, i, "There are no allies")
+	}
+
+	fields := make([]*discordgo.MessageEmbedField, 0, len(allyList))
+	for _, ally := range allyList {
+		contact := "None"
+		if ally.ContactId != "" {
+			contact = fmt.Sprintf("<@%s>", ally.ContactId)
+		}
+
+		value := "Contact: " + contact
+		if ally.Notes != "" {
+			value += "\n" + ally.Notes
+		}
+
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name:  ally.SID,
+			Value: value,
+		})
+
+		// discord allows 25 fields an embed
+		if len(fields) == 25 {
+			break
+		}
+	}
+
+	_, err = s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+		Embeds: &[]*discordgo.MessageEmbed{
+			{
+				Title:  "Allies",
+				Fields: fields,
+			},
+		},
+	})
+	return err
+}
+
+func allyAutocompleteHandler(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	logger := utils.GetLoggerFromContext(ctx)
+	logger.Debug("ally autocomplete handler")
+
+	allyList, err := allies.List()
+	if err != nil {
+		return err
+	}
+
+	typed := strings.ToUpper(options(i)[0].StringValue())
+
+	choices := []*discordgo.ApplicationCommandOptionChoice{}
+	for _, ally := range allyList {
+		if !strings.Contains(ally.SID, typed) {
+			continue
+		}
+
+		choices = append(choices, &discordgo.ApplicationCommandOptionChoice{Name: ally.SID, Value: ally.SID})
+		if len(choices) == 25 {
+			break
+		}
+	}
+
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionApplicationCommandAutocompleteResult,
+		Data: &discordgo.InteractionResponseData{
+			Choices: choices,
+		},
+	})
+}
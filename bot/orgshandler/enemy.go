@@ -0,0 +1,164 @@
+package orgshandler
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/sol-armada/sol-bot/config"
+	"github.com/sol-armada/sol-bot/members"
+	"github.com/sol-armada/sol-bot/utils"
+)
+
+// maxListedMembers keeps the member mentions within a message
+const maxListedMembers = 40
+
+func enemyAddHandler(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	sid := strings.ToUpper(strings.TrimSpace(options(i)[0].StringValue()))
+
+	added, err := config.AddEnemyOrg(sid)
+	if err != nil {
+		return err
+	}
+
+	if !added {
+		return respond(s, i, sid+" is already an enemy org")
+	}
+
+	mmbrs, err := members.ListAll()
+	if err != nil {
+		return err
+	}
+	inOrg := membersInOrg(mmbrs, sid)
+
+	content := fmt.Sprintf("Added %s to the enemy orgs", sid)
+	if len(inOrg) > 0 {
+		content += fmt.Sprintf("\n\n%d members are currently in %s: %s\nOfficers will be alerted on the next member update", len(inOrg), sid, mentions(inOrg))
+	}
+
+	return respond(s, i, content)
+}
+
+func enemyRemoveHandler(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	sid := strings.ToUpper(strings.TrimSpace(options(i)[0].StringValue()))
+
+	removed, err := config.RemoveEnemyOrg(sid)
+	if err != nil {
+		return err
+	}
+
+	if !removed {
+		return respond(s, i, sid+" is not an enemy org")
+	}
+
+	return respond(s, i, fmt.Sprintf("Removed %s from the enemy orgs", sid))
+}
+
+func enemyListHandler(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	enemies, err := config.GetEnemyOrgs()
+	if err != nil {
+		return err
+	}
+
+	if len(enemies) == 0 {
+		return respond(s, i, "There are no enemy orgs")
+	}
+
+	mmbrs, err := members.ListAll()
+	if err != nil {
+		return err
+	}
+
+	fields := make([]*discordgo.MessageEmbedField, 0, len(enemies))
+	for _, sid := range enemies {
+		inOrg := membersInOrg(mmbrs, sid)
+
+		value := "No members"
+		if len(inOrg) > 0 {
+			value = mentions(inOrg)
+		}
+
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name:  sid,
+			Value: value,
+		})
+
+		// discord allows 25 fields an embed
+		if len(fields) == 25 {
+			break
+		}
+	}
+
+	_, err = s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+		Embeds: &[]*discordgo.MessageEmbed{
+			{
+				Title:  "Enemy Orgs",
+				Fields: fields,
+			},
+		},
+	})
+	return err
+}
+
+func enemyAutocompleteHandler(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	logger := utils.GetLoggerFromContext(ctx)
+	logger.Debug("enemy autocomplete handler")
+
+	enemies, err := config.GetEnemyOrgs()
+	if err != nil {
+		return err
+	}
+
+	typed := strings.ToUpper(options(i)[0].StringValue())
+
+	choices := []*discordgo.ApplicationCommandOptionChoice{}
+	for _, sid := range enemies {
+		if !strings.Contains(sid, typed) {
+			continue
+		}
+
+		choices = append(choices, &discordgo.ApplicationCommandOptionChoice{Name: sid, Value: sid})
+		if len(choices) == 25 {
+			break
+		}
+	}
+
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionApplicationCommandAutocompleteResult,
+		Data: &discordgo.InteractionResponseData{
+			Choices: choices,
+		},
+	})
+}
+
+// membersInOrg returns the members with the org as their primary org or an affiliation
+func membersInOrg(mmbrs []members.Member, sid string) []members.Member {
+	inOrg := []members.Member{}
+	for _, member := range mmbrs {
+		if len(member.FindEnemyOrgs([]string{sid})) > 0 {
+			inOrg = append(inOrg, member)
+		}
+	}
+	return inOrg
+}
+
+func mentions(mmbrs []members.Member) string {
+	mentions := make([]string, 0, min(len(mmbrs), maxListedMembers))
+	for _, member := range mmbrs[:min(len(mmbrs), maxListedMembers)] {
+		mentions = append(mentions, fmt.Sprintf("<@%s>", member.Id))
+	}
+
+	out := strings.Join(mentions, ", ")
+	if len(mmbrs) > maxListedMembers {
+		out += fmt.Sprintf(" and %d more", len(mmbrs)-maxListedMembers)
+	}
+	return out
+}
+
+func respond(s *discordgo.Session, i *discordgo.InteractionCreate, content string) error {
+	_, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+		Content: &content,
+	})
+	return err
+}
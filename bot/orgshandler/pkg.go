@@ -0,0 +1,216 @@
+package orgshandler
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/sol-armada/sol-bot/bot/internal/command"
+	"github.com/sol-armada/sol-bot/customerrors"
+	"github.com/sol-armada/sol-bot/utils"
+)
+
+type OrgsCommand struct{}
+
+var _ command.ApplicationCommand = (*OrgsCommand)(nil)
+
+type handler func(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) error
+
+// subCommands are keyed by the subcommand group, then the subcommand
+var subCommands = map[string]map[string]handler{
+	"enemy": {
+		"add":    enemyAddHandler,
+		"remove": enemyRemoveHandler,
+		"list":   enemyListHandler,
+	},
+	"ally": {
+		"add":    allyAddHandler,
+		"remove": allyRemoveHandler,
+		"list":   allyListHandler,
+	},
+}
+
+var autoCompletes = map[string]map[string]handler{
+	"enemy": {
+		"remove": enemyAutocompleteHandler,
+	},
+	"ally": {
+		"remove": allyAutocompleteHandler,
+	},
+}
+
+func New() command.ApplicationCommand {
+	return &OrgsCommand{}
+}
+
+// AutocompleteHandler implements [command.ApplicationCommand].
+func (c *OrgsCommand) AutocompleteHandler(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	logger := utils.GetLoggerFromContext(ctx)
+	logger.Debug("orgs autocomplete handler")
+
+	group, sub := subCommand(i)
+	if h, ok := autoCompletes[group][sub]; ok {
+		return h(ctx, s, i)
+	}
+
+	return customerrors.InvalidAutocomplete
+}
+
+// ButtonHandler implements [command.ApplicationCommand].
+func (c *OrgsCommand) ButtonHandler(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	return nil
+}
+
+// CommandHandler implements [command.ApplicationCommand].
+func (c *OrgsCommand) CommandHandler(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	logger := utils.GetLoggerFromContext(ctx)
+	logger.Debug("orgs command handler")
+
+	if !utils.Allowed(i.Member, "ORGS") {
+		return customerrors.InvalidPermissions
+	}
+
+	group, sub := subCommand(i)
+	logger = logger.With(slog.String("group", group), slog.String("subcommand", sub))
+	ctx = utils.SetLoggerToContext(ctx, logger)
+
+	if h, ok := subCommands[group][sub]; ok {
+		return h(ctx, s, i)
+	}
+
+	return customerrors.InvalidSubcommand
+}
+
+// ModalHandler implements [command.ApplicationCommand].
+func (c *OrgsCommand) ModalHandler(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	return nil
+}
+
+// Name implements [command.ApplicationCommand].
+func (c *OrgsCommand) Name() string {
+	return "orgs"
+}
+
+// OnAfter implements [command.ApplicationCommand].
+func (c *OrgsCommand) OnAfter(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	return nil
+}
+
+// OnBefore implements [command.ApplicationCommand].
+func (c *OrgsCommand) OnBefore(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	return nil
+}
+
+// OnError implements [command.ApplicationCommand].
+func (c *OrgsCommand) OnError(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate, err error) {
+	logger := utils.GetLoggerFromContext(ctx)
+	logger.Error("handling orgs command", "error", err)
+}
+
+// SelectMenuHandler implements [command.ApplicationCommand].
+func (c *OrgsCommand) SelectMenuHandler(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	return nil
+}
+
+// Setup implements [command.ApplicationCommand].
+func (c *OrgsCommand) Setup() (*discordgo.ApplicationCommand, error) {
+	sidOption := func(description string, autocomplete bool) *discordgo.ApplicationCommandOption {
+		return &discordgo.ApplicationCommandOption{
+			Type:         discordgo.ApplicationCommandOptionString,
+			Name:         "sid",
+			Description:  description,
+			Required:     true,
+			Autocomplete: autocomplete,
+		}
+	}
+
+	return &discordgo.ApplicationCommand{
+		Name:        c.Name(),
+		Description: "Manage the orgs we watch for and ally with",
+		Type:        discordgo.ChatApplicationCommand,
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommandGroup,
+				Name:        "enemy",
+				Description: "Hostile orgs members are flagged for joining",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionSubCommand,
+						Name:        "add",
+						Description: "Add a hostile org",
+						Options:     []*discordgo.ApplicationCommandOption{sidOption("The org's SID", false)},
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionSubCommand,
+						Name:        "remove",
+						Description: "Remove a hostile org",
+						Options:     []*discordgo.ApplicationCommandOption{sidOption("The org's SID", true)},
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionSubCommand,
+						Name:        "list",
+						Description: "List the hostile orgs and who is in them",
+					},
+				},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommandGroup,
+				Name:        "ally",
+				Description: "Allied orgs whose verified members get the ally role",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionSubCommand,
+						Name:        "add",
+						Description: "Add or update an allied org",
+						Options: []*discordgo.ApplicationCommandOption{
+							sidOption("The org's SID", false),
+							{
+								Type:        discordgo.ApplicationCommandOptionUser,
+								Name:        "contact",
+								Description: "Who we talk to at the org",
+							},
+							{
+								Type:        discordgo.ApplicationCommandOptionString,
+								Name:        "notes",
+								Description: "Anything officers should know about the alliance",
+							},
+						},
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionSubCommand,
+						Name:        "remove",
+						Description: "Remove an allied org",
+						Options:     []*discordgo.ApplicationCommandOption{sidOption("The org's SID", true)},
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionSubCommand,
+						Name:        "list",
+						Description: "List the allied orgs",
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+func (c *OrgsCommand) SetupAliases() ([]*discordgo.ApplicationCommand, error) {
+	return nil, nil
+}
+
+// subCommand returns the names of the subcommand group and subcommand that were used
+func subCommand(i *discordgo.InteractionCreate) (string, string) {
+	data := i.ApplicationCommandData()
+	if len(data.Options) == 0 || len(data.Options[0].Options) == 0 {
+		return "", ""
+	}
+	return data.Options[0].Name, data.Options[0].Options[0].Name
+}
+
+// options returns the options given to the subcommand
+func options(i *discordgo.InteractionCreate) []*discordgo.ApplicationCommandInteractionDataOption {
+	data := i.ApplicationCommandData()
+	if len(data.Options) == 0 || len(data.Options[0].Options) == 0 {
+		return nil
+	}
+	return data.Options[0].Options[0].Options
+}
@@ -4,26 +4,28 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
-	"maps"
-	"slices"
 	"strings"
 	"time"
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/go-co-op/gocron/v2"
 	"github.com/pkg/errors"
+	"github.com/sol-armada/sol-bot/applications"
 	"github.com/sol-armada/sol-bot/bot/attendancehandler"
 	"github.com/sol-armada/sol-bot/bot/blueprinthandler"
 	"github.com/sol-armada/sol-bot/bot/giveawayhandler"
 	"github.com/sol-armada/sol-bot/bot/internal/command"
 	"github.com/sol-armada/sol-bot/bot/jobs"
+	"github.com/sol-armada/sol-bot/bot/orgshandler"
 	"github.com/sol-armada/sol-bot/bot/profilehandler"
 	"github.com/sol-armada/sol-bot/bot/rafflehandler"
 	"github.com/sol-armada/sol-bot/bot/rankupshandler"
 	"github.com/sol-armada/sol-bot/bot/tokenshandler"
+	"github.com/sol-armada/sol-bot/bot/verifyhandler"
 	"github.com/sol-armada/sol-bot/customerrors"
 	"github.com/sol-armada/sol-bot/giveaway"
 	"github.com/sol-armada/sol-bot/members"
+	"github.com/sol-armada/sol-bot/raffles"
 	"github.com/sol-armada/sol-bot/settings"
 	"github.com/sol-armada/sol-bot/stores"
 	"github.com/sol-armada/sol-bot/utils"
@@ -54,6 +56,9 @@ var commands = map[string]command.ApplicationCommand{
 	"tokens":     tokenshandler.New(),
 	"rankups":    rankupshandler.New(),
 	"blueprint":  blueprinthandler.New(),
+	"orgs":       orgshandler.New(),
+	"verify":     verifyhandler.New(),
+	"onboarding": &onboardingCommand{},
 
 	// "merit":      merithandler.New(),
 	// "demerit":    demerithandler.New(),
@@ -68,22 +73,21 @@ var aliases = map[string]command.ApplicationCommand{
 var onboardingButtonHanlders = map[string]Handler{
 	"choice":   onboardingButtonHandler,
 	"tryagain": onboardingTryAgainHandler,
+	"preview":  onboardingPreviewButtonHandler,
+	"publish":  onboardingPublishButtonHandler,
+	"discard":  onboardingDiscardButtonHandler,
 }
 
 var onboardingModalHandlers = map[string]Handler{
 	"onboard":   onboardingModalHandler,
 	"rsihandle": onboardingTryAgainModalHandler,
+	"preview":   onboardingPreviewModalHandler,
 }
 
 var attendanceModalHandlers = map[string]Handler{
 	"payout": attendancehandler.AddPayoutModalHandler,
 }
 
-var validateButtonHandlers = map[string]Handler{
-	"start": startValidateButtonHandler,
-	"check": checkValidateButtonHandler,
-}
-
 func New() (*Bot, error) {
 	slog.Info("creating discord bot")
 	b, err := discordgo.New(fmt.Sprintf("Bot %s", settings.GetString("DISCORD.BOT_TOKEN")))
@@ -142,41 +146,9 @@ func (b *Bot) Setup() error {
 		b.logger.Info("bot is ready", "user", r.User.Username)
 	})
 
-	for _, cmd := range commands {
-		cmdData, err := cmd.Setup()
-		if err != nil {
-			return errors.Wrap(err, "setting up command")
-		}
-
-		b.logger.Debug("setting up command", "command", cmd.Name())
-
-		if cmdData == nil {
-			b.logger.Warn("command setup returned nil", "command", cmd.Name())
-			continue
-		}
-
-		if _, err := b.ApplicationCommandCreate(b.ClientId, b.GuildId, cmdData); err != nil {
-			return errors.Wrap(err, "creating command")
-		}
-
-		aliases, err := cmd.SetupAliases()
-		if err != nil {
-			return errors.Wrap(err, "setting up command aliases")
-		}
-
-		if len(aliases) > 0 {
-			continue
-		}
-
-		b.logger.Debug("setting up command aliases", "command", cmd.Name(), "aliases", len(aliases))
-
-		for _, alias := range aliases {
-			b.logger.Debug("creating command alias", "command", cmd.Name(), "alias", alias.Name)
-
-			if _, err := b.ApplicationCommandCreate(b.ClientId, b.GuildId, alias); err != nil {
-				return errors.Wrap(err, "creating command alias")
-			}
-		}
+	b.logger.Debug("syncing commands")
+	if err := b.syncCommands(); err != nil {
+		return errors.Wrap(err, "syncing commands")
 	}
 
 	b.logger.Debug("adding interaction handler")
@@ -337,12 +309,8 @@ func (b *Bot) Setup() error {
 
 			ctx = utils.SetLoggerToContext(ctx, logger)
 			switch command {
-			case "onboarding":
-				if h, ok := onboardingButtonHanlders[subcommand]; ok {
-					err = h(ctx, s, i)
-				}
-			case "validate":
-				if h, ok := validateButtonHandlers[subcommand]; ok {
+			case "application":
+				if h, ok := applicationButtonHandlers[subcommand]; ok {
 					err = h(ctx, s, i)
 				}
 			default:
@@ -356,10 +324,6 @@ func (b *Bot) Setup() error {
 			command := strings.Split(i.ModalSubmitData().CustomID, ":")
 			subCommand := command[1]
 			switch command[0] {
-			case "onboarding":
-				if h, ok := onboardingModalHandlers[subCommand]; ok {
-					err = h(ctx, s, i)
-				}
 			case "attendance":
 				if h, ok := attendanceModalHandlers[subCommand]; ok {
 					err = h(ctx, s, i)
@@ -453,6 +417,12 @@ func (b *Bot) Setup() error {
 		return errors.Wrap(err, "loading giveaways")
 	}
 
+	// scheduled raffles
+	go raffles.Watch(b.ctx, b.Session)
+
+	// rsi applications
+	go applications.Watch(b.ctx, b.Session)
+
 	// activity tracking
 	if settings.GetBool("FEATURES.ACTIVITY_TRACKING.ENABLE") {
 		b.AddHandler(onVoiceUpdate)
@@ -504,24 +474,24 @@ func (b *Bot) Close() error {
 		b.logger.Error("failed to update custom status", "error", err)
 	}
 
-	// clear commands
+	return b.Session.Close()
+}
+
+// PurgeCommands deletes every slash command registered for the guild and returns how many were removed
+func (b *Bot) PurgeCommands() (int, error) {
 	cmds, err := b.ApplicationCommands(b.ClientId, b.GuildId)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
-	for _, cmd := range cmds {
-		if slices.Contains(slices.Collect(maps.Keys(commands)), cmd.Name) || slices.Contains(slices.Collect(maps.Keys(aliases)), cmd.Name) {
-			continue
-		}
-
+	for i, cmd := range cmds {
 		b.logger.Debug("deleting command", "command", cmd.Name)
 		if err := b.ApplicationCommandDelete(b.ClientId, b.GuildId, cmd.ID); err != nil {
-			return err
+			return i, errors.Wrap(err, "deleting command "+cmd.Name)
 		}
 	}
 
-	return b.Session.Close()
+	return len(cmds), nil
 }
 
 func (b *Bot) UpdateCustomStatus(status string) error {
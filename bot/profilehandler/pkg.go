@@ -4,12 +4,15 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/pkg/errors"
 	attdnc "github.com/sol-armada/sol-bot/attendance"
 	"github.com/sol-armada/sol-bot/bot/internal/command"
+	"github.com/sol-armada/sol-bot/giveaway"
 	"github.com/sol-armada/sol-bot/members"
+	"github.com/sol-armada/sol-bot/raffles"
 	"github.com/sol-armada/sol-bot/ranks"
 	"github.com/sol-armada/sol-bot/rsi"
 	"github.com/sol-armada/sol-bot/settings"
@@ -105,6 +108,11 @@ func (c *ProfileCommand) CommandHandler(ctx context.Context, s *discordgo.Sessio
 
 					logger.Debug("rsi user not found", "member", otherMember, "error", err.Error())
 					otherMember.RSIMember = false
+					otherMember.Dossier = nil
+				}
+
+				if otherMember.RSIMember {
+					rsi.UpdateDossier(otherMember, logger)
 				}
 
 				discordMember, err := s.GuildMember(i.GuildID, otherMember.Id)
@@ -157,7 +165,7 @@ func (c *ProfileCommand) CommandHandler(ctx context.Context, s *discordgo.Sessio
 	emFields := []*discordgo.MessageEmbedField{
 		{
 			Name:   "RSI Handle",
-			Value:  member.Name,
+			Value:  member.Handle(),
 			Inline: false,
 		},
 		{
@@ -196,7 +204,7 @@ func (c *ProfileCommand) CommandHandler(ctx context.Context, s *discordgo.Sessio
 		rsiFields := []*discordgo.MessageEmbedField{
 			{
 				Name:   "RSI Profile URL",
-				Value:  rsi.UserProfileURL(member.Name),
+				Value:  rsi.UserProfileURL(member.Handle()),
 				Inline: false,
 			},
 			{
@@ -211,19 +219,58 @@ func (c *ProfileCommand) CommandHandler(ctx context.Context, s *discordgo.Sessio
 			},
 		}
 		emFields = append(emFields, rsiFields...)
+
+		if member.Dossier != nil {
+			emFields = append(emFields, member.Dossier.EmbedFields(time.Now())...)
+		}
+	}
+
+	available, err := tokens.GetAvailableBalanceByMemberId(member.Id)
+	if err != nil {
+		logger.Error("getting available balance", "error", err)
+		available = 0
 	}
 
-	balance, err := tokens.GetBalanceByMemberId(member.Id)
+	held, err := tokens.GetHeldByMemberId(member.Id)
 	if err != nil {
-		logger.Error("getting balance", "error", err)
-		balance = 0
+		logger.Error("getting held balance", "error", err)
+		held = 0
 	}
 
-	emFields = append(emFields, &discordgo.MessageEmbedField{
-		Name:   "Tokens",
-		Value:  fmt.Sprintf("%d", balance),
-		Inline: false,
-	})
+	emFields = append(emFields,
+		&discordgo.MessageEmbedField{
+			Name:   "Tokens",
+			Value:  fmt.Sprintf("%d", available),
+			Inline: true,
+		},
+		&discordgo.MessageEmbedField{
+			Name:   "Tokens Held",
+			Value:  fmt.Sprintf("%d", held),
+			Inline: true,
+		},
+	)
+
+	raffleSummary, err := raffles.GetMemberSummary(member.Id)
+	if err != nil {
+		logger.Error("getting raffle summary", "error", err)
+	} else {
+		emFields = append(emFields, &discordgo.MessageEmbedField{
+			Name:   "Raffles",
+			Value:  fmt.Sprintf("%d entered, %d won, %d tokens spent", raffleSummary.Entries, raffleSummary.Wins, raffleSummary.TokensSpent),
+			Inline: false,
+		})
+	}
+
+	giveawaysWon, err := giveaway.History(giveaway.HistoryFilter{Winner: member.Id}, 0)
+	if err != nil {
+		logger.Error("getting giveaway history", "error", err)
+	} else {
+		emFields = append(emFields, &discordgo.MessageEmbedField{
+			Name:   "Giveaways Won",
+			Value:  fmt.Sprintf("%d", len(giveawaysWon)),
+			Inline: true,
+		})
+	}
 
 	memberIssues := attdnc.Issues(member)
 	if len(memberIssues) > 0 {
@@ -236,6 +283,7 @@ func (c *ProfileCommand) CommandHandler(ctx context.Context, s *discordgo.Sessio
 
 	em := &discordgo.MessageEmbed{
 		Title:       "Profile",
+		Thumbnail:   dossierThumbnail(member.Dossier),
 		Description: fmt.Sprintf("Information about <@%s> in Sol Armada", member.Id),
 		Color:       0x00FFFF,
 		Fields:      emFields,
@@ -269,6 +317,13 @@ func (c *ProfileCommand) CommandHandler(ctx context.Context, s *discordgo.Sessio
 	return nil
 }
 
+func dossierThumbnail(dossier *members.Dossier) *discordgo.MessageEmbedThumbnail {
+	if dossier == nil || dossier.Avatar == "" {
+		return nil
+	}
+	return &discordgo.MessageEmbedThumbnail{URL: dossier.Avatar}
+}
+
 // ModalHandler implements [command.ApplicationCommand].
 func (c *ProfileCommand) ModalHandler(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) error {
 	return nil
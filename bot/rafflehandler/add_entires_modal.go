@@ -2,6 +2,7 @@ package rafflehandler
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"strconv"
@@ -56,27 +57,37 @@ func addEntriesModal(ctx context.Context, s *discordgo.Session, i *discordgo.Int
 		})
 	}
 
-	balance, err := tokens.GetBalanceByMemberId(i.Member.User.ID)
-	if err != nil {
-		return err
-	}
+	if err := raffle.Enter(i.Member.User.ID, ticketCount); err != nil {
+		if errors.Is(err, raffles.ErrTooManyTickets) {
+			return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+				Type: discordgo.InteractionResponseChannelMessageWithSource,
+				Data: &discordgo.InteractionResponseData{
+					Content: fmt.Sprintf("This raffle allows at most %d tickets per member. Please try again.", raffle.Rules.MaxTickets),
+					Flags:   discordgo.MessageFlagsEphemeral,
+				},
+			})
+		}
+
+		if !errors.Is(err, tokens.ErrInsufficientTokens) {
+			return err
+		}
 
-	if balance < ticketCount {
-		logger.Debug("insufficient balance", "balance", balance)
+		available, err := tokens.GetAvailableForHold(i.Member.User.ID, raffle.HoldReference())
+		if err != nil {
+			return err
+		}
+
+		logger.Debug("insufficient balance", "available", available)
 
 		return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 			Type: discordgo.InteractionResponseChannelMessageWithSource,
 			Data: &discordgo.InteractionResponseData{
-				Content: fmt.Sprintf("You only have %d Tokens! Please try again.", balance),
+				Content: fmt.Sprintf("You only have %d Tokens available! Tokens entered in other raffles are held until they end. Please try again.", available),
 				Flags:   discordgo.MessageFlagsEphemeral,
 			},
 		})
 	}
 
-	if err := raffle.AddTicket(i.Member.User.ID, ticketCount).Save(); err != nil {
-		return err
-	}
-
 	if err := raffle.UpdateMessage(s); err != nil {
 		return err
 	}
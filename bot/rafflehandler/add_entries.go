@@ -2,6 +2,7 @@ package rafflehandler
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -42,26 +43,29 @@ func addEntries(ctx context.Context, s *discordgo.Session, i *discordgo.Interact
 		}
 	}
 
-	won, err := raffle.MemberWonLast(i.Member.User.ID)
-	if err != nil {
-		return err
-	}
+	if err := raffle.CanEnter(i.Member.User.ID); err != nil {
+		if !errors.Is(err, raffles.ErrOnCooldown) {
+			return err
+		}
 
-	if won {
 		return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 			Type: discordgo.InteractionResponseChannelMessageWithSource,
 			Data: &discordgo.InteractionResponseData{
-				Content: "You are on a raffle lockout and cannot enter this one. You will be able to enter the next.",
+				Content: "You won a recent raffle and are on a raffle cooldown, so you cannot enter this one.",
 				Flags:   discordgo.MessageFlagsEphemeral,
 			},
 		})
 	}
 
-	tokens, err := tokens.GetBalanceByMemberId(i.Member.User.ID)
+	tokens, err := tokens.GetAvailableForHold(i.Member.User.ID, raffle.HoldReference())
 	if err != nil {
 		return err
 	}
 
+	if raffle.Rules.MaxTickets > 0 {
+		tokens = min(tokens, raffle.Rules.MaxTickets)
+	}
+
 	if err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseModal,
 		Data: &discordgo.InteractionResponseData{
@@ -32,7 +32,7 @@ func backOut(ctx context.Context, s *discordgo.Session, i *discordgo.Interaction
 		return errors.Join(err, errors.New("getting raffle"))
 	}
 
-	if err := raffle.RemoveTicket(i.Member.User.ID).Save(); err != nil {
+	if err := raffle.Withdraw(i.Member.User.ID); err != nil {
 		return errors.Join(err, errors.New("removing ticket"))
 	}
 
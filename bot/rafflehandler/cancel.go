@@ -30,7 +30,7 @@ func cancel(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionC
 		return err
 	}
 
-	if err := raffle.Delete(); err != nil {
+	if err := raffle.Cancel(); err != nil {
 		return err
 	}
 
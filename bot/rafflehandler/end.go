@@ -2,12 +2,10 @@ package rafflehandler
 
 import (
 	"context"
-	"fmt"
 	"strings"
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/sol-armada/sol-bot/raffles"
-	"github.com/sol-armada/sol-bot/tokens"
 	"github.com/sol-armada/sol-bot/utils"
 )
 
@@ -41,40 +39,5 @@ func end(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCrea
 		return err
 	}
 
-	winners, err := raffle.PickWinner()
-	if err != nil {
-		if err == raffles.ErrNoEntries {
-			raffle.Ended = true
-			if err := raffle.Save(); err != nil {
-				return err
-			}
-		}
-
-		return raffle.UpdateMessage(s)
-	}
-
-	var winnerNames strings.Builder
-	for j, winner := range winners {
-		winnerNames.WriteString("<@")
-		winnerNames.WriteString(winner.Id)
-		winnerNames.WriteString(">")
-		if j < len(winners)-1 {
-			if j == len(winners)-2 {
-				winnerNames.WriteString(" and ")
-			}
-			winnerNames.WriteString(", ")
-		}
-
-		if !raffle.Test {
-			if err := tokens.New(winner.Id, raffle.Tickets[winner.Id]*-1, tokens.ReasonWonRaffle, nil, &raffle.AttedanceId, nil).Save(); err != nil {
-				return err
-			}
-		}
-	}
-
-	if _, err := s.ChannelMessageSend(i.Interaction.ChannelID, fmt.Sprintf("🎊 Congratulations to %s! They have won the raffle! 🎊", winnerNames.String())); err != nil {
-		return err
-	}
-
-	return raffle.UpdateMessage(s)
+	return raffle.Finish(s)
 }
@@ -0,0 +1,91 @@
+package rafflehandler
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/sol-armada/sol-bot/raffles"
+	"github.com/sol-armada/sol-bot/utils"
+)
+
+const historyLimit = 10
+
+func history(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	logger := utils.GetLoggerFromContext(ctx)
+	logger.Debug("raffle history command")
+
+	filter := raffles.ListFilter{EndedOnly: true}
+	for _, option := range i.ApplicationCommandData().Options[0].Options {
+		switch option.Name {
+		case "winner":
+			filter.Winner = option.UserValue(nil).ID
+		case "prize":
+			filter.Prize = option.StringValue()
+		case "attendance":
+			filter.AttendanceId = option.StringValue()
+		case "days":
+			filter.Since = time.Now().UTC().AddDate(0, 0, -int(option.IntValue()))
+		}
+	}
+
+	past, err := raffles.History(filter, historyLimit)
+	if err != nil {
+		return err
+	}
+
+	if len(past) == 0 {
+		_, err := s.FollowupMessageCreate(i.Interaction, false, &discordgo.WebhookParams{
+			Content: "No raffles found.",
+			Flags:   discordgo.MessageFlagsEphemeral,
+		})
+		return err
+	}
+
+	fields := make([]*discordgo.MessageEmbedField, 0, len(past))
+	for _, raffle := range past {
+		winners := mentions(raffle.Winners)
+
+		value := fmt.Sprintf("Prize: %s\nWinners: %s\nEntries: %d\n<t:%d:d> | ID: `%s`", raffle.Prize, winners, len(raffle.Tickets), raffle.CreatedAt.Unix(), raffle.Id)
+		if raffle.AttedanceId != "" {
+			value += fmt.Sprintf(" | Attendance: `%s`", raffle.AttedanceId)
+		}
+
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name:   raffle.Name,
+			Value:  value,
+			Inline: false,
+		})
+	}
+
+	description := []string{}
+	if filter.Winner != "" {
+		description = append(description, fmt.Sprintf("Won by <@%s>", filter.Winner))
+	}
+	if filter.Prize != "" {
+		description = append(description, "Prize contains "+filter.Prize)
+	}
+	if filter.AttendanceId != "" {
+		description = append(description, "Attendance "+filter.AttendanceId)
+	}
+	if !filter.Since.IsZero() {
+		description = append(description, fmt.Sprintf("Since <t:%d:d>", filter.Since.Unix()))
+	}
+
+	_, err = s.FollowupMessageCreate(i.Interaction, false, &discordgo.WebhookParams{
+		Embeds: []*discordgo.MessageEmbed{
+			{
+				Title:       "Raffle History",
+				Description: strings.Join(description, "\n"),
+				Fields:      fields,
+				Footer: &discordgo.MessageEmbedFooter{
+					Text: fmt.Sprintf("Showing the latest %d. Test raffles are not included", len(past)),
+				},
+			},
+		},
+		Flags: discordgo.MessageFlagsEphemeral,
+	})
+	return err
+}
@@ -15,8 +15,14 @@ type RaffleCommand struct{}
 
 var _ command.ApplicationCommand = (*RaffleCommand)(nil)
 
+var subCommands = map[string]func(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) error{
+	"start":   start,
+	"verify":  verify,
+	"history": history,
+}
+
 var autoCompletes = map[string]func(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) error{
-	"name": startAutocomplete,
+	"start": startAutocomplete,
 }
 
 var buttons = map[string]func(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) error{
@@ -43,24 +49,82 @@ func (r *RaffleCommand) Setup() (*discordgo.ApplicationCommand, error) {
 		Type:        discordgo.ChatApplicationCommand,
 		Options: []*discordgo.ApplicationCommandOption{
 			{
-				Type:         discordgo.ApplicationCommandOptionString,
-				Name:         "name",
-				Description:  "The name of the raffle. If linked to an attendance record, use the attendance record Name",
-				Required:     true,
-				Autocomplete: true,
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "start",
+				Description: "Start a raffle",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:         discordgo.ApplicationCommandOptionString,
+						Name:         "name",
+						Description:  "The name of the raffle. If linked to an attendance record, use the attendance record Name",
+						Required:     true,
+						Autocomplete: true,
+					},
+					{
+						Type:         discordgo.ApplicationCommandOptionString,
+						Name:         "prize",
+						Description:  "The prize. To add quantity, use 'item:qty'. E.g. 'Pickles:2'",
+						Required:     true,
+						Autocomplete: false,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionBoolean,
+						Name:        "test",
+						Description: "Whether this is a test raffle (won't be logged)",
+						Required:    false,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "duration",
+						Description: "Draw automatically after this long. E.g. '2h30m' or '1d'",
+						Required:    false,
+					},
+				},
 			},
 			{
-				Type:         discordgo.ApplicationCommandOptionString,
-				Name:         "prize",
-				Description:  "The prize. To add quantity, use 'item:qty'. E.g. 'Pickles:2'",
-				Required:     true,
-				Autocomplete: false,
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "verify",
+				Description: "Recompute a raffle's winners from its revealed seed",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "raffle",
+						Description: "The raffle ID shown at the bottom of the raffle",
+						Required:    true,
+					},
+				},
 			},
 			{
-				Type:        discordgo.ApplicationCommandOptionBoolean,
-				Name:        "test",
-				Description: "Whether this is a test raffle (won't be logged)",
-				Required:    false,
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "history",
+				Description: "List past raffles",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionUser,
+						Name:        "winner",
+						Description: "Only raffles this member won",
+						Required:    false,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "prize",
+						Description: "Only raffles with a prize containing this",
+						Required:    false,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "attendance",
+						Description: "Only raffles linked to this attendance ID",
+						Required:    false,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						Name:        "days",
+						Description: "Only raffles from the last this many days",
+						Required:    false,
+						MinValue:    new(1.0),
+					},
+				},
 			},
 		},
 	}, nil
@@ -118,7 +182,13 @@ func (r *RaffleCommand) CommandHandler(ctx context.Context, s *discordgo.Session
 	// 	return customerrors.InvalidPermissions
 	// }
 
-	return start(ctx, s, i)
+	data := i.ApplicationCommandData()
+	handler, ok := subCommands[data.Options[0].Name]
+	if !ok {
+		return customerrors.InvalidSubcommand
+	}
+
+	return handler(ctx, s, i)
 }
 
 // ModalHandler implements [command.ApplicationCommand].
@@ -18,7 +18,7 @@ func startAutocomplete(ctx context.Context, s *discordgo.Session, i *discordgo.I
 
 	choices := []*discordgo.ApplicationCommandOptionChoice{}
 
-	for _, option := range data.Options {
+	for _, option := range data.Options[0].Options {
 		switch option.Name {
 		case "name":
 			if option.Focused {
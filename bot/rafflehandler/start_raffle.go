@@ -2,6 +2,7 @@ package rafflehandler
 
 import (
 	"context"
+	"time"
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/sol-armada/sol-bot/attendance"
@@ -13,13 +14,31 @@ func start(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCr
 	logger := utils.GetLoggerFromContext(ctx)
 	logger.Debug("raffle start command")
 
-	data := i.ApplicationCommandData()
-
-	attendanceRecordId := data.Options[0].Value.(string)
-	prize := data.Options[1].Value.(string)
-	test := false
-	if len(data.Options) > 2 {
-		test = data.Options[2].Value.(bool)
+	var (
+		attendanceRecordId string
+		prize              string
+		test               bool
+		duration           time.Duration
+	)
+	for _, option := range i.ApplicationCommandData().Options[0].Options {
+		switch option.Name {
+		case "name":
+			attendanceRecordId = option.StringValue()
+		case "prize":
+			prize = option.StringValue()
+		case "test":
+			test = option.BoolValue()
+		case "duration":
+			d, err := utils.StringToDuration(option.StringValue())
+			if err != nil || d <= 0 {
+				_, err := s.FollowupMessageCreate(i.Interaction, false, &discordgo.WebhookParams{
+					Content: "Invalid duration. Please use the format 1d2h30m.",
+					Flags:   discordgo.MessageFlagsEphemeral,
+				})
+				return err
+			}
+			duration = d
+		}
 	}
 
 	name := attendanceRecordId
@@ -30,7 +49,21 @@ func start(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCr
 		attendanceRecordId = ""
 	}
 
-	raffle := raffles.New(name, attendanceRecordId, prize, test)
+	rules, err := raffles.LoadRules()
+	if err != nil {
+		return err
+	}
+
+	raffle := raffles.New(name, attendanceRecordId, prize, test, rules)
+
+	if duration > 0 {
+		reminders, err := raffles.LoadReminders()
+		if err != nil {
+			return err
+		}
+
+		raffle.Schedule(duration, reminders)
+	}
 
 	embed, err := raffle.GetEmbed()
 	if err != nil {
@@ -0,0 +1,101 @@
+package rafflehandler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/sol-armada/sol-bot/raffles"
+	"github.com/sol-armada/sol-bot/utils"
+)
+
+func verify(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	logger := utils.GetLoggerFromContext(ctx)
+	logger.Debug("raffle verify command")
+
+	raffleId := strings.TrimSpace(i.ApplicationCommandData().Options[0].Options[0].StringValue())
+
+	raffle, err := raffles.Get(raffleId)
+	if err != nil {
+		if errors.Is(err, raffles.ErrRaffleNotFound) {
+			_, err := s.FollowupMessageCreate(i.Interaction, false, &discordgo.WebhookParams{
+				Content: "I could not find a raffle with that ID.",
+				Flags:   discordgo.MessageFlagsEphemeral,
+			})
+			return err
+		}
+		return err
+	}
+
+	verification, err := raffle.Verify()
+	if err != nil {
+		if errors.Is(err, raffles.ErrRaffleNotDrawn) {
+			_, err := s.FollowupMessageCreate(i.Interaction, false, &discordgo.WebhookParams{
+				Content: "That raffle has not been drawn with a committed seed, so there is nothing to verify.",
+				Flags:   discordgo.MessageFlagsEphemeral,
+			})
+			return err
+		}
+		return err
+	}
+
+	check := func(ok bool) string {
+		if ok {
+			return "✅ Yes"
+		}
+		return "❌ No"
+	}
+
+	var entries strings.Builder
+	for _, entry := range raffle.DrawEntries {
+		line := fmt.Sprintf("<@%s> | %g\n", entry.Id, entry.Weight)
+		if entries.Len()+len(line) > 1000 {
+			entries.WriteString("...")
+			break
+		}
+		entries.WriteString(line)
+	}
+
+	color := 0x00FF00
+	if !verification.Valid() {
+		color = 0xFF0000
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:       raffle.Name + " Raffle Verification",
+		Description: "The seed must hash (SHA-256) to the commitment published when the raffle started. Each winner is picked with HMAC-SHA256(seed, \"<raffle id>:<round>\") over the entries sorted by member ID, using the weights below. Weights are tickets adjusted by the raffle's rules.",
+		Color:       color,
+		Fields: []*discordgo.MessageEmbedField{
+			{Name: "Seed Matches Commitment", Value: check(verification.CommitmentValid), Inline: true},
+			{Name: "Winners Match", Value: check(verification.WinnersMatch), Inline: true},
+			{Name: "Recorded Winners", Value: mentions(raffle.Winners), Inline: false},
+			{Name: "Recomputed Winners", Value: mentions(verification.Winners), Inline: false},
+			{Name: "Entries", Value: entries.String(), Inline: false},
+			{Name: "Seed", Value: "`" + raffle.Seed + "`", Inline: false},
+			{Name: "Commitment", Value: "`" + raffle.SeedHash + "`", Inline: false},
+		},
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: "Raffle ID: " + raffle.Id,
+		},
+	}
+
+	_, err = s.FollowupMessageCreate(i.Interaction, false, &discordgo.WebhookParams{
+		Embeds: []*discordgo.MessageEmbed{embed},
+		Flags:  discordgo.MessageFlagsEphemeral,
+	})
+	return err
+}
+
+func mentions(ids []string) string {
+	if len(ids) == 0 {
+		return "None"
+	}
+
+	parts := make([]string, len(ids))
+	for j, id := range ids {
+		parts[j] = "<@" + id + ">"
+	}
+	return strings.Join(parts, ", ")
+}
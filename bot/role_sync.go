@@ -0,0 +1,150 @@
+package bot
+
+import (
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/pkg/errors"
+	"github.com/sol-armada/sol-bot/members"
+	"github.com/sol-armada/sol-bot/settings"
+)
+
+const (
+	rolesOff     = "off"
+	rolesReport  = "report"
+	rolesEnforce = "enforce"
+)
+
+// RoleChange is how a member's roles differ from what their stored rank and status say they should be
+type RoleChange struct {
+	MemberId string
+	Name     string
+	members.RoleDiff
+}
+
+// roleSync applies role diffs during a member monitor run. At most batchSize members are changed a run so a bad
+// rank import or RSI outage can't strip roles from the whole server in one go
+type roleSync struct {
+	mode      string
+	batchSize int
+	applied   int
+}
+
+func newRoleSync() *roleSync {
+	mode := strings.ToLower(settings.GetStringWithDefault("FEATURES.ROLES.MODE", rolesReport))
+	if !slices.Contains([]string{rolesOff, rolesReport, rolesEnforce}, mode) {
+		mode = rolesReport
+	}
+
+	return &roleSync{
+		mode:      mode,
+		batchSize: settings.GetIntWithDefault("FEATURES.ROLES.BATCH_SIZE", 25),
+	}
+}
+
+// roleChange returns how the member's roles differ, nil if they don't or RSI couldn't confirm the member's rank
+func roleChange(discordMember *discordgo.Member, member *members.Member) *RoleChange {
+	// without RSI the rank was reset, not looked up
+	if !member.RSIMember {
+		return nil
+	}
+
+	diff := member.RoleDiff(discordMember.Roles)
+	if diff.Empty() {
+		return nil
+	}
+
+	return &RoleChange{
+		MemberId: member.Id,
+		Name:     member.Name,
+		RoleDiff: diff,
+	}
+}
+
+// reconcile logs how the member's roles differ and, when enforcing, changes them while the batch has room
+func (r *roleSync) reconcile(discordMember *discordgo.Member, member *members.Member, logger *slog.Logger) {
+	if r.mode == rolesOff {
+		return
+	}
+
+	change := roleChange(discordMember, member)
+	if change == nil {
+		return
+	}
+
+	logger = logger.With("add", change.Add, "remove", change.Remove)
+	if r.mode != rolesEnforce {
+		logger.Info("roles differ")
+		return
+	}
+
+	if r.applied >= r.batchSize {
+		logger.Info("roles differ, batch limit reached", "batch_size", r.batchSize)
+		return
+	}
+	r.applied++
+
+	for _, key := range change.Add {
+		id := members.RoleID(key)
+		if err := bot.GuildMemberRoleAdd(bot.GuildId, discordMember.User.ID, id); err != nil {
+			logger.Error("adding role", "role", key, "error", err)
+			continue
+		}
+		logger.Info("added role", "role", key)
+		discordMember.Roles = append(discordMember.Roles, id)
+	}
+
+	for _, key := range change.Remove {
+		id := members.RoleID(key)
+		if err := bot.GuildMemberRoleRemove(bot.GuildId, discordMember.User.ID, id); err != nil {
+			logger.Error("removing role", "role", key, "error", err)
+			continue
+		}
+		logger.Info("removed role", "role", key)
+		discordMember.Roles = slices.DeleteFunc(discordMember.Roles, func(role string) bool { return role == id })
+	}
+}
+
+// RoleReport lists how members' roles differ from their stored rank and status without changing any
+func RoleReport(logger *slog.Logger) ([]RoleChange, error) {
+	changes := []RoleChange{}
+	err := forEachStoredMember(logger, func(discordMember *discordgo.Member, member *members.Member) {
+		if change := roleChange(discordMember, member); change != nil {
+			changes = append(changes, *change)
+		}
+	})
+
+	return changes, err
+}
+
+// forEachStoredMember calls fn for every Discord member the monitor would process that has been stored
+func forEachStoredMember(logger *slog.Logger, fn func(discordMember *discordgo.Member, member *members.Member)) error {
+	if bot == nil {
+		return errors.New("bot instance is nil")
+	}
+
+	discordMembers, err := fetchAndValidateDiscordMembers(logger)
+	if err != nil {
+		return errors.Wrap(err, "fetching discord members")
+	}
+
+	stored, err := members.ListAll()
+	if err != nil {
+		return errors.Wrap(err, "listing members")
+	}
+
+	byId := make(map[string]*members.Member, len(stored))
+	for i := range stored {
+		byId[stored[i].Id] = &stored[i]
+	}
+
+	for _, discordMember := range discordMembers {
+		if member, ok := byId[discordMember.User.ID]; ok {
+			fn(discordMember, member)
+		}
+	}
+
+	return nil
+}
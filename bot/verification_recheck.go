@@ -0,0 +1,46 @@
+package bot
+
+import (
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/sol-armada/sol-bot/members"
+	"github.com/sol-armada/sol-bot/rsi"
+)
+
+// recheckVerification makes sure the member's verified RSI handle still exists, catching handle changes.
+// Members whose handle is gone lose their verification and the verified role
+func recheckVerification(discordMember *discordgo.Member, member *members.Member, logger *slog.Logger) {
+	now := time.Now().UTC()
+	if !member.ValidationRecheckDue(now) {
+		return
+	}
+
+	logger = logger.With("rsi_handle", member.RSIHandle)
+	valid, err := rsi.ValidHandle(member.RSIHandle)
+	if err != nil {
+		// RSI couldn't be asked, so check again on the next pass
+		logger.Debug("skipping verification recheck", "error", err)
+		return
+	}
+	if valid {
+		member.ValidationCheckedAt = &now
+		return
+	}
+
+	logger.Warn("verified rsi handle no longer exists, revoking verification")
+	member.RevokeValidation()
+
+	roleId := members.RoleID("VALIDATED")
+	if roleId == "" || bot == nil || !slices.Contains(discordMember.Roles, roleId) {
+		return
+	}
+
+	if err := bot.GuildMemberRoleRemove(bot.GuildId, discordMember.User.ID, roleId); err != nil {
+		logger.Error("removing verified role", "error", err)
+		return
+	}
+	discordMember.Roles = slices.DeleteFunc(discordMember.Roles, func(role string) bool { return role == roleId })
+}
@@ -0,0 +1,161 @@
+package verifyhandler
+
+import (
+	"context"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/sol-armada/sol-bot/bot/internal/command"
+	"github.com/sol-armada/sol-bot/customerrors"
+	"github.com/sol-armada/sol-bot/utils"
+)
+
+type VerifyCommand struct{}
+
+var _ command.ApplicationCommand = (*VerifyCommand)(nil)
+
+type handler func(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) error
+
+var subCommands = map[string]handler{
+	"rsi":   rsiHandler,
+	"panel": panelHandler,
+	"set":   setHandler,
+}
+
+var buttons = map[string]handler{
+	"start": startButtonHandler,
+	"check": checkButtonHandler,
+}
+
+var modals = map[string]handler{
+	"handle": handleModalHandler,
+}
+
+func New() command.ApplicationCommand {
+	return &VerifyCommand{}
+}
+
+// AutocompleteHandler implements [command.ApplicationCommand].
+func (c *VerifyCommand) AutocompleteHandler(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	return nil
+}
+
+// ButtonHandler implements [command.ApplicationCommand].
+func (c *VerifyCommand) ButtonHandler(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	logger := utils.GetLoggerFromContext(ctx)
+	logger.Debug("verify button handler")
+
+	action := strings.Split(i.MessageComponentData().CustomID, ":")[1]
+	if h, ok := buttons[action]; ok {
+		return h(ctx, s, i)
+	}
+
+	return customerrors.InvalidButton
+}
+
+// CommandHandler implements [command.ApplicationCommand].
+func (c *VerifyCommand) CommandHandler(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	logger := utils.GetLoggerFromContext(ctx)
+	logger.Debug("verify command handler")
+
+	if h, ok := subCommands[i.ApplicationCommandData().Options[0].Name]; ok {
+		return h(ctx, s, i)
+	}
+
+	return customerrors.InvalidSubcommand
+}
+
+// ModalHandler implements [command.ApplicationCommand].
+func (c *VerifyCommand) ModalHandler(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	logger := utils.GetLoggerFromContext(ctx)
+	logger.Debug("verify modal handler")
+
+	action := strings.Split(i.ModalSubmitData().CustomID, ":")[1]
+	if h, ok := modals[action]; ok {
+		return h(ctx, s, i)
+	}
+
+	return customerrors.InvalidModal
+}
+
+// Name implements [command.ApplicationCommand].
+func (c *VerifyCommand) Name() string {
+	return "verify"
+}
+
+// OnAfter implements [command.ApplicationCommand].
+func (c *VerifyCommand) OnAfter(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	return nil
+}
+
+// OnBefore implements [command.ApplicationCommand].
+func (c *VerifyCommand) OnBefore(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	return nil
+}
+
+// OnError implements [command.ApplicationCommand].
+func (c *VerifyCommand) OnError(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate, err error) {
+	logger := utils.GetLoggerFromContext(ctx)
+	logger.Error("handling verify command", "error", err)
+}
+
+// SelectMenuHandler implements [command.ApplicationCommand].
+func (c *VerifyCommand) SelectMenuHandler(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	return nil
+}
+
+// Setup implements [command.ApplicationCommand].
+func (c *VerifyCommand) Setup() (*discordgo.ApplicationCommand, error) {
+	return &discordgo.ApplicationCommand{
+		Name:        c.Name(),
+		Description: "Verify your RSI handle",
+		Type:        discordgo.ChatApplicationCommand,
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "rsi",
+				Description: "Prove you own an RSI handle by putting a code in your bio",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "handle",
+						Description: "Your RSI handle, defaults to your nickname",
+					},
+				},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "panel",
+				Description: "Post a message with a button members can verify from",
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "set",
+				Description: "Correct a member's RSI handle",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionUser,
+						Name:        "member",
+						Description: "The member to correct",
+						Required:    true,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "handle",
+						Description: "Their RSI handle",
+						Required:    true,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionBoolean,
+						Name:        "verified",
+						Description: "Mark the handle as verified without a bio code",
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+func (c *VerifyCommand) SetupAliases() ([]*discordgo.ApplicationCommand, error) {
+	return nil, nil
+}
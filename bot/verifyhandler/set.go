@@ -0,0 +1,78 @@
+package verifyhandler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/sol-armada/sol-bot/customerrors"
+	"github.com/sol-armada/sol-bot/members"
+	"github.com/sol-armada/sol-bot/rsi"
+	"github.com/sol-armada/sol-bot/utils"
+)
+
+// setHandler lets officers correct a member's RSI handle
+func setHandler(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	logger := utils.GetLoggerFromContext(ctx)
+
+	if !utils.Allowed(i.Member, "VERIFY") {
+		return customerrors.InvalidPermissions
+	}
+
+	var memberId, handle string
+	verified := false
+	for _, opt := range i.ApplicationCommandData().Options[0].Options {
+		switch opt.Name {
+		case "member":
+			memberId = opt.UserValue(nil).ID
+		case "handle":
+			handle = opt.StringValue()
+		case "verified":
+			verified = opt.BoolValue()
+		}
+	}
+
+	member, err := members.Get(memberId)
+	if err != nil {
+		if errors.Is(err, members.MemberNotFound) {
+			return respond(s, i, fmt.Sprintf("<@%s> hasn't been seen by the bot yet", memberId))
+		}
+		return err
+	}
+
+	valid, err := rsi.ValidHandle(handle)
+	if err != nil {
+		logger.Warn("checking rsi handle", "handle", handle, "error", err)
+		return respond(s, i, "I couldn't reach RSI to check that handle, try again in a few minutes")
+	}
+	if !valid {
+		return respond(s, i, fmt.Sprintf("I couldn't find the RSI handle %s", handle))
+	}
+
+	old := member.Handle()
+	member.SetRSIHandle(handle)
+	if verified {
+		now := time.Now().UTC()
+		member.Validated = true
+		member.ValidatedAt = &now
+		member.ValidationCheckedAt = &now
+	}
+
+	if err := rsi.UpdateRsiInfo(member); err != nil && !errors.Is(err, rsi.ErrUserNotFound) {
+		logger.Warn("updating rsi info after setting handle", "error", err)
+	}
+
+	if err := member.Save(); err != nil {
+		return err
+	}
+	logger.Info("rsi handle set", "member", member.Id, "old", old, "new", member.RSIHandle, "verified", member.Validated)
+
+	content := fmt.Sprintf("Set <@%s>'s RSI handle to %s", member.Id, member.RSIHandle)
+	if !member.Validated {
+		content += "\nThey are not verified, they can verify with /verify rsi"
+	}
+
+	return respond(s, i, content)
+}
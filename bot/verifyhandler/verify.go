@@ -0,0 +1,256 @@
+package verifyhandler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/sol-armada/sol-bot/customerrors"
+	"github.com/sol-armada/sol-bot/members"
+	"github.com/sol-armada/sol-bot/rsi"
+	"github.com/sol-armada/sol-bot/utils"
+)
+
+func rsiHandler(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	member := utils.GetMemberFromContext(ctx).(*members.Member)
+
+	handle := ""
+	if opts := i.ApplicationCommandData().Options[0].Options; len(opts) > 0 {
+		handle = opts[0].StringValue()
+	}
+
+	return startVerification(ctx, s, i, member, handle)
+}
+
+func panelHandler(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	if !utils.Allowed(i.Member, "VERIFY") {
+		return customerrors.InvalidPermissions
+	}
+
+	if _, err := s.ChannelMessageSendComplex(i.ChannelID, &discordgo.MessageSend{
+		Embeds: []*discordgo.MessageEmbed{
+			{
+				Title:       "Verify your RSI handle",
+				Description: "Click the button below and enter your RSI handle. You'll be given a code to put in the short bio of your RSI profile to prove the handle is yours.",
+			},
+		},
+		Components: []discordgo.MessageComponent{
+			discordgo.ActionsRow{
+				Components: []discordgo.MessageComponent{
+					discordgo.Button{
+						Label:    "Verify",
+						CustomID: "verify:start",
+						Style:    discordgo.PrimaryButton,
+						Emoji:    &discordgo.ComponentEmoji{Name: "✅"},
+					},
+				},
+			},
+		},
+	}); err != nil {
+		return err
+	}
+
+	return respond(s, i, "Posted the verify panel")
+}
+
+// startButtonHandler asks for the handle from the verify panel
+func startButtonHandler(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	member := utils.GetMemberFromContext(ctx).(*members.Member)
+
+	handle := member.RSIHandle
+	if handle == "" {
+		handle = member.GetTrueNick(i.Member)
+	}
+
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseModal,
+		Data: &discordgo.InteractionResponseData{
+			CustomID: "verify:handle",
+			Title:    "What is your RSI Handle?",
+			Components: []discordgo.MessageComponent{
+				discordgo.ActionsRow{
+					Components: []discordgo.MessageComponent{
+						discordgo.TextInput{
+							CustomID:    "rsi_handle",
+							Label:       "Your RSI handle",
+							Style:       discordgo.TextInputShort,
+							Placeholder: "Your handle can be found on your public RSI page",
+							Value:       handle,
+							Required:    new(true),
+						},
+					},
+				},
+			},
+		},
+	})
+}
+
+func handleModalHandler(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	member := utils.GetMemberFromContext(ctx).(*members.Member)
+
+	if err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Flags: discordgo.MessageFlagsEphemeral,
+		},
+	}); err != nil {
+		return err
+	}
+
+	handle := i.ModalSubmitData().Components[0].(*discordgo.ActionsRow).Components[0].(*discordgo.TextInput).Value
+
+	return startVerification(ctx, s, i, member, handle)
+}
+
+// startVerification gives the member a code for the handle. The interaction must already be deferred
+func startVerification(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate, member *members.Member, handle string) error {
+	logger := utils.GetLoggerFromContext(ctx)
+
+	handle = strings.TrimSpace(handle)
+	if handle == "" {
+		handle = member.RSIHandle
+	}
+	if handle == "" {
+		handle = member.GetTrueNick(i.Member)
+	}
+
+	if member.Validated && strings.EqualFold(handle, member.RSIHandle) {
+		return respond(s, i, fmt.Sprintf("You are already verified as %s!", member.RSIHandle))
+	}
+
+	now := time.Now().UTC()
+	if retryAt, err := member.AllowValidationAttempt(now); err != nil {
+		return respond(s, i, fmt.Sprintf("You've tried to verify too many times. You can try again <t:%d:R>", retryAt.Unix()))
+	}
+
+	valid, err := rsi.ValidHandle(handle)
+	if err != nil {
+		// not counted against their attempts, it wasn't their mistake
+		logger.Warn("checking rsi handle", "handle", handle, "error", err)
+		return respond(s, i, "I couldn't reach RSI to check your handle, try again in a few minutes")
+	}
+	if !valid {
+		if err := member.Save(); err != nil {
+			return err
+		}
+		return respond(s, i, fmt.Sprintf("I couldn't find the RSI handle %s. Check the spelling on your [RSI profile](https://robertsspaceindustries.com/account/profile) and try again", handle))
+	}
+
+	code := member.StartValidation(handle, now)
+	if err := member.Save(); err != nil {
+		return err
+	}
+	logger.Info("verification started", "handle", handle)
+
+	content := fmt.Sprintf("Please insert the code below anywhere into the short bio section of your [RSI profile](https://robertsspaceindustries.com/account/profile), then click \"APPLY ALL CHANGES\" on the page. Wait a few seconds, then click the \"Check\" button.\n\n%s\n\nThe code expires <t:%d:R>", code, member.ValidationExpiresAt.Unix())
+	_, err = s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+		Content:    &content,
+		Components: &[]discordgo.MessageComponent{checkButton(member.Id)},
+	})
+	return err
+}
+
+func checkButtonHandler(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	logger := utils.GetLoggerFromContext(ctx)
+
+	memberId := strings.Split(i.MessageComponentData().CustomID, ":")[2]
+	if memberId != i.Member.User.ID {
+		return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{
+				Content: "This isn't your verification. Use /verify rsi to start your own",
+				Flags:   discordgo.MessageFlagsEphemeral,
+			},
+		})
+	}
+
+	if err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredMessageUpdate,
+	}); err != nil {
+		return err
+	}
+
+	member, err := members.Get(memberId)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	code, err := member.PendingValidation(now)
+	if err != nil {
+		msg := "I'm not prepared to verify your handle. Please run /verify rsi again"
+		if errors.Is(err, members.ErrValidationExpired) {
+			msg = "Your code expired. Please run /verify rsi again for a new one"
+		}
+		return update(s, i, msg, nil)
+	}
+
+	if retryAt, err := member.AllowValidationAttempt(now); err != nil {
+		return update(s, i, fmt.Sprintf("You've tried to verify too many times. You can try again <t:%d:R>", retryAt.Unix()), nil)
+	}
+
+	bio, err := rsi.GetBio(member.ValidationHandle)
+	if err != nil {
+		if errors.Is(err, rsi.ErrUserNotFound) {
+			return update(s, i, fmt.Sprintf("I couldn't find the RSI handle %s. Please run /verify rsi again", member.ValidationHandle), nil)
+		}
+		return err
+	}
+
+	if !strings.Contains(bio, code) {
+		if err := member.Save(); err != nil {
+			return err
+		}
+		return update(s, i, fmt.Sprintf("I could not find the code on your profile. Make sure you clicked \"APPLY ALL CHANGES\", give it a minute and click \"Check\" again.\n\n%s", code), new(checkButton(member.Id)))
+	}
+
+	member.CompleteValidation(now)
+	if err := member.Save(); err != nil {
+		return err
+	}
+	logger.Info("verified rsi handle", "handle", member.RSIHandle)
+
+	if roleId := members.RoleID("VALIDATED"); roleId != "" {
+		if err := s.GuildMemberRoleAdd(i.GuildID, member.Id, roleId); err != nil {
+			logger.Error("adding verified role", "error", err)
+		}
+	}
+
+	return update(s, i, fmt.Sprintf("You've been verified as %s! You can remove the code from your bio.", member.RSIHandle), nil)
+}
+
+func checkButton(memberId string) discordgo.MessageComponent {
+	return discordgo.ActionsRow{
+		Components: []discordgo.MessageComponent{
+			discordgo.Button{
+				Label:    "Check",
+				CustomID: "verify:check:" + memberId,
+				Emoji:    &discordgo.ComponentEmoji{Name: "✅"},
+			},
+		},
+	}
+}
+
+func respond(s *discordgo.Session, i *discordgo.InteractionCreate, content string) error {
+	_, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+		Content: &content,
+	})
+	return err
+}
+
+// update replaces the verification message, dropping the check button unless one is given
+func update(s *discordgo.Session, i *discordgo.InteractionCreate, content string, button *discordgo.MessageComponent) error {
+	components := []discordgo.MessageComponent{}
+	if button != nil {
+		components = append(components, *button)
+	}
+
+	_, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+		Content:    &content,
+		Components: &components,
+	})
+	return err
+}
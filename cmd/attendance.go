@@ -0,0 +1,43 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/sol-armada/sol-bot/attendance"
+)
+
+// runAttendance handles the attendance subcommand
+func runAttendance(ctx context.Context, cfg *Config, args []string) error {
+	if len(args) == 0 || args[0] != "export" {
+		return errUsage
+	}
+
+	fs := newFlagSet("attendance export")
+	id := fs.String("id", "", "attendance record id")
+	if err := fs.Parse(args[1:]); err != nil {
+		return errUsage
+	}
+	if *id == "" {
+		return errUsage
+	}
+
+	record, err := attendance.Get(*id)
+	if err != nil {
+		if errors.Is(err, attendance.ErrAttendanceNotFound) {
+			return fmt.Errorf("attendance record %s not found", *id)
+		}
+		return err
+	}
+
+	// same format as the export button
+	names := []string{}
+	for _, member := range record.GetMembers(true) {
+		names = append(names, member.Name)
+	}
+
+	fmt.Println(strings.Join(names, ","))
+	return nil
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/sol-armada/sol-bot/backup"
+	"github.com/sol-armada/sol-bot/stores"
+)
+
+// runBackup handles the backup subcommand
+func runBackup(ctx context.Context, cfg *Config, args []string) error {
+	fs := newFlagSet("backup")
+	out := fs.String("out", "solbot-backup-"+time.Now().UTC().Format("20060102-150405")+".tar.gz", "archive to write")
+	if err := fs.Parse(args); err != nil {
+		return errUsage
+	}
+
+	client := stores.Get()
+	if client == nil {
+		return errors.New("storage client not initialized")
+	}
+
+	f, err := os.OpenFile(*out, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	manifest, err := backup.Backup(ctx, client.Database(), f)
+	if err != nil {
+		_ = os.Remove(*out)
+		return err
+	}
+
+	for _, c := range manifest.Collections {
+		fmt.Printf("%-20s %d\n", c.Name, c.Documents)
+	}
+	fmt.Printf("wrote %s\n", *out)
+
+	return f.Close()
+}
+
+// runRestore handles the restore subcommand
+func runRestore(ctx context.Context, cfg *Config, args []string) error {
+	fs := newFlagSet("restore")
+	in := fs.String("in", "", "archive to read")
+	dryRun := fs.Bool("dry-run", false, "validate the archive without writing")
+	collections := fs.String("collections", "", "comma separated collections to restore, defaults to all")
+	yes := fs.Bool("yes", false, "confirm that existing collections will be replaced")
+	if err := fs.Parse(args); err != nil {
+		return errUsage
+	}
+	if *in == "" {
+		return errUsage
+	}
+	if !*dryRun && !*yes {
+		return errors.New("restore replaces the selected collections, pass --yes to confirm or --dry-run to check the archive")
+	}
+
+	client := stores.Get()
+	if client == nil {
+		return errors.New("storage client not initialized")
+	}
+
+	f, err := os.Open(*in)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	opts := backup.RestoreOptions{DryRun: *dryRun}
+	if *collections != "" {
+		opts.Collections = strings.Split(*collections, ",")
+	}
+
+	manifest, restored, err := backup.Restore(ctx, client.Database(), f, opts)
+	if manifest != nil {
+		fmt.Printf("archive v%d of %s from %s\n", manifest.Version, manifest.Database, manifest.CreatedAt.Format(time.RFC3339))
+	}
+	for _, c := range restored {
+		fmt.Printf("%-20s %d\n", c.Name, c.Documents)
+	}
+	if err != nil {
+		return err
+	}
+
+	if *dryRun {
+		fmt.Println("dry run, nothing written")
+	}
+
+	return nil
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+type subcommand struct {
+	usage string
+	// offline commands do not run the bot and log to stderr
+	offline bool
+	run     func(ctx context.Context, cfg *Config, args []string) error
+}
+
+var subcommands = map[string]subcommand{
+	"serve": {
+		usage: "serve",
+		run: func(_ context.Context, cfg *Config, _ []string) error {
+			return serve(cfg)
+		},
+	},
+	"migrate": {
+		usage:   "migrate <up|status|down>",
+		offline: true,
+		run: func(ctx context.Context, _ *Config, args []string) error {
+			return runMigrate(ctx, args)
+		},
+	},
+	"backup": {
+		usage:   "backup [--out FILE]",
+		offline: true,
+		run:     runBackup,
+	},
+	"restore": {
+		usage:   "restore --in FILE [--dry-run] [--collections NAME,...] [--yes]",
+		offline: true,
+		run:     runRestore,
+	},
+	"members": {
+		usage:   "members <sync | nicknames | roles | keep-nickname --member ID [--off]>",
+		offline: true,
+		run:     runMembers,
+	},
+	"tokens": {
+		usage:   "tokens <grant --member ID --amount N [--comment TEXT] | balance --member ID>",
+		offline: true,
+		run:     runTokens,
+	},
+	"attendance": {
+		usage:   "attendance export --id ID",
+		offline: true,
+		run:     runAttendance,
+	},
+	"config": {
+		usage:   "config <get NAME | set NAME VALUE>",
+		offline: true,
+		run:     runConfig,
+	},
+	"commands": {
+		usage:   "commands purge",
+		offline: true,
+		run:     runCommands,
+	},
+	"rsi": {
+		usage:   "rsi check-selectors [--handle HANDLE]",
+		offline: true,
+		run:     runRSI,
+	},
+}
+
+// subcommandOrder is the order subcommands are listed in the usage
+var subcommandOrder = []string{"serve", "migrate", "backup", "restore", "members", "tokens", "attendance", "config", "commands", "rsi"}
+
+var errUsage = errors.New("invalid usage")
+
+// run dispatches to the subcommand named by the first argument. With no arguments the bot is served
+func run(ctx context.Context, args []string) error {
+	name := "serve"
+	if len(args) > 0 {
+		name, args = args[0], args[1:]
+	}
+
+	if name == "help" || name == "-h" || name == "--help" {
+		fmt.Print(usage())
+		return nil
+	}
+
+	cmd, ok := subcommands[name]
+	if !ok {
+		return fmt.Errorf("unknown command %q\n\n%s", name, usage())
+	}
+
+	cfg, err := bootstrap(cmd.offline)
+	if err != nil {
+		return err
+	}
+
+	if err := cmd.run(ctx, cfg, args); err != nil {
+		if errors.Is(err, errUsage) {
+			return fmt.Errorf("usage: solbot %s", cmd.usage)
+		}
+		return err
+	}
+
+	return nil
+}
+
+func usage() string {
+	var sb strings.Builder
+	sb.WriteString("usage: solbot <command> [arguments]\n\ncommands:\n")
+	for _, name := range subcommandOrder {
+		sb.WriteString("  " + subcommands[name].usage + "\n")
+	}
+	return sb.String()
+}
+
+// newFlagSet creates a flag set for a subcommand that reports errors instead of exiting
+func newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	return fs
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sol-armada/sol-bot/bot"
+)
+
+// runCommands handles the commands subcommand
+func runCommands(ctx context.Context, cfg *Config, args []string) error {
+	if len(args) != 1 || args[0] != "purge" {
+		return errUsage
+	}
+
+	b, err := bot.New()
+	if err != nil {
+		return fmt.Errorf("creating bot: %w", err)
+	}
+
+	purged, err := b.PurgeCommands()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("purged %d commands\n", purged)
+	return nil
+}
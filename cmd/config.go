@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/sol-armada/sol-bot/config"
+)
+
+// runConfig handles the config subcommand. Values are parsed as JSON when possible, otherwise stored as a string
+func runConfig(ctx context.Context, cfg *Config, args []string) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+
+	switch args[0] {
+	case "get":
+		if len(args) != 2 {
+			return errUsage
+		}
+
+		value, err := config.GetConfig(args[1])
+		if err != nil {
+			if errors.Is(err, config.ErrConfigNotFound) {
+				return fmt.Errorf("config %s not found", args[1])
+			}
+			return err
+		}
+
+		out, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(string(out))
+	case "set":
+		if len(args) != 3 {
+			return errUsage
+		}
+
+		var value any
+		if err := json.Unmarshal([]byte(args[2]), &value); err != nil {
+			value = args[2]
+		}
+
+		if err := config.SetConfig(args[1], value); err != nil {
+			return err
+		}
+
+		fmt.Printf("set %s\n", args[1])
+	default:
+		return errUsage
+	}
+
+	return nil
+}
@@ -13,6 +13,8 @@ import (
 	"github.com/go-co-op/gocron/v2"
 	"github.com/google/uuid"
 	"github.com/sol-armada/sol-bot/activity"
+	"github.com/sol-armada/sol-bot/allies"
+	"github.com/sol-armada/sol-bot/applications"
 	"github.com/sol-armada/sol-bot/attendance"
 	"github.com/sol-armada/sol-bot/bot"
 	"github.com/sol-armada/sol-bot/config"
@@ -20,6 +22,7 @@ import (
 	"github.com/sol-armada/sol-bot/health"
 	"github.com/sol-armada/sol-bot/members"
 	"github.com/sol-armada/sol-bot/raffles"
+	"github.com/sol-armada/sol-bot/rsi"
 	"github.com/sol-armada/sol-bot/settings"
 	"github.com/sol-armada/sol-bot/stores"
 	"github.com/sol-armada/sol-bot/systemd"
@@ -28,6 +31,8 @@ import (
 
 var (
 	environment string = ""
+	version     string = "unknown"
+	hash        string = "unknown"
 	logger      *slog.Logger
 )
 
@@ -57,17 +62,22 @@ type FeatureConfig struct {
 	SystemdIntegration bool
 }
 
-func init() {
-	fmt.Println("Starting sol-bot initialization...")
-
+// bootstrap loads the configuration, sets up logging and initializes the services every
+// subcommand needs. Offline commands log to stderr so their output stays clean
+func bootstrap(offline bool) (*Config, error) {
 	cfg := loadConfig()
-	logger = setupLogger(cfg)
+	if offline {
+		logger = setupOfflineLogger(cfg)
+	} else {
+		logger = setupLogger(cfg)
+	}
 
 	logger.Info("sol-bot starting up",
 		"environment", cfg.Environment,
 		"debug", cfg.Debug,
 		"cli", cfg.CLI,
-		"version", "unknown") // You could add a version variable later
+		"version", version,
+		"hash", hash)
 
 	logger.Info("configuration loaded successfully",
 		"mongo_host", cfg.MongoConfig.Host,
@@ -79,21 +89,20 @@ func init() {
 
 	if err := initializeServices(cfg); err != nil {
 		logger.Error("failed to initialize services", "error", err)
-		os.Exit(1)
+		return nil, err
 	}
 
-	logger.Info("health monitor starting")
-	go health.Monitor()
+	return cfg, nil
 }
 
 // loadConfig initializes and loads application configuration
 func loadConfig() *Config {
-	fmt.Printf("Loading configuration for environment: %s\n", environment)
+	fmt.Fprintf(os.Stderr, "Loading configuration for environment: %s\n", environment)
 
 	configName := "settings"
 	if environment == "staging" {
 		configName = "settings.staging"
-		fmt.Printf("Using staging configuration: %s\n", configName)
+		fmt.Fprintf(os.Stderr, "Using staging configuration: %s\n", configName)
 	}
 	settings.SetConfigName(configName)
 
@@ -101,15 +110,15 @@ func loadConfig() *Config {
 	configPaths := []string{".", "../", "/etc/solbot/"}
 	for _, path := range configPaths {
 		settings.AddConfigPath(path)
-		fmt.Printf("Adding config search path: %s\n", path)
+		fmt.Fprintf(os.Stderr, "Adding config search path: %s\n", path)
 	}
 
-	fmt.Printf("Attempting to read configuration file: %s\n", configName)
+	fmt.Fprintf(os.Stderr, "Attempting to read configuration file: %s\n", configName)
 	if err := settings.ReadInConfig(); err != nil {
-		fmt.Printf("could not parse configuration: %v\n", err)
+		fmt.Fprintf(os.Stderr, "could not parse configuration: %v\n", err)
 		os.Exit(1)
 	}
-	fmt.Printf("Configuration loaded successfully\n")
+	fmt.Fprintf(os.Stderr, "Configuration loaded successfully\n")
 
 	return &Config{
 		Environment:          environment,
@@ -135,7 +144,7 @@ func loadConfig() *Config {
 
 // setupLogger creates and configures the application logger
 func setupLogger(cfg *Config) *slog.Logger {
-	fmt.Printf("Setting up logger - Debug: %v, CLI: %v, LogFile: %s\n", cfg.Debug, cfg.CLI, cfg.LogFile)
+	fmt.Fprintf(os.Stderr, "Setting up logger - Debug: %v, CLI: %v, LogFile: %s\n", cfg.Debug, cfg.CLI, cfg.LogFile)
 
 	opts := &slog.HandlerOptions{
 		AddSource: true,
@@ -143,22 +152,22 @@ func setupLogger(cfg *Config) *slog.Logger {
 
 	if cfg.Debug {
 		opts.Level = slog.LevelDebug
-		fmt.Printf("Debug logging enabled\n")
+		fmt.Fprintf(os.Stderr, "Debug logging enabled\n")
 	}
 
 	// Create CLI logger if configured for CLI output
 	if cfg.CLI {
-		fmt.Printf("Using CLI logger (stdout)\n")
+		fmt.Fprintf(os.Stderr, "Using CLI logger (stdout)\n")
 		log := slog.New(slog.NewTextHandler(os.Stdout, opts))
 		slog.SetDefault(log)
 		return log
 	}
 
 	// Create file logger for non-CLI output
-	fmt.Printf("Using file logger: %s\n", cfg.LogFile)
+	fmt.Fprintf(os.Stderr, "Using file logger: %s\n", cfg.LogFile)
 	f, err := os.OpenFile(cfg.LogFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
 	if err != nil {
-		fmt.Printf("Failed to open log file: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Failed to open log file: %v\n", err)
 		os.Exit(1)
 	}
 
@@ -169,7 +178,22 @@ func setupLogger(cfg *Config) *slog.Logger {
 		log.Debug("debug mode enabled")
 	}
 
-	fmt.Printf("Logger setup completed successfully\n")
+	fmt.Fprintf(os.Stderr, "Logger setup completed successfully\n")
+	return log
+}
+
+// setupOfflineLogger creates a logger for maintenance commands that writes to stderr
+func setupOfflineLogger(cfg *Config) *slog.Logger {
+	opts := &slog.HandlerOptions{
+		Level: slog.LevelWarn,
+	}
+
+	if cfg.Debug {
+		opts.Level = slog.LevelDebug
+	}
+
+	log := slog.New(slog.NewTextHandler(os.Stderr, opts))
+	slog.SetDefault(log)
 	return log
 }
 
@@ -184,7 +208,7 @@ func initializeServices(cfg *Config) error {
 		"database", cfg.MongoConfig.Database,
 		"replica_set", cfg.MongoConfig.ReplicaSetName)
 
-	if _, err := stores.New(
+	client, err := stores.New(
 		ctx,
 		cfg.MongoConfig.Host,
 		cfg.MongoConfig.Port,
@@ -192,21 +216,27 @@ func initializeServices(cfg *Config) error {
 		cfg.MongoConfig.Password,
 		cfg.MongoConfig.Database,
 		cfg.MongoConfig.ReplicaSetName,
-	); err != nil {
+	)
+	if err != nil {
 		logger.Error("failed to create storage client", "error", err)
 		return fmt.Errorf("failed to create storage client: %w", err)
 	}
 	logger.Info("database connection established successfully")
 
+	reg := client.Stores()
+
 	// Initialize all services
 	services := map[string]func() error{
-		"members":    members.Setup,
-		"attendance": attendance.Setup,
-		"activity":   activity.Setup,
-		"tokens":     tokens.Setup,
-		"config":     config.Setup,
-		"raffles":    raffles.Setup,
-		"giveaways":  giveaway.Setup,
+		"members":      func() error { return members.Setup(members.NewMongoStore(reg.Members())) },
+		"attendance":   func() error { return attendance.Setup(attendance.NewMongoStore(reg.Attendance())) },
+		"activity":     activity.Setup,
+		"tokens":       func() error { return tokens.Setup(tokens.NewMongoStore(reg.Tokens(), reg.TokenHolds())) },
+		"config":       func() error { return config.Setup(config.NewMongoStore(reg.Configs())) },
+		"raffles":      func() error { return raffles.Setup(raffles.NewMongoStore(reg.Raffles())) },
+		"giveaways":    func() error { return giveaway.Setup(giveaway.NewMongoStore(reg.Giveaways())) },
+		"applications": func() error { return applications.Setup(applications.NewMongoStore(reg.Applications())) },
+		"allies":       func() error { return allies.Setup(allies.NewMongoStore(reg.Allies())) },
+		"rsi":          func() error { rsi.SetCache(rsi.NewMongoCache(reg.RSICache())); return nil },
 	}
 
 	logger.Info("initializing services", "count", len(services))
@@ -235,18 +265,26 @@ type Application struct {
 }
 
 func main() {
+	if err := run(context.Background(), os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// serve runs the bot until it receives a shutdown signal
+func serve(cfg *Config) error {
 	defer func() {
 		if r := recover(); r != nil {
 			if logger != nil {
 				logger.Error("panic in main", "panic", r)
 			} else {
-				fmt.Printf("panic in main: %v\n", r)
+				fmt.Fprintf(os.Stderr, "panic in main: %v\n", r)
 			}
 		}
 	}()
 
-	logger.Info("main function starting")
-	cfg := loadConfig()
+	logger.Info("health monitor starting")
+	go health.Monitor()
 
 	logger.Info("creating application instance")
 	app := &Application{
@@ -264,7 +302,7 @@ func main() {
 	logger.Info("starting application")
 	if err := app.start(); err != nil {
 		logger.Error("failed to start application", "error", err)
-		os.Exit(1)
+		return err
 	}
 
 	logger.Info("application started successfully, waiting for shutdown signal")
@@ -274,6 +312,8 @@ func main() {
 	signal.Notify(c, syscall.SIGTERM)
 	sig := <-c
 	logger.Info("received shutdown signal", "signal", sig.String())
+
+	return nil
 }
 
 // start initializes and starts all application components
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/sol-armada/sol-bot/bot"
+	"github.com/sol-armada/sol-bot/health"
+	"github.com/sol-armada/sol-bot/members"
+)
+
+// runMembers handles the members subcommand
+func runMembers(ctx context.Context, cfg *Config, args []string) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+
+	switch args[0] {
+	case "sync":
+		if len(args) != 1 {
+			return errUsage
+		}
+
+		if !health.Check(ctx) {
+			return fmt.Errorf("storage is not healthy")
+		}
+
+		// the monitor only needs the REST client, so the gateway is never opened
+		if _, err := bot.New(); err != nil {
+			return fmt.Errorf("creating bot: %w", err)
+		}
+
+		if err := bot.MemberMonitor(ctx, logger); err != nil {
+			return err
+		}
+
+		fmt.Println("members synced")
+	case "nicknames":
+		if len(args) != 1 {
+			return errUsage
+		}
+
+		if !health.Check(ctx) {
+			return fmt.Errorf("storage is not healthy")
+		}
+
+		if _, err := bot.New(); err != nil {
+			return fmt.Errorf("creating bot: %w", err)
+		}
+
+		changes, err := bot.NicknameReport(logger)
+		if err != nil {
+			return err
+		}
+
+		for _, change := range changes {
+			note := ""
+			if change.TooLong {
+				note = " (too long, skipped)"
+			}
+			fmt.Printf("%s\t%q -> %q%s\n", change.MemberId, change.Current, change.Want, note)
+		}
+		fmt.Printf("%d nicknames differ\n", len(changes))
+	case "roles":
+		if len(args) != 1 {
+			return errUsage
+		}
+
+		if !health.Check(ctx) {
+			return fmt.Errorf("storage is not healthy")
+		}
+
+		if _, err := bot.New(); err != nil {
+			return fmt.Errorf("creating bot: %w", err)
+		}
+
+		changes, err := bot.RoleReport(logger)
+		if err != nil {
+			return err
+		}
+
+		for _, change := range changes {
+			fmt.Printf("%s\t%s\t+%s -%s\n", change.MemberId, change.Name, strings.Join(change.Add, ","), strings.Join(change.Remove, ","))
+		}
+		fmt.Printf("%d members have roles that differ\n", len(changes))
+	case "keep-nickname":
+		fs := newFlagSet("members keep-nickname")
+		memberId := fs.String("member", "", "discord id of the member")
+		off := fs.Bool("off", false, "let the bot manage the member's nickname again")
+		if err := fs.Parse(args[1:]); err != nil {
+			return errUsage
+		}
+		if *memberId == "" {
+			return errUsage
+		}
+
+		member, err := members.Get(*memberId)
+		if err != nil {
+			if errors.Is(err, members.MemberNotFound) {
+				return fmt.Errorf("member %s not found", *memberId)
+			}
+			return err
+		}
+
+		member.KeepNickname = !*off
+		if err := member.Save(); err != nil {
+			return fmt.Errorf("saving member: %w", err)
+		}
+
+		if member.KeepNickname {
+			fmt.Printf("the bot will leave %s's (%s) nickname alone\n", member.Name, member.Id)
+		} else {
+			fmt.Printf("the bot will manage %s's (%s) nickname\n", member.Name, member.Id)
+		}
+	default:
+		return errUsage
+	}
+
+	return nil
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sol-armada/sol-bot/rsi"
+	"github.com/sol-armada/sol-bot/settings"
+)
+
+// runRSI handles the rsi subcommand
+func runRSI(ctx context.Context, cfg *Config, args []string) error {
+	if len(args) == 0 || args[0] != "check-selectors" {
+		return errUsage
+	}
+
+	fs := newFlagSet("rsi check-selectors")
+	handle := fs.String("handle", settings.GetString("RSI.HEALTH_HANDLE"), "visible member of the org to check the pages of")
+	if err := fs.Parse(args[1:]); err != nil {
+		return errUsage
+	}
+	if *handle == "" {
+		return errUsage
+	}
+
+	client, err := rsi.NewDefaultClient()
+	if err != nil {
+		return err
+	}
+
+	checks, err := client.CheckSelectors(ctx, *handle)
+	if err != nil {
+		return err
+	}
+
+	broken := 0
+	for _, check := range checks {
+		status := "ok"
+		if !check.Healthy() {
+			status = "BROKEN"
+			broken++
+		}
+		fmt.Printf("%-6s %-13s %-20s %d matches\n", status, check.Page, check.Name, check.Matches)
+	}
+
+	if broken > 0 {
+		return fmt.Errorf("%d rsi selectors matched nothing", broken)
+	}
+
+	return nil
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/sol-armada/sol-bot/members"
+	"github.com/sol-armada/sol-bot/tokens"
+)
+
+// runTokens handles the tokens subcommand
+func runTokens(ctx context.Context, cfg *Config, args []string) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+
+	fs := newFlagSet("tokens " + args[0])
+	memberId := fs.String("member", "", "discord id of the member")
+
+	switch args[0] {
+	case "grant":
+		amount := fs.Int("amount", 0, "amount of tokens to grant, negative to take")
+		comment := fs.String("comment", "", "reason for the grant")
+		if err := fs.Parse(args[1:]); err != nil {
+			return errUsage
+		}
+		if *memberId == "" || *amount == 0 {
+			return errUsage
+		}
+
+		member, err := members.Get(*memberId)
+		if err != nil {
+			if errors.Is(err, members.MemberNotFound) {
+				return fmt.Errorf("member %s not found", *memberId)
+			}
+			return err
+		}
+
+		var c *string
+		if *comment != "" {
+			c = comment
+		}
+
+		if err := tokens.New(member.Id, *amount, tokens.ReasonOther, nil, nil, c).Save(); err != nil {
+			return fmt.Errorf("saving token record: %w", err)
+		}
+
+		fmt.Printf("granted %d tokens to %s (%s)\n", *amount, member.Name, member.Id)
+	case "balance":
+		if err := fs.Parse(args[1:]); err != nil {
+			return errUsage
+		}
+		if *memberId == "" {
+			return errUsage
+		}
+
+		balance, err := tokens.GetBalanceByMemberId(*memberId)
+		if err != nil {
+			return err
+		}
+
+		held, err := tokens.GetHeldByMemberId(*memberId)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("balance: %d\navailable: %d\nheld: %d\n", balance, balance-held, held)
+	default:
+		return errUsage
+	}
+
+	return nil
+}
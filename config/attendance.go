@@ -4,38 +4,21 @@ import (
 	"errors"
 	"strings"
 
-	"github.com/sol-armada/sol-bot/stores"
 	"go.mongodb.org/mongo-driver/bson"
-	"go.mongodb.org/mongo-driver/mongo"
 )
 
 func GetAttendanceTags() ([]string, error) {
-	c, ok := stores.Get().GetConfigsStore()
-	if !ok {
-		return nil, errors.New("could not get configs store")
-	}
-
-	res := c.Get("attendance_tags")
-	if res.Err() != nil {
-		return nil, res.Err()
-	}
-
-	var out []string
-	if err := res.Decode(&out); err != nil {
+	raw, err := GetConfig("attendance_tags")
+	if err != nil {
 		return nil, err
 	}
 
-	return out, nil
+	return toStrings(raw, "attendance tags")
 }
 
 func NewAttendanceTag(tag string) error {
-	c, ok := stores.Get().GetConfigsStore()
-	if !ok {
-		return errors.New("could not get configs store")
-	}
-
 	tags, err := GetAttendanceTags()
-	if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+	if err != nil && !errors.Is(err, ErrConfigNotFound) {
 		return err
 	}
 
@@ -46,7 +29,7 @@ func NewAttendanceTag(tag string) error {
 	}
 
 	tags = append(tags, strings.ToUpper(strings.ReplaceAll(tag, " ", "-")))
-	return c.Upsert("attendance_tags", tags)
+	return SetConfig("attendance_tags", tags)
 }
 
 func GetAttendanceNames() ([]string, error) {
@@ -55,17 +38,7 @@ func GetAttendanceNames() ([]string, error) {
 		return nil, err
 	}
 
-	names, ok := raw.(bson.A)
-	if !ok {
-		return nil, errors.New("could not convert attendance names to []string")
-	}
-
-	var out []string
-	for _, name := range names {
-		out = append(out, name.(string))
-	}
-
-	return out, nil
+	return toStrings(raw, "attendance names")
 }
 
 func ValidAttendanceName(name string) (bool, error) {
@@ -85,13 +58,8 @@ func ValidAttendanceName(name string) (bool, error) {
 }
 
 func NewAttendanceName(name string) error {
-	c, ok := stores.Get().GetConfigsStore()
-	if !ok {
-		return errors.New("could not get configs store")
-	}
-
 	names, err := GetAttendanceNames()
-	if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+	if err != nil && !errors.Is(err, ErrConfigNotFound) {
 		return err
 	}
 
@@ -102,17 +70,12 @@ func NewAttendanceName(name string) error {
 	}
 
 	names = append(names, name)
-	return c.Upsert("attendance_names", names)
+	return SetConfig("attendance_names", names)
 }
 
 func RemoveAttendanceName(name string) error {
-	c, ok := stores.Get().GetConfigsStore()
-	if !ok {
-		return errors.New("could not get configs store")
-	}
-
 	names, err := GetAttendanceNames()
-	if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+	if err != nil && !errors.Is(err, ErrConfigNotFound) {
 		return err
 	}
 
@@ -123,5 +86,23 @@ func RemoveAttendanceName(name string) error {
 		}
 	}
 
-	return c.Upsert("attendance_names", names)
+	return SetConfig("attendance_names", names)
+}
+
+func toStrings(raw any, what string) ([]string, error) {
+	values, ok := raw.(bson.A)
+	if !ok {
+		return nil, errors.New("could not convert " + what + " to []string")
+	}
+
+	var out []string
+	for _, value := range values {
+		s, ok := value.(string)
+		if !ok {
+			return nil, errors.New("could not convert " + what + " to []string")
+		}
+		out = append(out, s)
+	}
+
+	return out, nil
 }
@@ -0,0 +1,51 @@
+package config
+
+import (
+	"strings"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type memoryStore struct {
+	mu      sync.RWMutex
+	configs map[string][]byte
+}
+
+var _ Store = (*memoryStore)(nil)
+
+// NewMemoryStore returns a Store that keeps configs in memory. Used for tests.
+// Values are round tripped through bson so they come back as the same types mongo returns
+func NewMemoryStore() Store {
+	return &memoryStore{configs: map[string][]byte{}}
+}
+
+func (s *memoryStore) Get(name string) (any, error) {
+	s.mu.RLock()
+	raw, ok := s.configs[strings.ToLower(name)]
+	s.mu.RUnlock()
+
+	if !ok {
+		return nil, ErrConfigNotFound
+	}
+
+	var out map[string]any
+	if err := bson.Unmarshal(raw, &out); err != nil {
+		return nil, err
+	}
+
+	return out["value"], nil
+}
+
+func (s *memoryStore) Set(name string, value any) error {
+	raw, err := bson.Marshal(bson.D{{Key: "name", Value: name}, {Key: "value", Value: value}})
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.configs[strings.ToLower(name)] = raw
+	return nil
+}
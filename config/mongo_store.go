@@ -0,0 +1,43 @@
+package config
+
+import (
+	"errors"
+
+	"github.com/sol-armada/sol-bot/stores"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type mongoStore struct {
+	store *stores.ConfigsStore
+}
+
+var _ Store = (*mongoStore)(nil)
+
+// NewMongoStore wraps the mongo configs collection as a Store
+func NewMongoStore(store *stores.ConfigsStore) Store {
+	if store == nil {
+		return nil
+	}
+	return &mongoStore{store: store}
+}
+
+func (s *mongoStore) Get(name string) (any, error) {
+	res := s.store.Get(name)
+	if res.Err() != nil {
+		if errors.Is(res.Err(), mongo.ErrNoDocuments) {
+			return nil, ErrConfigNotFound
+		}
+		return nil, res.Err()
+	}
+
+	var out map[string]any
+	if err := res.Decode(&out); err != nil {
+		return nil, err
+	}
+
+	return out["value"], nil
+}
+
+func (s *mongoStore) Set(name string, value any) error {
+	return s.store.Upsert(name, value)
+}
@@ -0,0 +1,251 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/sol-armada/sol-bot/members"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+const (
+	onboardingFlowConfig  = "onboarding_flow"
+	onboardingDraftConfig = "onboarding_flow_draft"
+)
+
+// Discord's limits on messages, buttons and modals
+const (
+	maxMessageLength      = 2000
+	maxOnboardingChoices  = 25
+	maxChoiceLabelLength  = 80
+	maxModalQuestions     = 5
+	maxQuestionLabel      = 45
+	maxPlaceholderLength  = 100
+	maxAnswerLength       = 4000
+	maxOnboardingIdLength = 40
+)
+
+// OnboardingFlow is what new members see in the onboarding channel and the questions they are asked
+type OnboardingFlow struct {
+	// Welcome is the content of the onboarding message
+	Welcome string `json:"welcome" bson:"welcome"`
+	// Finished is sent to the member once they answered the questions
+	Finished string `json:"finished" bson:"finished"`
+	// Choices are the buttons under the welcome, each opening its own questions
+	Choices []OnboardingChoice `json:"choices" bson:"choices"`
+}
+
+type OnboardingChoice struct {
+	Id        string               `json:"id" bson:"id"`
+	Label     string               `json:"label" bson:"label"`
+	Emoji     string               `json:"emoji" bson:"emoji"`
+	Questions []OnboardingQuestion `json:"questions" bson:"questions"`
+}
+
+type OnboardingQuestion struct {
+	Id          string `json:"id" bson:"id"`
+	Label       string `json:"label" bson:"label"`
+	Placeholder string `json:"placeholder" bson:"placeholder"`
+	Paragraph   bool   `json:"paragraph" bson:"paragraph"`
+	Required    bool   `json:"required" bson:"required"`
+	MinLength   int    `json:"min_length" bson:"min_length"`
+	MaxLength   int    `json:"max_length" bson:"max_length"`
+	// Pattern is a regular expression the whole answer has to match
+	Pattern string `json:"pattern" bson:"pattern"`
+	// Field is where the answer is stored on the member, see members.OnboardingFields. Empty only shows it to officers
+	Field string `json:"field" bson:"field"`
+}
+
+// GetOnboardingFlow returns the published flow, ErrConfigNotFound if one was never published
+func GetOnboardingFlow() (*OnboardingFlow, error) {
+	return getOnboardingFlow(onboardingFlowConfig)
+}
+
+// GetOnboardingDraft returns the flow waiting to be published, ErrConfigNotFound if there isn't one
+func GetOnboardingDraft() (*OnboardingFlow, error) {
+	return getOnboardingFlow(onboardingDraftConfig)
+}
+
+// SetOnboardingDraft validates and stores the flow to be previewed before publishing
+func SetOnboardingDraft(flow *OnboardingFlow) error {
+	if err := flow.Validate(); err != nil {
+		return err
+	}
+	return SetConfig(onboardingDraftConfig, flow)
+}
+
+// PublishOnboardingDraft makes the draft the flow new members see
+func PublishOnboardingDraft() (*OnboardingFlow, error) {
+	flow, err := GetOnboardingDraft()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := SetConfig(onboardingFlowConfig, flow); err != nil {
+		return nil, err
+	}
+
+	return flow, DiscardOnboardingDraft()
+}
+
+// DiscardOnboardingDraft removes the draft. Stores can't delete so an empty value is stored in its place
+func DiscardOnboardingDraft() error {
+	return SetConfig(onboardingDraftConfig, nil)
+}
+
+func getOnboardingFlow(name string) (*OnboardingFlow, error) {
+	raw, err := GetConfig(name)
+	if err != nil {
+		return nil, err
+	}
+	if raw == nil {
+		return nil, ErrConfigNotFound
+	}
+
+	// values come back as loose bson, so round trip them into the flow
+	b, err := bson.Marshal(bson.D{{Key: "value", Value: raw}})
+	if err != nil {
+		return nil, err
+	}
+
+	var out struct {
+		Value OnboardingFlow `bson:"value"`
+	}
+	if err := bson.Unmarshal(b, &out); err != nil {
+		return nil, fmt.Errorf("decoding onboarding flow: %w", err)
+	}
+
+	return &out.Value, nil
+}
+
+// Choice returns the choice with the id
+func (f *OnboardingFlow) Choice(id string) (*OnboardingChoice, bool) {
+	for i := range f.Choices {
+		if f.Choices[i].Id == id {
+			return &f.Choices[i], true
+		}
+	}
+	return nil, false
+}
+
+// Validate makes sure the flow can be shown on Discord and every choice asks for the RSI handle
+func (f *OnboardingFlow) Validate() error {
+	var errs []error
+
+	if strings.TrimSpace(f.Welcome) == "" || utf8.RuneCountInString(f.Welcome) > maxMessageLength {
+		errs = append(errs, fmt.Errorf("welcome must be 1 to %d characters", maxMessageLength))
+	}
+	if utf8.RuneCountInString(f.Finished) > maxMessageLength {
+		errs = append(errs, fmt.Errorf("finished must be at most %d characters", maxMessageLength))
+	}
+
+	if len(f.Choices) == 0 || len(f.Choices) > maxOnboardingChoices {
+		errs = append(errs, fmt.Errorf("there must be 1 to %d choices", maxOnboardingChoices))
+	}
+
+	choiceIds := []string{}
+	for _, c := range f.Choices {
+		if !validOnboardingId(c.Id) {
+			errs = append(errs, fmt.Errorf("choice id %q must be 1 to %d letters, numbers, - or _", c.Id, maxOnboardingIdLength))
+		}
+		if slices.Contains(choiceIds, c.Id) {
+			errs = append(errs, fmt.Errorf("choice id %q is used more than once", c.Id))
+		}
+		choiceIds = append(choiceIds, c.Id)
+
+		if c.Label == "" || utf8.RuneCountInString(c.Label) > maxChoiceLabelLength {
+			errs = append(errs, fmt.Errorf("choice %s: label must be 1 to %d characters", c.Id, maxChoiceLabelLength))
+		}
+
+		if len(c.Questions) == 0 || len(c.Questions) > maxModalQuestions {
+			errs = append(errs, fmt.Errorf("choice %s: there must be 1 to %d questions", c.Id, maxModalQuestions))
+		}
+
+		questionIds := []string{}
+		handles := 0
+		for _, q := range c.Questions {
+			if err := q.validate(); err != nil {
+				errs = append(errs, fmt.Errorf("choice %s: %w", c.Id, err))
+			}
+			if slices.Contains(questionIds, q.Id) {
+				errs = append(errs, fmt.Errorf("choice %s: question id %q is used more than once", c.Id, q.Id))
+			}
+			questionIds = append(questionIds, q.Id)
+
+			if q.Field == members.OnboardingRSIHandle {
+				handles++
+			}
+		}
+
+		if handles != 1 {
+			errs = append(errs, fmt.Errorf("choice %s: exactly one question must have the %s field", c.Id, members.OnboardingRSIHandle))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (q OnboardingQuestion) validate() error {
+	if !validOnboardingId(q.Id) {
+		return fmt.Errorf("question id %q must be 1 to %d letters, numbers, - or _", q.Id, maxOnboardingIdLength)
+	}
+	if q.Label == "" || utf8.RuneCountInString(q.Label) > maxQuestionLabel {
+		return fmt.Errorf("question %s: label must be 1 to %d characters", q.Id, maxQuestionLabel)
+	}
+	if utf8.RuneCountInString(q.Placeholder) > maxPlaceholderLength {
+		return fmt.Errorf("question %s: placeholder must be at most %d characters", q.Id, maxPlaceholderLength)
+	}
+	if q.MinLength < 0 || q.MaxLength < 0 || q.MinLength > maxAnswerLength || q.MaxLength > maxAnswerLength {
+		return fmt.Errorf("question %s: lengths must be 0 to %d", q.Id, maxAnswerLength)
+	}
+	if q.MaxLength > 0 && q.MinLength > q.MaxLength {
+		return fmt.Errorf("question %s: min_length is more than max_length", q.Id)
+	}
+	if q.Pattern != "" {
+		if _, err := regexp.Compile(q.Pattern); err != nil {
+			return fmt.Errorf("question %s: invalid pattern: %w", q.Id, err)
+		}
+	}
+	if q.Field != "" && !slices.Contains(members.OnboardingFields, q.Field) {
+		return fmt.Errorf("question %s: unknown field %q, expected one of %s", q.Id, q.Field, strings.Join(members.OnboardingFields, ", "))
+	}
+	return nil
+}
+
+// Check returns why the answer isn't allowed, nil if it is. The message is shown to the member
+func (q OnboardingQuestion) Check(answer string) error {
+	answer = strings.TrimSpace(answer)
+	length := utf8.RuneCountInString(answer)
+
+	if answer == "" {
+		if q.Required || q.Field == members.OnboardingRSIHandle {
+			return fmt.Errorf("%q needs an answer", q.Label)
+		}
+		return nil
+	}
+
+	if q.MinLength > 0 && length < q.MinLength {
+		return fmt.Errorf("%q needs at least %d characters", q.Label, q.MinLength)
+	}
+	if q.MaxLength > 0 && length > q.MaxLength {
+		return fmt.Errorf("%q can be at most %d characters", q.Label, q.MaxLength)
+	}
+	if q.Pattern != "" {
+		// validated when the flow was saved
+		if !regexp.MustCompile(`^(?:` + q.Pattern + `)$`).MatchString(answer) {
+			return fmt.Errorf("%q isn't in the expected format", q.Label)
+		}
+	}
+
+	return nil
+}
+
+var onboardingIdRegex = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+func validOnboardingId(id string) bool {
+	return len(id) <= maxOnboardingIdLength && onboardingIdRegex.MatchString(id)
+}
@@ -0,0 +1,135 @@
+package config
+
+import (
+	"errors"
+	"testing"
+)
+
+func testFlow() *OnboardingFlow {
+	return &OnboardingFlow{
+		Welcome: "Welcome!",
+		Choices: []OnboardingChoice{
+			{
+				Id:    "rsi",
+				Label: "Found us on RSI",
+				Questions: []OnboardingQuestion{
+					{Id: "handle", Label: "Your RSI Handle", Field: "rsi_handle"},
+					{Id: "age", Label: "How old are you?", Required: true, Pattern: `\d+`, Field: "age"},
+				},
+			},
+		},
+	}
+}
+
+func TestOnboardingFlowValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(f *OnboardingFlow)
+		valid  bool
+	}{
+		{"valid", func(f *OnboardingFlow) {}, true},
+		{"no welcome", func(f *OnboardingFlow) { f.Welcome = " " }, false},
+		{"no choices", func(f *OnboardingFlow) { f.Choices = nil }, false},
+		{"duplicate choice", func(f *OnboardingFlow) { f.Choices = append(f.Choices, f.Choices[0]) }, false},
+		{"colon in id", func(f *OnboardingFlow) { f.Choices[0].Id = "a:b" }, false},
+		{"no rsi handle", func(f *OnboardingFlow) { f.Choices[0].Questions[0].Field = "" }, false},
+		{"unknown field", func(f *OnboardingFlow) { f.Choices[0].Questions[1].Field = "favorite_ship" }, false},
+		{"bad pattern", func(f *OnboardingFlow) { f.Choices[0].Questions[1].Pattern = "(" }, false},
+		{"min over max", func(f *OnboardingFlow) {
+			f.Choices[0].Questions[1].MinLength, f.Choices[0].Questions[1].MaxLength = 5, 2
+		}, false},
+		{"long label", func(f *OnboardingFlow) {
+			f.Choices[0].Questions[1].Label = "What gameplay loops are you most interested in trying?"
+		}, false},
+		{"too many questions", func(f *OnboardingFlow) {
+			for range 4 {
+				f.Choices[0].Questions = append(f.Choices[0].Questions, OnboardingQuestion{Id: "q", Label: "Q"})
+			}
+		}, false},
+	}
+
+	for _, tt := range tests {
+		f := testFlow()
+		tt.change(f)
+		if err := f.Validate(); (err == nil) != tt.valid {
+			t.Errorf("%s: valid = %t, got error %v", tt.name, tt.valid, err)
+		}
+	}
+}
+
+func TestOnboardingQuestionCheck(t *testing.T) {
+	q := OnboardingQuestion{Label: "Age", Required: true, MinLength: 1, MaxLength: 3, Pattern: `\d+`}
+
+	tests := []struct {
+		answer string
+		valid  bool
+	}{
+		{"21", true},
+		{" 21 ", true},
+		{"", false},
+		{"twenty", false},
+		{"1000", false},
+		{"21a", false},
+	}
+
+	for _, tt := range tests {
+		if err := q.Check(tt.answer); (err == nil) != tt.valid {
+			t.Errorf("Check(%q): valid = %t, got error %v", tt.answer, tt.valid, err)
+		}
+	}
+
+	optional := OnboardingQuestion{Label: "Pronouns"}
+	if err := optional.Check(""); err != nil {
+		t.Errorf("expected an optional question to allow no answer, got %v", err)
+	}
+
+	handle := OnboardingQuestion{Label: "Handle", Field: "rsi_handle"}
+	if err := handle.Check(""); err == nil {
+		t.Error("expected the RSI handle to always need an answer")
+	}
+}
+
+func TestOnboardingDraft(t *testing.T) {
+	if err := Setup(NewMemoryStore()); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := GetOnboardingFlow(); !errors.Is(err, ErrConfigNotFound) {
+		t.Fatalf("expected ErrConfigNotFound before publishing, got %v", err)
+	}
+
+	invalid := testFlow()
+	invalid.Choices = nil
+	if err := SetOnboardingDraft(invalid); err == nil {
+		t.Fatal("expected an invalid draft to be refused")
+	}
+
+	if err := SetOnboardingDraft(testFlow()); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := GetOnboardingFlow(); !errors.Is(err, ErrConfigNotFound) {
+		t.Fatalf("expected the draft to not be published yet, got %v", err)
+	}
+
+	published, err := PublishOnboardingDraft()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	flow, err := GetOnboardingFlow()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if flow.Welcome != published.Welcome || len(flow.Choices) != 1 || flow.Choices[0].Questions[1].Pattern != `\d+` {
+		t.Errorf("published flow didn't round trip, got %+v", flow)
+	}
+
+	if _, err := GetOnboardingDraft(); !errors.Is(err, ErrConfigNotFound) {
+		t.Errorf("expected the draft to be cleared after publishing, got %v", err)
+	}
+
+	if _, err := PublishOnboardingDraft(); !errors.Is(err, ErrConfigNotFound) {
+		t.Errorf("expected publishing without a draft to fail, got %v", err)
+	}
+}
@@ -0,0 +1,76 @@
+package config
+
+import (
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/sol-armada/sol-bot/settings"
+)
+
+const enemyOrgsConfig = "enemy_orgs"
+
+// GetEnemyOrgs returns the SIDs of the hostile orgs. Until one is added through the bot, the enimies setting is used
+func GetEnemyOrgs() ([]string, error) {
+	raw, err := GetConfig(enemyOrgsConfig)
+	if err != nil {
+		if !errors.Is(err, ErrConfigNotFound) {
+			return nil, err
+		}
+
+		raw := settings.GetStringSlice("ENIMIES")
+		enemies := make([]string, 0, len(raw))
+		for _, sid := range raw {
+			enemies = append(enemies, normalizeSID(sid))
+		}
+		return enemies, nil
+	}
+
+	enemies, err := toStrings(raw, "enemy orgs")
+	if err != nil {
+		return nil, err
+	}
+	if enemies == nil {
+		enemies = []string{}
+	}
+
+	return enemies, nil
+}
+
+// AddEnemyOrg adds the org to the hostile orgs, reporting false if it was already listed
+func AddEnemyOrg(sid string) (bool, error) {
+	enemies, err := GetEnemyOrgs()
+	if err != nil {
+		return false, err
+	}
+
+	sid = normalizeSID(sid)
+	if slices.Contains(enemies, sid) {
+		return false, nil
+	}
+
+	enemies = append(enemies, sid)
+	slices.Sort(enemies)
+	return true, SetConfig(enemyOrgsConfig, enemies)
+}
+
+// RemoveEnemyOrg removes the org from the hostile orgs, reporting false if it was not listed
+func RemoveEnemyOrg(sid string) (bool, error) {
+	enemies, err := GetEnemyOrgs()
+	if err != nil {
+		return false, err
+	}
+
+	sid = normalizeSID(sid)
+	i := slices.Index(enemies, sid)
+	if i == -1 {
+		return false, nil
+	}
+
+	enemies = slices.Delete(enemies, i, i+1)
+	return true, SetConfig(enemyOrgsConfig, enemies)
+}
+
+func normalizeSID(sid string) string {
+	return strings.ToUpper(strings.TrimSpace(sid))
+}
@@ -2,55 +2,46 @@ package config
 
 import (
 	"errors"
-
-	"github.com/sol-armada/sol-bot/stores"
 )
 
-var configStore *stores.ConfigsStore
+var configStore Store
 
-func Setup() error {
-	storesClient := stores.Get()
-	cs, ok := storesClient.GetConfigsStore()
-	if !ok {
+var (
+	ErrConfigNotFound = errors.New("config not found")
+)
+
+func Setup(store Store) error {
+	if store == nil {
 		return errors.New("config store not found")
 	}
-	configStore = cs
+	configStore = store
 
 	return nil
 }
 
 func GetConfig(config string) (any, error) {
-	res := configStore.Get(config)
-	if res.Err() != nil {
-		return "", res.Err()
-	}
-
-	var out map[string]any
-
-	if err := res.Decode(&out); err != nil {
+	value, err := configStore.Get(config)
+	if err != nil {
 		return "", err
 	}
 
-	return out["value"], nil
+	return value, nil
 }
 
 func SetConfig(config string, value any) error {
-	return configStore.Upsert(config, value)
+	return configStore.Set(config, value)
 }
 
 func GetConfigWithDefault[T any](config string, defaultValue T) (T, error) {
-	res := configStore.Get(config)
-	if res.Err() != nil {
-		return defaultValue, nil
-	}
-
-	var decoded map[string]any
-
-	if err := res.Decode(&decoded); err != nil {
+	value, err := configStore.Get(config)
+	if err != nil {
+		if errors.Is(err, ErrConfigNotFound) {
+			return defaultValue, nil
+		}
 		return defaultValue, err
 	}
 
-	val, ok := decoded["value"].(T)
+	val, ok := value.(T)
 	if !ok {
 		return defaultValue, nil
 	}
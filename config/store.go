@@ -0,0 +1,8 @@
+package config
+
+// Store is the persistence layer the config package works against
+type Store interface {
+	// Get returns the stored value for the config, or ErrConfigNotFound
+	Get(name string) (any, error)
+	Set(name string, value any) error
+}
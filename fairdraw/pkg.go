@@ -0,0 +1,87 @@
+// Package fairdraw picks winners with a commit-reveal scheme.
+//
+// A random seed is created when a raffle or giveaway starts and only its hash is published.
+// When it ends, winners are derived from the seed and the sorted entries, then the seed is revealed
+// so anyone can check it matches the published hash and recompute the same winners.
+package fairdraw
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Entry is a participant and their weight in a draw
+type Entry struct {
+	Id     string  `json:"id" bson:"id"`
+	Weight float64 `json:"weight" bson:"weight"`
+}
+
+// NewSeed returns a new random seed
+func NewSeed() string {
+	b := make([]byte, 32)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// Commit returns the hash that is published before the draw
+func Commit(seed string) string {
+	sum := sha256.Sum256([]byte(seed))
+	return hex.EncodeToString(sum[:])
+}
+
+// Verify reports if the seed matches the published commitment
+func Verify(seed, commitment string) bool {
+	return seed != "" && hmac.Equal([]byte(Commit(seed)), []byte(strings.ToLower(commitment)))
+}
+
+// Draw picks up to count winners without replacement. The chance of each pick is proportional to the
+// entry's weight. Entries are sorted by id first so the result only depends on the seed, the label
+// and the entries themselves. The label separates draws that share a seed, like giveaway items
+func Draw(seed, label string, entries []Entry, count int) []string {
+	pool := make([]Entry, 0, len(entries))
+	for _, e := range entries {
+		if e.Weight > 0 {
+			pool = append(pool, e)
+		}
+	}
+	slices.SortFunc(pool, func(a, b Entry) int { return strings.Compare(a.Id, b.Id) })
+
+	winners := []string{}
+	for round := 0; round < count && len(pool) > 0; round++ {
+		total := 0.0
+		for _, e := range pool {
+			total += e.Weight
+		}
+
+		target := uniform(seed, label, round) * total
+
+		picked := len(pool) - 1
+		for j, e := range pool {
+			if target < e.Weight {
+				picked = j
+				break
+			}
+			target -= e.Weight
+		}
+
+		winners = append(winners, pool[picked].Id)
+		pool = slices.Delete(pool, picked, picked+1)
+	}
+
+	return winners
+}
+
+// uniform returns a number in [0, 1) derived from the seed, label and round
+func uniform(seed, label string, round int) float64 {
+	mac := hmac.New(sha256.New, []byte(seed))
+	_, _ = fmt.Fprintf(mac, "%s:%d", label, round)
+	sum := mac.Sum(nil)
+
+	return float64(binary.BigEndian.Uint64(sum[:8])>>11) / (1 << 53)
+}
@@ -0,0 +1,45 @@
+package fairdraw
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestCommitVerify(t *testing.T) {
+	seed := NewSeed()
+	if !Verify(seed, Commit(seed)) {
+		t.Error("seed should verify against its own commitment")
+	}
+	if Verify(NewSeed(), Commit(seed)) {
+		t.Error("a different seed should not verify")
+	}
+	if Verify("", Commit("")) {
+		t.Error("an empty seed should not verify")
+	}
+}
+
+func TestDrawDeterministic(t *testing.T) {
+	entries := []Entry{{Id: "c", Weight: 1}, {Id: "a", Weight: 5}, {Id: "b", Weight: 2}, {Id: "d", Weight: 0}}
+	shuffled := []Entry{entries[2], entries[3], entries[0], entries[1]}
+
+	seed := NewSeed()
+	first := Draw(seed, "", entries, 2)
+	second := Draw(seed, "", shuffled, 2)
+
+	if !slices.Equal(first, second) {
+		t.Errorf("entry order changed the result: %v vs %v", first, second)
+	}
+	if len(first) != 2 || first[0] == first[1] {
+		t.Errorf("expected 2 distinct winners, got %v", first)
+	}
+	if slices.Contains(first, "d") {
+		t.Error("zero weight entry should never win")
+	}
+}
+
+func TestDrawMoreWinnersThanEntries(t *testing.T) {
+	winners := Draw(NewSeed(), "item", []Entry{{Id: "a", Weight: 1}, {Id: "b", Weight: 1}}, 5)
+	if len(winners) != 2 {
+		t.Errorf("expected every entry to win, got %v", winners)
+	}
+}
@@ -4,21 +4,35 @@ import (
 	"slices"
 )
 
+// AddMemberToItems sets the member's entries to exactly the given items, holding the token cost of any that have one
 func (g *Giveaway) AddMemberToItems(items []string, memberId string) (*Giveaway, error) {
-	for _, itemId := range items {
-		item, ok := g.Items[itemId]
-		if !ok {
-			return g, nil
-		}
-
-		item.AddMember(memberId)
+	if err := g.holdEntries(memberId, items); err != nil {
+		g.restoreHolds(memberId)
+		return nil, err
 	}
 
-	for _, item := range g.Items {
-		if !slices.Contains(items, item.Id) {
-			item.RemoveMember(memberId)
+	updated, err := Update(g.Id, func(g *Giveaway) error {
+		for _, itemId := range items {
+			item, ok := g.Items[itemId]
+			if !ok {
+				continue
+			}
+
+			item.AddMember(memberId)
 		}
+
+		for _, item := range g.Items {
+			if !slices.Contains(items, item.Id) {
+				item.RemoveMember(memberId)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		g.restoreHolds(memberId)
+		return nil, err
 	}
 
-	return g.Save()
+	return updated, nil
 }
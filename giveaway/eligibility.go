@@ -0,0 +1,215 @@
+package giveaway
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sol-armada/sol-bot/attendance"
+	"github.com/sol-armada/sol-bot/members"
+	"github.com/sol-armada/sol-bot/ranks"
+	"github.com/sol-armada/sol-bot/tokens"
+)
+
+// Eligibility is who can enter an item. The zero value lets everyone in
+type Eligibility struct {
+	// MinRank is the lowest rank that can enter. ranks.None means any rank
+	MinRank ranks.Rank `json:"min_rank"`
+	// MinAttendance is how many recorded events the member needs, within the last AttendanceDays if set
+	MinAttendance  int `json:"min_attendance"`
+	AttendanceDays int `json:"attendance_days"`
+	// RequireValidated requires a validated RSI account
+	RequireValidated bool `json:"require_validated"`
+	// MembersOnly keeps guests and allies out
+	MembersOnly bool `json:"members_only"`
+	// TokenCost is held when the member enters and spent when the giveaway ends
+	TokenCost int `json:"token_cost"`
+}
+
+var ErrInvalidEligibility = errors.New("invalid item rules")
+
+// ParseEligibility reads item rules written as a comma separated list, e.g. "rank=technician,events=3/30,rsi,members,cost=10".
+// events takes a count and optionally the number of days to count over
+func ParseEligibility(in string) (Eligibility, error) {
+	e := Eligibility{}
+
+	for rule := range strings.SplitSeq(in, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(rule), "=")
+		switch strings.ToLower(key) {
+		case "":
+			continue
+		case "rank":
+			e.MinRank = ranks.GetRankByName(value)
+			if e.MinRank == ranks.None {
+				return e, fmt.Errorf("%w: unknown rank %q", ErrInvalidEligibility, value)
+			}
+		case "events":
+			count, days, _ := strings.Cut(value, "/")
+			n, err := strconv.Atoi(count)
+			if err != nil || n < 1 {
+				return e, fmt.Errorf("%w: events must be a whole number", ErrInvalidEligibility)
+			}
+			e.MinAttendance = n
+
+			if days != "" {
+				d, err := strconv.Atoi(strings.TrimSuffix(days, "d"))
+				if err != nil || d < 1 {
+					return e, fmt.Errorf("%w: events days must be a whole number", ErrInvalidEligibility)
+				}
+				e.AttendanceDays = d
+			}
+		case "rsi":
+			e.RequireValidated = true
+		case "members":
+			e.MembersOnly = true
+		case "cost":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 0 {
+				return e, fmt.Errorf("%w: cost must be a whole number", ErrInvalidEligibility)
+			}
+			e.TokenCost = n
+		default:
+			return e, fmt.Errorf("%w: unknown rule %q", ErrInvalidEligibility, key)
+		}
+	}
+
+	return e, nil
+}
+
+// Describe lists the rules for the giveaway embed. Empty when anyone can enter
+func (e Eligibility) Describe() []string {
+	rules := []string{}
+
+	if e.MinRank != ranks.None {
+		rules = append(rules, e.MinRank.String()+" or higher")
+	}
+	if e.MinAttendance > 0 {
+		rule := fmt.Sprintf("%d+ events", e.MinAttendance)
+		if e.AttendanceDays > 0 {
+			rule += fmt.Sprintf(" in %d days", e.AttendanceDays)
+		}
+		rules = append(rules, rule)
+	}
+	if e.RequireValidated {
+		rules = append(rules, "Validated RSI account")
+	}
+	if e.MembersOnly {
+		rules = append(rules, "No guests or allies")
+	}
+	if e.TokenCost > 0 {
+		rules = append(rules, fmt.Sprintf("Costs %d tokens", e.TokenCost))
+	}
+
+	return rules
+}
+
+// Check returns why the member can't enter, or nothing if they can
+func (e Eligibility) Check(member *members.Member) ([]string, error) {
+	reasons := []string{}
+
+	// a lower rank value is a higher rank
+	if e.MinRank != ranks.None && (member.Rank == ranks.None || member.Rank > e.MinRank) {
+		reasons = append(reasons, fmt.Sprintf("You need to be %s or higher", e.MinRank.String()))
+	}
+
+	if e.RequireValidated && !member.Validated {
+		reasons = append(reasons, "You need to validate your RSI account")
+	}
+
+	if e.MembersOnly && (member.IsGuest || member.IsAlly) {
+		reasons = append(reasons, "Guests and allies can't enter")
+	}
+
+	if e.MinAttendance > 0 {
+		count, window, err := e.attendanceCount(member.Id)
+		if err != nil {
+			return nil, err
+		}
+
+		if count < e.MinAttendance {
+			reasons = append(reasons, fmt.Sprintf("You need to attend %d events%s, you have %d", e.MinAttendance, window, count))
+		}
+	}
+
+	return reasons, nil
+}
+
+func (e Eligibility) attendanceCount(memberId string) (int, string, error) {
+	if e.AttendanceDays <= 0 {
+		count, err := attendance.GetMemberAttendanceCount(memberId)
+		return count, "", err
+	}
+
+	since := time.Now().AddDate(0, 0, -e.AttendanceDays)
+	count, err := attendance.GetMemberAttendanceCountSince(memberId, since)
+	return count, fmt.Sprintf(" in the last %d days", e.AttendanceDays), err
+}
+
+// CheckEligibility returns the reasons the member can't enter each of the items, keyed by item name.
+// Items they can enter are left out. Token costs are added up across all the items
+func (g *Giveaway) CheckEligibility(member *members.Member, itemIds []string) (map[string][]string, error) {
+	ineligible := map[string][]string{}
+
+	available := -1
+	for _, itemId := range itemIds {
+		item, ok := g.Items[itemId]
+		if !ok {
+			continue
+		}
+
+		reasons, err := item.Eligibility.Check(member)
+		if err != nil {
+			return nil, err
+		}
+
+		if cost := item.Eligibility.TokenCost; cost > 0 && len(reasons) == 0 {
+			if available < 0 {
+				available, err = g.availableTokens(member.Id)
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			if cost > available {
+				reasons = append(reasons, fmt.Sprintf("You need %d available tokens to enter, you have %d left", cost, available))
+			} else {
+				available -= cost
+			}
+		}
+
+		if len(reasons) > 0 {
+			ineligible[item.Name] = reasons
+		}
+	}
+
+	return ineligible, nil
+}
+
+// availableTokens is the member's available balance plus what they already hold for this giveaway,
+// since those holds are replaced when they change their entries
+func (g *Giveaway) availableTokens(memberId string) (int, error) {
+	available, err := tokens.GetAvailableBalanceByMemberId(memberId)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, item := range g.Items {
+		if item.Eligibility.TokenCost == 0 {
+			continue
+		}
+
+		hold, err := tokens.GetHold(memberId, item.HoldReference(g.Id))
+		if err != nil {
+			if errors.Is(err, tokens.ErrHoldNotFound) {
+				continue
+			}
+			return 0, err
+		}
+
+		available += hold.Amount
+	}
+
+	return available, nil
+}
@@ -0,0 +1,70 @@
+package giveaway
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sol-armada/sol-bot/members"
+	"github.com/sol-armada/sol-bot/ranks"
+)
+
+func TestParseEligibility(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Eligibility
+		wantErr bool
+	}{
+		{in: "", want: Eligibility{}},
+		{in: "rank=technician", want: Eligibility{MinRank: ranks.Technician}},
+		{in: "events=3/30d, rsi", want: Eligibility{MinAttendance: 3, AttendanceDays: 30, RequireValidated: true}},
+		{in: "members,cost=10,events=2", want: Eligibility{MembersOnly: true, TokenCost: 10, MinAttendance: 2}},
+		{in: "rank=captain", wantErr: true},
+		{in: "events=some", wantErr: true},
+		{in: "cost=-1", wantErr: true},
+		{in: "vip", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseEligibility(tt.in)
+		if tt.wantErr {
+			if !errors.Is(err, ErrInvalidEligibility) {
+				t.Errorf("ParseEligibility(%q) expected ErrInvalidEligibility, got %v", tt.in, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseEligibility(%q) unexpected error %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseEligibility(%q) = %+v, want %+v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestEligibilityCheck(t *testing.T) {
+	e := Eligibility{MinRank: ranks.Technician, RequireValidated: true, MembersOnly: true}
+
+	tests := []struct {
+		name    string
+		member  *members.Member
+		reasons int
+	}{
+		{name: "eligible", member: &members.Member{Rank: ranks.Specialist, Validated: true}, reasons: 0},
+		{name: "same rank", member: &members.Member{Rank: ranks.Technician, Validated: true}, reasons: 0},
+		{name: "low rank", member: &members.Member{Rank: ranks.Recruit, Validated: true}, reasons: 1},
+		{name: "no rank", member: &members.Member{Validated: true}, reasons: 1},
+		{name: "guest", member: &members.Member{Rank: ranks.Guest, IsGuest: true}, reasons: 3},
+		{name: "ally", member: &members.Member{Rank: ranks.Admiral, Validated: true, IsAlly: true}, reasons: 1},
+	}
+
+	for _, tt := range tests {
+		reasons, err := e.Check(tt.member)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(reasons) != tt.reasons {
+			t.Errorf("%s: expected %d reasons, got %v", tt.name, tt.reasons, reasons)
+		}
+	}
+}
@@ -36,6 +36,13 @@ func (g *Giveaway) GetEmbed() *discordgo.MessageEmbed {
 
 		feilds = sortFieldsByName(feilds)
 
+		if g.Seed != "" {
+			feilds = append(feilds, &discordgo.MessageEmbedField{
+				Name:  "Draw Seed",
+				Value: fmt.Sprintf("`%s`\nCommitment: `%s`", g.Seed, g.SeedHash),
+			})
+		}
+
 		return &discordgo.MessageEmbed{
 			Title:       g.Name,
 			Description: "### 🎊 Giveaway Winners 🎊\nCongrats on your winnings! Please meet at the OIC's hanger to collect your prizes, if your name is shown below.",
@@ -55,6 +62,9 @@ func (g *Giveaway) GetEmbed() *discordgo.MessageEmbed {
 		}
 
 		valueStr += fmt.Sprintf("\nEntries: %d", len(item.Members))
+		for _, rule := range item.Eligibility.Describe() {
+			valueStr += "\n- " + rule
+		}
 
 		field := &discordgo.MessageEmbedField{
 			Name:   fmt.Sprintf("%s (%dx Winners)", item.Name, item.Amount),
@@ -66,56 +76,32 @@ func (g *Giveaway) GetEmbed() *discordgo.MessageEmbed {
 
 	feilds = sortFieldsByName(feilds)
 
-	// get the time remaining
-	r := max(time.Until(g.EndTime), 0)
-	days := int(r.Hours() / 24)
-	hours := int(r.Hours()) % 24
-	minutes := int(r.Minutes()) % 60
-	seconds := int(r.Seconds()) % 60
-
-	timeRemainingParts := []string{}
-
-	if days > 0 {
-		dayStr := fmt.Sprintf("%d Day", days)
-		if days > 1 {
-			dayStr += "s"
-		}
-		timeRemainingParts = append(timeRemainingParts, dayStr)
-	}
-	if hours > 0 {
-		hourStr := fmt.Sprintf("%d Hour", hours)
-		if hours > 1 {
-			hourStr += "s"
-		}
-		timeRemainingParts = append(timeRemainingParts, hourStr)
-	}
-	if minutes > 0 && hours == 0 && days == 0 {
-		minuteStr := fmt.Sprintf("%d Minute", minutes)
-		if minutes > 1 {
-			minuteStr += "s"
-		}
-		timeRemainingParts = append(timeRemainingParts, minuteStr)
-	}
-	if seconds > 0 && minutes < 2 && hours == 0 && days == 0 {
-		secondStr := fmt.Sprintf("%d Second", seconds)
-		if seconds > 1 {
-			secondStr += "s"
-		}
-		timeRemainingParts = append(timeRemainingParts, secondStr)
+	if g.Weighting.Weighted() {
+		feilds = append(feilds, &discordgo.MessageEmbedField{
+			Name:  "Odds",
+			Value: g.Weighting.Describe(),
+		})
 	}
 
-	timeRemaining := strings.Join(timeRemainingParts, " ")
-	if timeRemaining == "" {
-		timeRemaining = "0 Seconds"
+	if g.SeedHash != "" {
+		feilds = append(feilds, &discordgo.MessageEmbedField{
+			Name:  "Draw Commitment",
+			Value: "`" + g.SeedHash + "`",
+		})
 	}
 
+	// discord renders the countdown itself so the message doesn't need editing every second
+	feilds = append(feilds, &discordgo.MessageEmbedField{
+		Name:  "Ends",
+		Value: fmt.Sprintf("<t:%d:R>", g.EndTime.Unix()),
+	})
+
 	footer := &discordgo.MessageEmbedFooter{
-		// Text: fmt.Sprintf("Time Remaining: %s", timeRemaining),
-		Text: fmt.Sprintf("Giveaway ends in %s", timeRemaining),
+		Text: "Giveaway ID: " + g.Id,
 	}
 	return &discordgo.MessageEmbed{
 		Title:       g.Name,
-		Description: "### 🎁  Ongoing Giveaways  🎁\nLooking to earn yourself something nice? Select the items you want from the dropdown below for a chance to win!\n\n_Tokens not required. Must be part of the associated event._",
+		Description: "### 🎁  Ongoing Giveaways  🎁\nLooking to earn yourself something nice? Select the items you want from the dropdown below for a chance to win!\n\n_Tokens not required unless the item says otherwise. Must be part of the associated event._",
 		Fields:      feilds,
 		Color:       0x000000 + rand.Intn(0xffffff),
 		Footer:      footer,
@@ -2,6 +2,7 @@ package giveaway
 
 import (
 	"fmt"
+	"log/slog"
 	"strings"
 
 	"github.com/bwmarrin/discordgo"
@@ -10,22 +11,43 @@ import (
 func (g *Giveaway) GetViewEntriesEmbed(memberId string) *discordgo.MessageEmbed {
 	entries := g.GetMembersEntries(memberId)
 
+	weights, err := g.GetWeights()
+	if err != nil {
+		slog.Error("failed to get giveaway weights", "giveaway_id", g.Id, "error", err)
+	}
+
 	entryNames := []string{}
 	for _, item := range entries {
-		entryNames = append(entryNames, fmt.Sprintf("- [%s](https://google.com/)", item.Name))
+		entryNames = append(entryNames, fmt.Sprintf("- [%s](https://google.com/) - %s chance", item.Name, formatOdds(item.Odds(memberId, weights))))
+	}
+
+	fields := []*discordgo.MessageEmbedField{
+		{
+			Name:  "Your Entries",
+			Value: strings.Join(entryNames, "\n"),
+		},
+	}
+
+	if weights != nil && g.Weighting.Weighted() {
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name:  "Your Weight",
+			Value: fmt.Sprintf("%g\n_%s_", entryWeight(weights, memberId), g.Weighting.Describe()),
+		})
 	}
 
 	return &discordgo.MessageEmbed{
 		Title:       fmt.Sprintf("Giveaway for %s", g.Name),
-		Description: "## 🎟️ You have entered the Giveaway!\nBelow are the entries you submitted.\n\n _If you would like to resubmit for different items, please adjust the items you want in the original message. See pinned messages in this channel if you can't find it._",
-		Fields: []*discordgo.MessageEmbedField{
-			{
-				Name:  "Your Entries",
-				Value: strings.Join(entryNames, "\n"),
-			},
-		},
+		Description: "## 🎟️ You have entered the Giveaway!\nBelow are the entries you submitted and your current odds of winning each. Odds change as others enter.\n\n _If you would like to resubmit for different items, please adjust the items you want in the original message. See pinned messages in this channel if you can't find it._",
+		Fields:      fields,
 		Footer: &discordgo.MessageEmbedFooter{
 			Text: "Need to see this message again? Press \"View Entries\" on the giveaway message.",
 		},
 	}
 }
+
+func formatOdds(odds float64) string {
+	if odds > 0 && odds < 0.001 {
+		return "<0.1%"
+	}
+	return fmt.Sprintf("%.1f%%", odds*100)
+}
@@ -0,0 +1,73 @@
+package giveaway
+
+import (
+	"slices"
+	"strings"
+	"time"
+)
+
+// HistoryFilter narrows the giveaways returned by History. Zero values match everything
+type HistoryFilter struct {
+	// Since and Until match giveaways that ended in the range
+	Since time.Time
+	Until time.Time
+	// Prize matches giveaways with an item containing the text, ignoring case
+	Prize        string
+	AttendanceId string
+	// Winner matches giveaways the member won an item in
+	Winner string
+}
+
+func (f HistoryFilter) matches(g *Giveaway) bool {
+	if !f.Since.IsZero() && !g.EndTime.After(f.Since) {
+		return false
+	}
+
+	if !f.Until.IsZero() && !g.EndTime.Before(f.Until) {
+		return false
+	}
+
+	if f.AttendanceId != "" && g.AttendanceId != f.AttendanceId {
+		return false
+	}
+
+	if f.Prize != "" && !slices.ContainsFunc(g.itemList(), func(item *Item) bool {
+		return strings.Contains(strings.ToLower(item.Name), strings.ToLower(f.Prize))
+	}) {
+		return false
+	}
+
+	if f.Winner != "" && len(g.ItemsWonBy(f.Winner)) == 0 {
+		return false
+	}
+
+	return true
+}
+
+func (g *Giveaway) itemList() []*Item {
+	items := make([]*Item, 0, len(g.Items))
+	for _, item := range g.Items {
+		items = append(items, item)
+	}
+	return items
+}
+
+// ItemsWonBy returns the items the member won. Only meaningful once the giveaway has ended
+func (g *Giveaway) ItemsWonBy(memberId string) []*Item {
+	if !g.Ended {
+		return nil
+	}
+
+	won := []*Item{}
+	for _, item := range g.Items {
+		if item.HasMember(memberId) {
+			won = append(won, item)
+		}
+	}
+	return won
+}
+
+// History returns ended giveaways matching the filter, most recently ended first
+func History(filter HistoryFilter, limit int) ([]*Giveaway, error) {
+	return giveawayStore.History(filter, limit)
+}
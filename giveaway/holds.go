@@ -0,0 +1,105 @@
+package giveaway
+
+import (
+	"errors"
+	"slices"
+
+	"github.com/sol-armada/sol-bot/tokens"
+)
+
+// HoldReference is the reference entries to an item hold its token cost against
+func (i *Item) HoldReference(giveawayId string) string {
+	return "giveaway:" + giveawayId + ":" + i.Id
+}
+
+// holdEntries holds the token cost of every item the member is entering and releases the rest.
+// Holds are replaced, so calling it again with the same items changes nothing
+func (g *Giveaway) holdEntries(memberId string, itemIds []string) error {
+	for _, item := range g.Items {
+		if item.Eligibility.TokenCost == 0 {
+			continue
+		}
+
+		if !slices.Contains(itemIds, item.Id) {
+			if err := tokens.ReleaseHold(memberId, item.HoldReference(g.Id)); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if _, err := tokens.PlaceHold(memberId, item.HoldReference(g.Id), item.Eligibility.TokenCost); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// settleHolds spends the token cost of every entry and releases anything left over
+func (g *Giveaway) settleHolds() error {
+	var errs []error
+	for _, item := range g.Items {
+		if item.Eligibility.TokenCost == 0 {
+			continue
+		}
+
+		reference := item.HoldReference(g.Id)
+		for _, memberId := range item.Entrants {
+			err := tokens.ConsumeHold(memberId, reference, tokens.ReasonGiveawayEntry, &g.AttendanceId)
+			if err != nil && !errors.Is(err, tokens.ErrHoldNotFound) {
+				errs = append(errs, err)
+			}
+		}
+
+		if err := tokens.ReleaseHolds(reference); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// settle settles the holds and marks the giveaway settled so it isn't settled again
+func (g *Giveaway) settle() error {
+	if err := g.settleHolds(); err != nil {
+		return errors.Join(err, errors.New("failed to settle giveaway token holds"))
+	}
+
+	for range updateRetries {
+		latest, err := giveawayStore.Get(g.Id)
+		if err != nil {
+			return err
+		}
+
+		latest.Settled = true
+		err = giveawayStore.Update(latest)
+		if errors.Is(err, ErrVersionConflict) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		*g = *latest
+		return nil
+	}
+
+	return ErrVersionConflict
+}
+
+// restoreHolds puts the member's holds back in line with their stored entries after a failed change
+func (g *Giveaway) restoreHolds(memberId string) {
+	latest, err := Get(g.Id)
+	if err != nil {
+		return
+	}
+
+	itemIds := []string{}
+	if !latest.Ended {
+		for _, item := range latest.GetMembersEntries(memberId) {
+			itemIds = append(itemIds, item.Id)
+		}
+	}
+
+	_ = latest.holdEntries(memberId, itemIds)
+}
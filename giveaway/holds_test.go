@@ -0,0 +1,109 @@
+package giveaway
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sol-armada/sol-bot/tokens"
+)
+
+func setupHolds(t *testing.T, balance int) *Giveaway {
+	t.Helper()
+
+	if err := Setup(NewMemoryStore()); err != nil {
+		t.Fatal(err)
+	}
+	if err := tokens.Setup(tokens.NewMemoryStore()); err != nil {
+		t.Fatal(err)
+	}
+	if err := tokens.New("a", balance, tokens.ReasonOther, nil, nil, nil).Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	g := &Giveaway{Id: "g", Items: map[string]*Item{
+		"i1": {Id: "i1", Amount: 1, Eligibility: Eligibility{TokenCost: 3}},
+		"i2": {Id: "i2", Amount: 1, Eligibility: Eligibility{TokenCost: 3}},
+		"i3": {Id: "i3", Amount: 1, Eligibility: Eligibility{TokenCost: 7}},
+	}}
+	if err := giveawayStore.Insert(g); err != nil {
+		t.Fatal(err)
+	}
+
+	return g
+}
+
+func TestHoldEntriesRollback(t *testing.T) {
+	g := setupHolds(t, 6)
+
+	g, err := g.AddMemberToItems([]string{"i1", "i2"}, "a")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// i3 can't be held, whatever was released on the way has to be held again
+	if _, err := g.AddMemberToItems([]string{"i3"}, "a"); !errors.Is(err, tokens.ErrInsufficientTokens) {
+		t.Fatalf("expected ErrInsufficientTokens, got %v", err)
+	}
+
+	for _, itemId := range []string{"i1", "i2"} {
+		if held, _ := tokens.HasHold("a", g.Items[itemId].HoldReference(g.Id)); !held {
+			t.Errorf("expected the hold for %s to be kept", itemId)
+		}
+	}
+	if held, _ := tokens.GetHeldByMemberId("a"); held != 6 {
+		t.Errorf("expected 6 held, got %d", held)
+	}
+
+	if _, err := g.AddMemberToItems([]string{"i2"}, "a"); err != nil {
+		t.Fatal(err)
+	}
+	if held, _ := tokens.GetHeldByMemberId("a"); held != 3 {
+		t.Errorf("expected 3 held after leaving i1, got %d", held)
+	}
+}
+
+func TestSettle(t *testing.T) {
+	g := setupHolds(t, 6)
+
+	if _, err := g.AddMemberToItems([]string{"i1"}, "a"); err != nil {
+		t.Fatal(err)
+	}
+
+	g, err := Update(g.Id, func(g *Giveaway) error {
+		for _, item := range g.Items {
+			item.SelectWinners("seed", nil)
+		}
+		g.Ended = true
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	unsettled, _ := giveawayStore.GetUnsettled()
+	if len(unsettled) != 1 {
+		t.Fatalf("expected the ended giveaway to be unsettled, got %d", len(unsettled))
+	}
+
+	// settling again, like Load does after a failed settle, only charges once
+	for range 2 {
+		if err := g.settle(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if balance, _ := tokens.GetBalanceByMemberId("a"); balance != 3 {
+		t.Errorf("expected a balance of 3 after settling, got %d", balance)
+	}
+	if held, _ := tokens.GetHeldByMemberId("a"); held != 0 {
+		t.Errorf("expected nothing held after settling, got %d", held)
+	}
+
+	stored, _ := Get(g.Id)
+	if !stored.Settled {
+		t.Error("expected the giveaway to be marked settled")
+	}
+	if unsettled, _ := giveawayStore.GetUnsettled(); len(unsettled) != 0 {
+		t.Errorf("expected no unsettled giveaways, got %d", len(unsettled))
+	}
+}
@@ -1,8 +1,9 @@
 package giveaway
 
 import (
-	"math/rand"
 	"slices"
+
+	"github.com/sol-armada/sol-bot/fairdraw"
 )
 
 type Item struct {
@@ -10,6 +11,10 @@ type Item struct {
 	Name    string   `json:"name"`
 	Amount  int      `json:"amount"`
 	Members []string `json:"members"`
+	// Entrants are the members entered when the winners were drawn, kept so the draw can be recomputed
+	Entrants []string `json:"entrants,omitempty"`
+	// Eligibility is who can enter the item
+	Eligibility Eligibility `json:"eligibility"`
 }
 
 func (i *Item) AddMember(memberId string) {
@@ -42,29 +47,19 @@ func (i *Item) HasMember(memberId string) bool {
 	return false
 }
 
-func (i *Item) SelectWinners() {
+// SelectWinners draws the item's winners from the giveaway seed and entry weights, replacing Members with the winners
+func (i *Item) SelectWinners(seed string, weights map[string]float64) {
 	if len(i.Members) == 0 {
 		return
 	}
 
-	// shuffle the members
-	for j := len(i.Members) - 1; j > 0; j-- {
-		k := rand.Intn(j + 1)
-		i.Members[j], i.Members[k] = i.Members[k], i.Members[j]
-	}
+	i.Entrants = slices.Clone(i.Members)
+	slices.Sort(i.Entrants)
 
-	if len(i.Members) <= i.Amount {
-		return
+	entries := make([]fairdraw.Entry, len(i.Entrants))
+	for j, memberId := range i.Entrants {
+		entries[j] = fairdraw.Entry{Id: memberId, Weight: entryWeight(weights, memberId)}
 	}
 
-	// only keep the top N members
-	selected := []string{}
-	for j := range i.Amount {
-		if len(selected) > i.Amount {
-			break
-		}
-
-		selected = append(selected, i.Members[j])
-	}
-	i.Members = selected
+	i.Members = fairdraw.Draw(seed, i.Id, entries, i.Amount)
 }
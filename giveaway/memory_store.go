@@ -0,0 +1,96 @@
+package giveaway
+
+import (
+	"slices"
+	"sync"
+)
+
+type memoryStore struct {
+	mu        sync.RWMutex
+	giveaways map[string]*Giveaway
+}
+
+var _ Store = (*memoryStore)(nil)
+
+// NewMemoryStore returns a Store that keeps giveaways in memory. Used for tests
+func NewMemoryStore() Store {
+	return &memoryStore{giveaways: map[string]*Giveaway{}}
+}
+
+func (s *memoryStore) Get(id string) (*Giveaway, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	g, ok := s.giveaways[id]
+	if !ok {
+		return nil, ErrGiveawayNotFound
+	}
+
+	return cloneGiveaway(g), nil
+}
+
+func (s *memoryStore) GetAll() ([]*Giveaway, error) {
+	return s.find(func(g *Giveaway) bool { return !g.Ended }), nil
+}
+
+func (s *memoryStore) GetUnsettled() ([]*Giveaway, error) {
+	return s.find(func(g *Giveaway) bool { return g.Ended && !g.Settled }), nil
+}
+
+func (s *memoryStore) History(filter HistoryFilter, limit int) ([]*Giveaway, error) {
+	gList := s.find(func(g *Giveaway) bool { return g.Ended && filter.matches(g) })
+	slices.SortFunc(gList, func(a, b *Giveaway) int { return b.EndTime.Compare(a.EndTime) })
+
+	if limit > 0 && len(gList) > limit {
+		gList = gList[:limit]
+	}
+
+	return gList, nil
+}
+
+func (s *memoryStore) Insert(g *Giveaway) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.giveaways[g.Id] = cloneGiveaway(g)
+	return nil
+}
+
+func (s *memoryStore) Update(g *Giveaway) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, ok := s.giveaways[g.Id]
+	if !ok || stored.Version != g.Version {
+		return ErrVersionConflict
+	}
+
+	g.Version++
+	s.giveaways[g.Id] = cloneGiveaway(g)
+	return nil
+}
+
+func (s *memoryStore) find(match func(*Giveaway) bool) []*Giveaway {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var gList []*Giveaway
+	for _, g := range s.giveaways {
+		if match(g) {
+			gList = append(gList, cloneGiveaway(g))
+		}
+	}
+	return gList
+}
+
+func cloneGiveaway(g *Giveaway) *Giveaway {
+	c := *g
+	c.Items = make(map[string]*Item, len(g.Items))
+	for id, item := range g.Items {
+		i := *item
+		i.Members = slices.Clone(item.Members)
+		i.Entrants = slices.Clone(item.Entrants)
+		c.Items[id] = &i
+	}
+	return &c
+}
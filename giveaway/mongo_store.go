@@ -0,0 +1,151 @@
+package giveaway
+
+import (
+	"context"
+	"errors"
+	"regexp"
+
+	"github.com/sol-armada/sol-bot/stores"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type mongoStore struct {
+	store *stores.GiveawaysStore
+}
+
+var _ Store = (*mongoStore)(nil)
+
+// NewMongoStore wraps the mongo giveaways collection as a Store
+func NewMongoStore(store *stores.GiveawaysStore) Store {
+	if store == nil {
+		return nil
+	}
+	return &mongoStore{store: store}
+}
+
+func (s *mongoStore) Get(id string) (*Giveaway, error) {
+	g := &Giveaway{}
+	if err := s.store.Get(id).Decode(g); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrGiveawayNotFound
+		}
+		return nil, errors.Join(err, errors.New("failed to get giveaway from store"))
+	}
+
+	return g, nil
+}
+
+func (s *mongoStore) GetAll() ([]*Giveaway, error) {
+	cur, err := s.store.GetAll()
+	if err != nil {
+		return nil, errors.Join(err, errors.New("failed to get giveaways from store"))
+	}
+	defer cur.Close(context.Background())
+
+	var gList []*Giveaway
+	if err := cur.All(context.Background(), &gList); err != nil {
+		return nil, errors.Join(err, errors.New("failed to decode giveaways from store"))
+	}
+
+	return gList, nil
+}
+
+func (s *mongoStore) GetUnsettled() ([]*Giveaway, error) {
+	cur, err := s.store.GetUnsettled()
+	if err != nil {
+		return nil, errors.Join(err, errors.New("failed to get unsettled giveaways from store"))
+	}
+	defer cur.Close(context.Background())
+
+	var gList []*Giveaway
+	if err := cur.All(context.Background(), &gList); err != nil {
+		return nil, errors.Join(err, errors.New("failed to decode giveaways from store"))
+	}
+
+	return gList, nil
+}
+
+func (s *mongoStore) History(filter HistoryFilter, limit int) ([]*Giveaway, error) {
+	cur, err := s.store.History(historyFilterToBSON(filter), int64(limit))
+	if err != nil {
+		return nil, errors.Join(err, errors.New("failed to get ended giveaways from store"))
+	}
+	defer cur.Close(context.Background())
+
+	var gList []*Giveaway
+	if err := cur.All(context.Background(), &gList); err != nil {
+		return nil, errors.Join(err, errors.New("failed to decode giveaways from store"))
+	}
+
+	return gList, nil
+}
+
+func (s *mongoStore) Insert(g *Giveaway) error {
+	return s.store.Insert(g.Id, g)
+}
+
+func (s *mongoStore) Update(g *Giveaway) error {
+	expected := g.Version
+	g.Version++
+
+	ok, err := s.store.ReplaceVersion(g.Id, expected, g)
+	if err != nil || !ok {
+		g.Version = expected
+		if err != nil {
+			return err
+		}
+		return ErrVersionConflict
+	}
+
+	return nil
+}
+
+func historyFilterToBSON(f HistoryFilter) bson.D {
+	filter := bson.D{{Key: "ended", Value: true}}
+
+	endTime := bson.M{}
+	if !f.Since.IsZero() {
+		endTime["$gt"] = f.Since
+	}
+	if !f.Until.IsZero() {
+		endTime["$lt"] = f.Until
+	}
+	if len(endTime) > 0 {
+		filter = append(filter, bson.E{Key: "endtime", Value: endTime})
+	}
+
+	if f.AttendanceId != "" {
+		filter = append(filter, bson.E{Key: "attendanceid", Value: f.AttendanceId})
+	}
+
+	// items are keyed by id, so they are matched as an array of their values
+	items := bson.A{}
+	if f.Prize != "" {
+		items = append(items, anyItem(bson.M{"$regexMatch": bson.M{
+			"input":   "$$item.v.name",
+			"regex":   regexp.QuoteMeta(f.Prize),
+			"options": "i",
+		}}))
+	}
+	if f.Winner != "" {
+		items = append(items, anyItem(bson.M{"$in": bson.A{f.Winner, bson.M{"$ifNull": bson.A{"$$item.v.members", bson.A{}}}}}))
+	}
+	if len(items) > 0 {
+		filter = append(filter, bson.E{Key: "$expr", Value: bson.M{"$and": items}})
+	}
+
+	return filter
+}
+
+// anyItem is an expression matching giveaways with at least one item meeting the condition
+func anyItem(cond bson.M) bson.M {
+	return bson.M{"$gt": bson.A{
+		bson.M{"$size": bson.M{"$filter": bson.M{
+			"input": bson.M{"$objectToArray": bson.M{"$ifNull": bson.A{"$items", bson.M{}}}},
+			"as":    "item",
+			"cond":  cond,
+		}}},
+		0,
+	}}
+}
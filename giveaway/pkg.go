@@ -1,7 +1,6 @@
 package giveaway
 
 import (
-	"context"
 	"errors"
 	"log/slog"
 	"strings"
@@ -10,7 +9,7 @@ import (
 	"github.com/bwmarrin/discordgo"
 	"github.com/rs/xid"
 	"github.com/sol-armada/sol-bot/attendance"
-	"github.com/sol-armada/sol-bot/stores"
+	"github.com/sol-armada/sol-bot/fairdraw"
 )
 
 type Giveaway struct {
@@ -19,73 +18,98 @@ type Giveaway struct {
 	Items        map[string]*Item `json:"items"`
 	AttendanceId string           `json:"attendance_id"`
 	EndTime      time.Time        `json:"end_time"`
+	// SeedHash is shown while the giveaway runs, Seed is only revealed once it ends
+	Seed     string `json:"seed"`
+	SeedHash string `json:"seed_hash"`
+	// Weighting is how entries are weighted, Weights are each member's weight at the draw
+	Weighting Weighting          `json:"weighting"`
+	Weights   map[string]float64 `json:"weights,omitempty"`
 
 	Ended          bool   `json:"ended"`
+	Settled        bool   `json:"settled"` // entries' token holds are spent and released
 	ChannelId      string `json:"channel_id"`
 	EmbedMessageId string `json:"embed_message_id"`
 	InputMessageId string `json:"input_message_id"`
 
-	sess *discordgo.Session `json:""`
+	// Version is bumped on every save so concurrent updates don't overwrite each other
+	Version int `json:"version"`
 }
 
-var giveaways = map[string]*Giveaway{}
+var (
+	giveawayStore Store
+	session       *discordgo.Session
+)
 
-var giveawayStore *stores.GiveawaysStore
+// updateRetries is how many times an update is retried after losing a version race
+const updateRetries = 5
 
 var (
 	ErrGiveawayNotFound      = errors.New("giveaway not found")
+	ErrGiveawayEnded         = errors.New("giveaway has ended")
+	ErrVersionConflict       = errors.New("giveaway was changed by someone else")
 	ErrUnableToUnpinGiveaway = errors.New("unable to unpin giveaway message")
 )
 
-func Setup() error {
-	storesClient := stores.Get()
-	gs, ok := storesClient.GetGiveawaysStore()
-	if !ok {
+func Setup(store Store) error {
+	if store == nil {
 		return errors.New("failed to get giveaways store")
 	}
-	giveawayStore = gs
+	giveawayStore = store
 	return nil
 }
 
+// Load refreshes the messages of running giveaways and schedules them to end
 func Load(s *discordgo.Session) error {
-	cur, err := giveawayStore.GetAll()
-	if err != nil {
-		return errors.Join(err, errors.New("failed to get giveaways from store"))
-	}
-	defer cur.Close(context.Background())
+	session = s
 
-	var gList []*Giveaway
-	if err := cur.All(context.Background(), &gList); err != nil {
-		return errors.Join(err, errors.New("failed to decode giveaways from store"))
+	if err := startScheduler(); err != nil {
+		return err
 	}
 
-	giveaways = make(map[string]*Giveaway)
-	for _, g := range gList {
-		giveaways[g.Id] = g
+	gList, err := giveawayStore.GetAll()
+	if err != nil {
+		return err
 	}
 
-	for _, g := range giveaways {
-		g.sess = s
-
+	for _, g := range gList {
 		if err := g.UpdateMessage(); err != nil {
 			slog.Error("failed to update giveaway message", "error", err)
 		}
 		if err := g.UpdateInputs(); err != nil {
 			slog.Error("failed to update giveaway inputs", "error", err)
 		}
+
+		if err := schedule(g); err != nil {
+			slog.Error("failed to schedule giveaway", "giveaway_id", g.Id, "error", err)
+		}
+	}
+
+	// giveaways that ended while their holds couldn't be settled get another try
+	unsettled, err := giveawayStore.GetUnsettled()
+	if err != nil {
+		return err
 	}
 
-	go watch()
+	for _, g := range unsettled {
+		if err := g.settle(); err != nil {
+			slog.Error("failed to settle giveaway token holds", "giveaway_id", g.Id, "error", err)
+		}
+	}
 
 	return nil
 }
 
 func NewGiveaway(s *discordgo.Session, name, attendanceId string, items []*Item) (*Giveaway, error) {
+	session = s
+
+	seed := fairdraw.NewSeed()
+
 	g := &Giveaway{
-		Id:    xid.New().String(),
-		Name:  name,
-		Items: make(map[string]*Item),
-		sess:  s,
+		Id:       xid.New().String(),
+		Name:     name,
+		Items:    make(map[string]*Item),
+		Seed:     seed,
+		SeedHash: fairdraw.Commit(seed),
 	}
 
 	var a *attendance.Attendance
@@ -116,20 +140,39 @@ func NewGiveaway(s *discordgo.Session, name, attendanceId string, items []*Item)
 	return g, nil
 }
 
-func GetGiveaway(id string) *Giveaway {
-	if g, ok := giveaways[id]; ok {
-		return g
-	}
-	return nil
+func Get(id string) (*Giveaway, error) {
+	return giveawayStore.Get(id)
 }
 
-func SaveGiveaways() error {
-	giveawaysAny := make(map[string]any)
-	for id, giveaway := range giveaways {
-		giveawaysAny[id] = giveaway
+// Update applies change to the latest stored giveaway and saves it. If someone else saved in between,
+// it reloads and applies the change again. Returns ErrGiveawayEnded once the giveaway has ended
+func Update(id string, change func(g *Giveaway) error) (*Giveaway, error) {
+	for range updateRetries {
+		g, err := giveawayStore.Get(id)
+		if err != nil {
+			return nil, err
+		}
+
+		if g.Ended {
+			return g, ErrGiveawayEnded
+		}
+
+		if err := change(g); err != nil {
+			return nil, err
+		}
+
+		err = giveawayStore.Update(g)
+		if errors.Is(err, ErrVersionConflict) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		return g, nil
 	}
 
-	return giveawayStore.UpsertAll(giveawaysAny)
+	return nil, ErrVersionConflict
 }
 
 func (g *Giveaway) CanParticipate(memberId string) bool {
@@ -151,25 +194,58 @@ func (g *Giveaway) SetEndTime(time_m int) *Giveaway {
 	return g
 }
 
+// Save stores a new giveaway and schedules it to end
 func (g *Giveaway) Save() (*Giveaway, error) {
-	giveaways[g.Id] = g
-	return g, SaveGiveaways()
+	if err := giveawayStore.Insert(g); err != nil {
+		return nil, err
+	}
+
+	return g, schedule(g)
 }
 
+// End draws the winners and posts them. Only the first call to end a giveaway draws and posts,
+// so the End button and the scheduler can't both end it. Later calls settle the token holds if that failed before
 func (g *Giveaway) End() error {
-	if g.Ended {
-		slog.Debug("giveaway already ended")
+	ended, err := Update(g.Id, func(g *Giveaway) error {
+		// giveaways started before draws were committed get their seed now
+		if g.Seed == "" {
+			g.Seed = fairdraw.NewSeed()
+			g.SeedHash = fairdraw.Commit(g.Seed)
+		}
+
+		// weights are frozen at the draw so it can be recomputed later
+		weights, err := g.GetWeights()
+		if err != nil {
+			return err
+		}
+		g.Weights = weights
+
+		for _, item := range g.Items {
+			item.SelectWinners(g.Seed, g.Weights)
+		}
+
+		g.Ended = true
 		return nil
+	})
+	if err != nil {
+		if errors.Is(err, ErrGiveawayEnded) {
+			slog.Debug("giveaway already ended")
+			if ended.Settled {
+				return nil
+			}
+			return ended.settle()
+		}
+		return err
 	}
+	*g = *ended
 
-	for _, item := range g.Items {
-		item.SelectWinners()
-	}
+	unschedule(g.Id)
 
-	g.Ended = true
+	// the winners are still posted when settling fails, it's retried on the next End or Load
+	settleErr := g.settle()
 
-	if err := g.sess.ChannelMessageUnpin(g.ChannelId, g.EmbedMessageId); err != nil {
-		return errors.Join(err, errors.New("failed to unpin giveaway embed message"))
+	if err := session.ChannelMessageUnpin(g.ChannelId, g.EmbedMessageId); err != nil {
+		return errors.Join(settleErr, err, errors.New("failed to unpin giveaway embed message"))
 	}
 
 	// get the winners
@@ -201,18 +277,18 @@ func (g *Giveaway) End() error {
 		mentions.WriteString("<@" + winner + "> ")
 	}
 
-	msg, err := g.sess.ChannelMessageSendComplex(g.ChannelId, &discordgo.MessageSend{
+	msg, err := session.ChannelMessageSendComplex(g.ChannelId, &discordgo.MessageSend{
 		Content:    mentions.String(),
 		Components: g.GetComponents(),
 		Embeds:     []*discordgo.MessageEmbed{g.GetEmbed()},
 	})
 	if err != nil {
-		return errors.Join(err, errors.New("failed to send giveaway end message"))
+		return errors.Join(settleErr, err, errors.New("failed to send giveaway end message"))
 	}
 
-	_ = g.sess.ChannelMessagePin(msg.ChannelID, msg.ID)
-	_ = g.sess.ChannelMessageDelete(g.ChannelId, g.EmbedMessageId)
-	_ = g.sess.ChannelMessageDelete(g.ChannelId, g.InputMessageId)
+	_ = session.ChannelMessagePin(msg.ChannelID, msg.ID)
+	_ = session.ChannelMessageDelete(g.ChannelId, g.EmbedMessageId)
+	_ = session.ChannelMessageDelete(g.ChannelId, g.InputMessageId)
 
-	return SaveGiveaways()
+	return settleErr
 }
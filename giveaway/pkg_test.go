@@ -0,0 +1,45 @@
+package giveaway
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestUpdate(t *testing.T) {
+	if err := Setup(NewMemoryStore()); err != nil {
+		t.Fatal(err)
+	}
+
+	g := &Giveaway{Id: "g", Items: map[string]*Item{"i": {Id: "i", Amount: 1}}}
+	if err := giveawayStore.Insert(g); err != nil {
+		t.Fatal(err)
+	}
+
+	// a stale copy loses the race and has to be reapplied on the latest version
+	stale, _ := Get("g")
+	if _, err := g.AddMemberToItems([]string{"i"}, "a"); err != nil {
+		t.Fatal(err)
+	}
+	stale.Items["i"].AddMember("b")
+	if err := giveawayStore.Update(stale); !errors.Is(err, ErrVersionConflict) {
+		t.Errorf("expected ErrVersionConflict for a stale save, got %v", err)
+	}
+
+	updated, err := stale.AddMemberToItems([]string{"i"}, "b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if members := updated.Items["i"].Members; len(members) != 2 {
+		t.Errorf("expected both entries to be kept, got %v", members)
+	}
+	if updated.Version != 2 {
+		t.Errorf("expected version 2, got %d", updated.Version)
+	}
+
+	if _, err := Update("g", func(g *Giveaway) error { g.Ended = true; return nil }); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := g.RemoveMemberFromItems("a"); !errors.Is(err, ErrGiveawayEnded) {
+		t.Errorf("expected ErrGiveawayEnded after the giveaway ended, got %v", err)
+	}
+}
@@ -1,8 +1,21 @@
 package giveaway
 
-func (g *Giveaway) RemoveMemberFromItems(memberId string) {
-	for _, item := range g.Items {
-		item.RemoveMember(memberId)
+func (g *Giveaway) RemoveMemberFromItems(memberId string) (*Giveaway, error) {
+	updated, err := Update(g.Id, func(g *Giveaway) error {
+		for _, item := range g.Items {
+			item.RemoveMember(memberId)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
-	_ = g.UpdateMessage()
+
+	if err := updated.holdEntries(memberId, nil); err != nil {
+		return nil, err
+	}
+
+	_ = updated.UpdateMessage()
+
+	return updated, nil
 }
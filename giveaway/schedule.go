@@ -0,0 +1,67 @@
+package giveaway
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/go-co-op/gocron/v2"
+)
+
+// scheduler ends giveaways at their end time. The end time is stored on the giveaway, so Load
+// schedules every running giveaway again after a restart and overdue ones end right away
+var scheduler gocron.Scheduler
+
+func startScheduler() error {
+	if scheduler != nil {
+		return nil
+	}
+
+	s, err := gocron.NewScheduler()
+	if err != nil {
+		return err
+	}
+	s.Start()
+
+	scheduler = s
+	return nil
+}
+
+func schedule(g *Giveaway) error {
+	if scheduler == nil {
+		return nil
+	}
+
+	unschedule(g.Id)
+
+	startAt := gocron.OneTimeJobStartImmediately()
+	if g.EndTime.After(time.Now().Add(time.Second)) {
+		startAt = gocron.OneTimeJobStartDateTime(g.EndTime)
+	}
+
+	id := g.Id
+	_, err := scheduler.NewJob(
+		gocron.OneTimeJob(startAt),
+		gocron.NewTask(func() {
+			g, err := Get(id)
+			if err != nil {
+				slog.Error("failed to get scheduled giveaway", "giveaway_id", id, "error", err)
+				return
+			}
+
+			if err := g.End(); err != nil {
+				slog.Error("failed to end giveaway", "giveaway_id", id, "error", err)
+			}
+		}),
+		gocron.WithTags(id),
+		gocron.WithName("end giveaway "+id),
+	)
+	return err
+}
+
+func unschedule(id string) {
+	if scheduler == nil {
+		return
+	}
+
+	scheduler.RemoveByTags(id)
+}
@@ -0,0 +1,19 @@
+package giveaway
+
+// Store is the persistence layer the giveaway package works against.
+// Giveaways are saved one at a time and updates are guarded by the version field
+type Store interface {
+	// Get returns ErrGiveawayNotFound if there is no giveaway with the id
+	Get(id string) (*Giveaway, error)
+	// GetAll returns all giveaways that have not ended
+	GetAll() ([]*Giveaway, error)
+	// GetUnsettled returns ended giveaways whose token holds have not been settled
+	GetUnsettled() ([]*Giveaway, error)
+	// History returns ended giveaways matching the filter, most recently ended first. A limit of 0 returns all of them
+	History(filter HistoryFilter, limit int) ([]*Giveaway, error)
+	// Insert saves a new giveaway
+	Insert(giveaway *Giveaway) error
+	// Update saves the giveaway if the stored version is still giveaway.Version, bumping the version.
+	// Returns ErrVersionConflict if it was changed since it was read
+	Update(giveaway *Giveaway) error
+}
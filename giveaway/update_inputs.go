@@ -5,7 +5,7 @@ import (
 )
 
 func (g *Giveaway) UpdateInputs() error {
-	_, err := g.sess.ChannelMessageEditComplex(&discordgo.MessageEdit{
+	_, err := session.ChannelMessageEditComplex(&discordgo.MessageEdit{
 		Channel:    g.ChannelId,
 		ID:         g.InputMessageId,
 		Components:new(g.GetComponents()),
@@ -5,7 +5,7 @@ import (
 )
 
 func (g *Giveaway) UpdateMessage() error {
-	_, err := g.sess.ChannelMessageEditComplex(&discordgo.MessageEdit{
+	_, err := session.ChannelMessageEditComplex(&discordgo.MessageEdit{
 		Channel: g.ChannelId,
 		ID:      g.EmbedMessageId,
 		Embeds:  &[]*discordgo.MessageEmbed{g.GetEmbed()},
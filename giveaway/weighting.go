@@ -0,0 +1,152 @@
+package giveaway
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/sol-armada/sol-bot/attendance"
+	"github.com/sol-armada/sol-bot/tokens"
+)
+
+type WeightMode string
+
+const (
+	WeightEqual      WeightMode = "equal"
+	WeightAttendance WeightMode = "attendance"
+	WeightTokens     WeightMode = "tokens"
+)
+
+var ErrInvalidWeightMode = errors.New("invalid weighting mode")
+
+// Weighting is how much each entry counts in the draw. The zero value gives everyone the same chance
+type Weighting struct {
+	Mode WeightMode `json:"mode"`
+	// Days limits attendance or spending to the last N days. 0 counts everything
+	Days int `json:"days"`
+}
+
+func (w Weighting) Validate() error {
+	switch w.Mode {
+	case "", WeightEqual, WeightAttendance, WeightTokens:
+	default:
+		return ErrInvalidWeightMode
+	}
+
+	if w.Days < 0 {
+		return errors.New("weighting days can't be negative")
+	}
+
+	return nil
+}
+
+// Weighted reports if some members get better odds than others
+func (w Weighting) Weighted() bool {
+	return w.Mode == WeightAttendance || w.Mode == WeightTokens
+}
+
+func (w Weighting) Describe() string {
+	window := ""
+	if w.Days > 0 {
+		window = fmt.Sprintf(" in the last %d days", w.Days)
+	}
+
+	switch w.Mode {
+	case WeightAttendance:
+		return "Better odds for every event attended" + window
+	case WeightTokens:
+		return "Better odds for every token spent" + window
+	default:
+		return "Everyone has the same odds"
+	}
+}
+
+func (w Weighting) since() time.Time {
+	if w.Days <= 0 {
+		return time.Time{}
+	}
+	return time.Now().AddDate(0, 0, -w.Days)
+}
+
+// weight is 1 plus the member's attendance or spending so members with none still have a chance
+func (w Weighting) weight(memberId string) (float64, error) {
+	switch w.Mode {
+	case WeightAttendance:
+		var count int
+		var err error
+		if w.Days > 0 {
+			count, err = attendance.GetMemberAttendanceCountSince(memberId, w.since())
+		} else {
+			count, err = attendance.GetMemberAttendanceCount(memberId)
+		}
+		if err != nil {
+			return 0, err
+		}
+		return 1 + float64(count), nil
+	case WeightTokens:
+		spent, err := tokens.GetSpentByMemberId(memberId, w.since())
+		if err != nil {
+			return 0, err
+		}
+		return 1 + float64(spent), nil
+	default:
+		return 1, nil
+	}
+}
+
+// GetWeights returns the weight of every member entered in the giveaway. Once it has ended,
+// these are the weights the winners were drawn with
+func (g *Giveaway) GetWeights() (map[string]float64, error) {
+	if g.Ended && g.Weights != nil {
+		return g.Weights, nil
+	}
+
+	weights := map[string]float64{}
+	for _, item := range g.Items {
+		for _, memberId := range item.Members {
+			if _, ok := weights[memberId]; ok {
+				continue
+			}
+
+			weight, err := g.Weighting.weight(memberId)
+			if err != nil {
+				return nil, err
+			}
+			weights[memberId] = weight
+		}
+	}
+
+	return weights, nil
+}
+
+// Odds is the member's chance of winning at least one of the item's prizes. With more than one prize
+// it treats the draws as independent, which slightly understates the real odds
+func (i *Item) Odds(memberId string, weights map[string]float64) float64 {
+	if !i.HasMember(memberId) {
+		return 0
+	}
+
+	if len(i.Members) <= i.Amount {
+		return 1
+	}
+
+	total := 0.0
+	for _, id := range i.Members {
+		total += entryWeight(weights, id)
+	}
+	if total == 0 {
+		return 0
+	}
+
+	p := entryWeight(weights, memberId) / total
+	return 1 - math.Pow(1-p, float64(i.Amount))
+}
+
+// entryWeight defaults to 1 for members without a weight, like draws from before weighting
+func entryWeight(weights map[string]float64, memberId string) float64 {
+	if weight, ok := weights[memberId]; ok {
+		return weight
+	}
+	return 1
+}
@@ -0,0 +1,65 @@
+package giveaway
+
+import (
+	"math"
+	"testing"
+
+	"github.com/sol-armada/sol-bot/tokens"
+)
+
+func TestOdds(t *testing.T) {
+	item := &Item{Id: "i", Amount: 1, Members: []string{"a", "b", "c"}}
+	weights := map[string]float64{"a": 2, "b": 1, "c": 1}
+
+	tests := []struct {
+		memberId string
+		amount   int
+		want     float64
+	}{
+		{memberId: "a", amount: 1, want: 0.5},
+		{memberId: "b", amount: 1, want: 0.25},
+		{memberId: "d", amount: 1, want: 0},
+		{memberId: "b", amount: 3, want: 1},
+	}
+
+	for _, tt := range tests {
+		item.Amount = tt.amount
+		if got := item.Odds(tt.memberId, weights); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("Odds(%s) with %d prizes = %v, want %v", tt.memberId, tt.amount, got, tt.want)
+		}
+	}
+}
+
+func TestTokenWeights(t *testing.T) {
+	if err := tokens.Setup(tokens.NewMemoryStore()); err != nil {
+		t.Fatal(err)
+	}
+
+	records := []*tokens.TokenRecord{
+		tokens.New("a", 20, tokens.ReasonAttendance, nil, nil, nil),
+		tokens.New("a", -5, tokens.ReasonWonRaffle, nil, nil, nil),
+		tokens.New("a", -3, tokens.ReasonGiveawayEntry, nil, nil, nil),
+		// taken by an officer, not spent
+		tokens.New("a", -4, tokens.ReasonOther, nil, nil, nil),
+	}
+	for _, record := range records {
+		if err := record.Save(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	g := &Giveaway{
+		Weighting: Weighting{Mode: WeightTokens},
+		Items: map[string]*Item{
+			"i": {Id: "i", Amount: 1, Members: []string{"a", "b"}},
+		},
+	}
+
+	weights, err := g.GetWeights()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if weights["a"] != 9 || weights["b"] != 1 {
+		t.Errorf("expected weights a=9 b=1, got %v", weights)
+	}
+}
@@ -3,21 +3,22 @@ module github.com/sol-armada/sol-bot
 go 1.26.0
 
 require (
+	github.com/PuerkitoBio/goquery v1.5.1
+	github.com/antchfx/htmlquery v1.2.3
 	github.com/bwmarrin/discordgo v0.29.1-0.20260214123928-f43dd94faaac
 	github.com/go-co-op/gocron/v2 v2.21.1
 	github.com/google/uuid v1.6.0
 	github.com/lithammer/fuzzysearch v1.1.8
 	go.mongodb.org/mongo-driver v1.17.3
 	golang.org/x/exp v0.0.0-20221126150942-6ab00d035af9
+	golang.org/x/net v0.42.0
 	golang.org/x/text v0.28.0
 	google.golang.org/api v0.81.0
 )
 
 require (
 	cloud.google.com/go/compute v1.6.1 // indirect
-	github.com/PuerkitoBio/goquery v1.5.1 // indirect
 	github.com/andybalholm/cascadia v1.2.0 // indirect
-	github.com/antchfx/htmlquery v1.2.3 // indirect
 	github.com/antchfx/xmlquery v1.2.4 // indirect
 	github.com/antchfx/xpath v1.1.8 // indirect
 	github.com/fsnotify/fsnotify v1.5.4 // indirect
@@ -53,7 +54,6 @@ require (
 	github.com/youmark/pkcs8 v0.0.0-20240726163527-a2c0da244d78 // indirect
 	go.opencensus.io v0.23.0 // indirect
 	golang.org/x/crypto v0.41.0 // indirect
-	golang.org/x/net v0.42.0 // indirect
 	golang.org/x/oauth2 v0.0.0-20220411215720-9780585627b5 // indirect
 	golang.org/x/sync v0.16.0 // indirect
 	google.golang.org/appengine v1.6.8 // indirect
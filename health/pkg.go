@@ -11,22 +11,27 @@ import (
 var healthy bool = false
 
 func Monitor() {
-	logger := slog.Default().With("func", "health.Monitor")
 	for {
-		s := stores.Get()
-		ctx := context.Background()
-		if !s.Connected(ctx) {
-			logger.Warn("not connected to storage")
-			healthy = false
-			goto WAIT
-		}
-
-		healthy = true
-	WAIT:
+		Check(context.Background())
 		time.Sleep(10 * time.Second)
 	}
 }
 
+// Check updates and returns the current health
+func Check(ctx context.Context) bool {
+	logger := slog.Default().With("func", "health.Check")
+
+	s := stores.Get()
+	if s == nil || !s.Connected(ctx) {
+		logger.Warn("not connected to storage")
+		healthy = false
+		return healthy
+	}
+
+	healthy = true
+	return healthy
+}
+
 func IsHealthy() bool {
 	return healthy
 }
@@ -0,0 +1,25 @@
+package members
+
+import (
+	"slices"
+	"strings"
+)
+
+// FindEnemyOrgs returns the orgs from the enemy list the member is in, either as their primary org or an affiliation
+func (m *Member) FindEnemyOrgs(enemies []string) []string {
+	found := []string{}
+
+	orgs := append([]string{m.PrimaryOrg}, m.Affilations...)
+	for _, org := range orgs {
+		org = strings.ToUpper(org)
+		if org == "" || slices.Contains(found, org) {
+			continue
+		}
+
+		if slices.ContainsFunc(enemies, func(enemy string) bool { return strings.EqualFold(enemy, org) }) {
+			found = append(found, org)
+		}
+	}
+
+	return found
+}
@@ -0,0 +1,100 @@
+package members
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/sol-armada/sol-bot/settings"
+)
+
+// Dossier is the public record on the member's RSI citizen page
+type Dossier struct {
+	Handle  string `json:"handle" bson:"handle"`
+	Moniker string `json:"moniker" bson:"moniker"`
+	// RecordNumber is the UEE citizen record, empty when RSI shows n/a
+	RecordNumber string    `json:"record_number" bson:"record_number"`
+	Enlisted     time.Time `json:"enlisted" bson:"enlisted"`
+	Location     string    `json:"location" bson:"location"`
+	Fluency      []string  `json:"fluency" bson:"fluency"`
+	// Title is the badge shown under the moniker
+	Title     string    `json:"title" bson:"title"`
+	Avatar    string    `json:"avatar" bson:"avatar"`
+	UpdatedAt time.Time `json:"updated_at" bson:"updated_at"`
+}
+
+// Stale reports if the dossier is missing or older than RSI.DOSSIER_MAX_AGE
+func (d *Dossier) Stale() bool {
+	if d == nil {
+		return true
+	}
+	return time.Since(d.UpdatedAt) > settings.GetDurationWithDefault("RSI.DOSSIER_MAX_AGE", 7*24*time.Hour)
+}
+
+// AccountAge is how long ago the citizen enlisted, zero when the enlisted date is unknown
+func (d *Dossier) AccountAge(now time.Time) time.Duration {
+	if d.Enlisted.IsZero() {
+		return 0
+	}
+	return now.Sub(d.Enlisted)
+}
+
+// Warnings lists what recruiters should look twice at, like accounts enlisted within RSI.NEW_ACCOUNT_DAYS
+func (d *Dossier) Warnings(now time.Time) []string {
+	warnings := []string{}
+
+	newAccountDays := settings.GetIntWithDefault("RSI.NEW_ACCOUNT_DAYS", 30)
+	switch {
+	case d.Enlisted.IsZero():
+		warnings = append(warnings, "Unknown enlisted date")
+	case d.AccountAge(now) < time.Duration(newAccountDays)*24*time.Hour:
+		warnings = append(warnings, fmt.Sprintf("Enlisted %d days ago", int(d.AccountAge(now).Hours()/24)))
+	}
+
+	if d.RecordNumber == "" {
+		warnings = append(warnings, "No citizen record")
+	}
+
+	if d.Avatar == "" || strings.Contains(d.Avatar, "avatar_default") {
+		warnings = append(warnings, "Default avatar")
+	}
+
+	return warnings
+}
+
+// EmbedFields shows the dossier in an embed, with any warnings last
+func (d *Dossier) EmbedFields(now time.Time) []*discordgo.MessageEmbedField {
+	enlisted := "Unknown"
+	if !d.Enlisted.IsZero() {
+		enlisted = fmt.Sprintf("<t:%d:D> (<t:%d:R>)", d.Enlisted.Unix(), d.Enlisted.Unix())
+	}
+
+	record := d.RecordNumber
+	if record == "" {
+		record = "None"
+	}
+
+	fields := []*discordgo.MessageEmbedField{
+		{Name: "Enlisted", Value: enlisted, Inline: true},
+		{Name: "Citizen Record", Value: record, Inline: true},
+	}
+
+	if d.Title != "" {
+		fields = append(fields, &discordgo.MessageEmbedField{Name: "Title", Value: d.Title, Inline: true})
+	}
+
+	if d.Location != "" {
+		fields = append(fields, &discordgo.MessageEmbedField{Name: "Location", Value: d.Location, Inline: true})
+	}
+
+	if len(d.Fluency) > 0 {
+		fields = append(fields, &discordgo.MessageEmbedField{Name: "Fluency", Value: strings.Join(d.Fluency, ", "), Inline: true})
+	}
+
+	if warnings := d.Warnings(now); len(warnings) > 0 {
+		fields = append(fields, &discordgo.MessageEmbedField{Name: "⚠️ Account Warnings", Value: strings.Join(warnings, "\n"), Inline: false})
+	}
+
+	return fields
+}
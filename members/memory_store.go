@@ -0,0 +1,170 @@
+package members
+
+import (
+	"context"
+	"math/rand/v2"
+	"slices"
+	"sort"
+	"sync"
+
+	"github.com/sol-armada/sol-bot/ranks"
+)
+
+type memoryStore struct {
+	mu       sync.RWMutex
+	members  map[string]*Member
+	watchers []chan Member
+}
+
+var _ Store = (*memoryStore)(nil)
+
+// NewMemoryStore returns a Store that keeps members in memory. Used for tests
+func NewMemoryStore() Store {
+	return &memoryStore{members: map[string]*Member{}}
+}
+
+func (s *memoryStore) Get(id string) (*Member, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	member, ok := s.members[id]
+	if !ok {
+		return nil, MemberNotFound
+	}
+
+	return cloneMember(member), nil
+}
+
+func (s *memoryStore) GetList(ids []string) ([]*Member, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	members := []*Member{}
+	for _, id := range ids {
+		if member, ok := s.members[id]; ok {
+			members = append(members, cloneMember(member))
+		}
+	}
+
+	return members, nil
+}
+
+func (s *memoryStore) GetRandom(max int, maxRank ranks.Rank) ([]Member, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	members := []Member{}
+	for _, member := range s.members {
+		if member.Rank == ranks.None || member.Rank > maxRank {
+			continue
+		}
+		members = append(members, *cloneMember(member))
+	}
+
+	rand.Shuffle(len(members), func(i, j int) {
+		members[i], members[j] = members[j], members[i]
+	})
+
+	if len(members) > max {
+		members = members[:max]
+	}
+
+	return members, nil
+}
+
+func (s *memoryStore) List(filter ListFilter, page, max int) ([]Member, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	members := []Member{}
+	for _, member := range s.members {
+		if filter.matches(member) {
+			members = append(members, *cloneMember(member))
+		}
+	}
+
+	// stable paging
+	sort.Slice(members, func(i, j int) bool { return members[i].Id < members[j].Id })
+
+	if page > 0 {
+		start := (page - 1) * max
+		if start >= len(members) {
+			return []Member{}, nil
+		}
+		end := min(start+max, len(members))
+		members = members[start:end]
+	}
+
+	return members, nil
+}
+
+func (s *memoryStore) Save(member *Member) error {
+	s.mu.Lock()
+	_, exists := s.members[member.Id]
+	s.members[member.Id] = cloneMember(member)
+	watchers := slices.Clone(s.watchers)
+	s.mu.Unlock()
+
+	if !exists {
+		for _, w := range watchers {
+			w <- *cloneMember(member)
+		}
+	}
+
+	return nil
+}
+
+func (s *memoryStore) BulkSave(members []Member) error {
+	for i := range members {
+		if err := s.Save(&members[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *memoryStore) Delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.members, id)
+	return nil
+}
+
+func (s *memoryStore) GetIDs() ([]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	ids := make([]string, 0, len(s.members))
+	for id := range s.members {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	return ids, nil
+}
+
+func (s *memoryStore) Watch(ctx context.Context, out chan Member) error {
+	s.mu.Lock()
+	s.watchers = append(s.watchers, out)
+	s.mu.Unlock()
+
+	<-ctx.Done()
+
+	s.mu.Lock()
+	s.watchers = slices.DeleteFunc(s.watchers, func(w chan Member) bool { return w == out })
+	s.mu.Unlock()
+
+	return ctx.Err()
+}
+
+func cloneMember(m *Member) *Member {
+	c := *m
+	c.Affilations = slices.Clone(m.Affilations)
+	c.DKP = slices.Clone(m.DKP)
+	c.Merits = slices.Clone(m.Merits)
+	c.Demerits = slices.Clone(m.Demerits)
+	c.BlueprintIds = slices.Clone(m.BlueprintIds)
+	c.Gameplay = slices.Clone(m.Gameplay)
+	return &c
+}
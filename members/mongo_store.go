@@ -0,0 +1,210 @@
+package members
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/sol-armada/sol-bot/ranks"
+	"github.com/sol-armada/sol-bot/stores"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type mongoStore struct {
+	store *stores.MembersStore
+}
+
+var _ Store = (*mongoStore)(nil)
+
+// NewMongoStore wraps the mongo members collection as a Store
+func NewMongoStore(store *stores.MembersStore) Store {
+	if store == nil {
+		return nil
+	}
+	return &mongoStore{store: store}
+}
+
+func (s *mongoStore) Get(id string) (*Member, error) {
+	cur, err := s.store.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(context.Background())
+
+	member := &Member{}
+	if cur.Next(context.Background()) {
+		if err := cur.Decode(member); err != nil {
+			return nil, err
+		}
+	}
+
+	if member.Id == "" {
+		return nil, MemberNotFound
+	}
+
+	return member, nil
+}
+
+func (s *mongoStore) GetList(ids []string) ([]*Member, error) {
+	cur, err := s.store.GetList(ids)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(context.Background())
+
+	members := []*Member{}
+	for cur.Next(context.Background()) {
+		member := &Member{}
+		if err := cur.Decode(member); err != nil {
+			return nil, err
+		}
+		members = append(members, member)
+	}
+
+	return members, nil
+}
+
+func (s *mongoStore) GetRandom(max int, maxRank ranks.Rank) ([]Member, error) {
+	membersMap, err := s.store.GetRandom(max, int(maxRank))
+	if err != nil {
+		return nil, err
+	}
+
+	members := []Member{}
+	for _, memberMap := range membersMap {
+		raw, err := bson.Marshal(memberMap)
+		if err != nil {
+			return nil, err
+		}
+		member := Member{}
+		if err := bson.Unmarshal(raw, &member); err != nil {
+			return nil, err
+		}
+		members = append(members, member)
+	}
+
+	return members, nil
+}
+
+func (s *mongoStore) List(filter ListFilter, page, max int) ([]Member, error) {
+	query := bson.D{}
+	if filter.ExcludeBots {
+		query = append(query, bson.E{Key: "is_bot", Value: bson.D{{Key: "$eq", Value: false}}})
+	}
+	if filter.BlueprintId != "" {
+		query = append(query, bson.E{Key: "blueprintIds", Value: bson.D{{Key: "$in", Value: []string{filter.BlueprintId}}}})
+	}
+
+	cur, err := s.store.List(query, page, max)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(context.Background())
+
+	members := []Member{}
+	for cur.Next(context.Background()) {
+		member := Member{}
+		if err := cur.Decode(&member); err != nil {
+			return nil, err
+		}
+		members = append(members, member)
+	}
+
+	return members, nil
+}
+
+func (s *mongoStore) Save(member *Member) error {
+	return s.store.Upsert(member.Id, toDocument(member))
+}
+
+func (s *mongoStore) BulkSave(members []Member) error {
+	docs := make([]interface{}, 0, len(members))
+	for i := range members {
+		docs = append(docs, toDocument(&members[i]))
+	}
+
+	return s.store.BulkUpsert(docs)
+}
+
+func (s *mongoStore) Delete(id string) error {
+	return s.store.Delete(id)
+}
+
+func (s *mongoStore) GetIDs() ([]string, error) {
+	return s.store.GetIDsOnly()
+}
+
+func (s *mongoStore) Watch(ctx context.Context, out chan Member) error {
+	stream, err := s.store.Watch(ctx, bson.D{})
+	if err != nil {
+		return err
+	}
+	defer stream.Close(ctx)
+
+	for stream.Next(ctx) {
+		var event bson.M
+		if err := stream.Decode(&event); err != nil {
+			return err
+		}
+
+		operationType, ok := event["operationType"].(string)
+		if !ok {
+			slog.Error("operationType not found in event", "event", event)
+			continue
+		}
+
+		if operationType != "insert" {
+			continue
+		}
+
+		memberRaw, ok := event["fullDocument"].(bson.M)
+		if !ok {
+			slog.Error("fullDocument not found in event", "event", event)
+			continue
+		}
+
+		bytes, err := bson.Marshal(memberRaw)
+		if err != nil {
+			slog.Error("failed to marshal member", "err", err)
+			continue
+		}
+
+		var member Member
+		if err := bson.Unmarshal(bytes, &member); err != nil {
+			slog.Error("failed to unmarshal member", "err", err)
+			continue
+		}
+
+		out <- member
+	}
+	return ctx.Err()
+}
+
+// toDocument converts the member into the shape stored in mongo
+func toDocument(m *Member) map[string]interface{} {
+	memberMap := m.ToMap()
+
+	memberMap["_id"] = memberMap["id"]
+	delete(memberMap, "id")
+
+	if recruiter, ok := memberMap["recruiter"].(map[string]any); ok {
+		memberMap["recruiter"] = recruiter["id"]
+	}
+
+	if m.MemberSince.IsZero() {
+		memberMap["member_since"] = m.Joined.UTC()
+	}
+
+	// convert go datetimes to mongo datetimes
+	memberMap["joined"] = m.Joined.UTC()
+	memberMap["updated"] = m.Updated.UTC()
+
+	if m.ValidatedAt != nil {
+		memberMap["validated_at"] = m.ValidatedAt.UTC()
+	}
+
+	if m.OnboardedAt != nil {
+		memberMap["onboarded_at"] = m.OnboardedAt.UTC()
+	}
+
+	return memberMap
+}
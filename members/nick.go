@@ -0,0 +1,75 @@
+package members
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/sol-armada/sol-bot/ranks"
+)
+
+// MaxNickLength is the longest nickname Discord accepts
+const MaxNickLength = 32
+
+var (
+	rankPrefixRegex = regexp.MustCompile(`\[(.*?)\] `)
+	allyTagRegex    = regexp.MustCompile(`\{(.*?)\} `)
+	suffixRegex     = regexp.MustCompile(` \((.*?)\)`)
+)
+
+// NickFits reports if Discord will accept the nickname
+func NickFits(nick string) bool {
+	return utf8.RuneCountInString(nick) <= MaxNickLength
+}
+
+// parseNick splits the member's nickname into the name and suffix, without the rank prefix and ally tag
+func parseNick(discordMember *discordgo.Member) (string, string) {
+	if discordMember.Nick == "" {
+		return discordMember.User.Username, ""
+	}
+
+	suffix := strings.TrimSpace(suffixRegex.FindString(discordMember.Nick))
+	suffix = strings.ReplaceAll(suffix, "(", "")
+	suffix = strings.ReplaceAll(suffix, ")", "")
+
+	name := rankPrefixRegex.ReplaceAllString(discordMember.Nick, "")
+	name = allyTagRegex.ReplaceAllString(name, "")
+	name = suffixRegex.ReplaceAllString(name, "")
+
+	return name, suffix
+}
+
+// CanonicalNick is the nickname the member should have: their rank prefix, ally tag, name and stored suffix.
+// The suffix is dropped when the nickname would be too long and false is returned if it still doesn't fit.
+// The member isn't changed, so a dropped suffix is still there if the name gets shorter
+func (m *Member) CanonicalNick(discordMember *discordgo.Member) (string, bool) {
+	name := m.Name
+	if discordMember != nil {
+		name, _ = parseNick(discordMember)
+	}
+
+	// allies the bot tagged get their verified org, allies given the role by hand keep the tag they have
+	sid := m.AllyOrg
+	if sid == "" && m.IsAlly && discordMember != nil {
+		if match := allyTagRegex.FindStringSubmatch(discordMember.Nick); match != nil {
+			sid = match[1]
+		}
+	}
+
+	nick := name
+	if sid != "" {
+		nick = "{" + strings.ToUpper(sid) + "} " + nick
+	}
+	if prefix := ranks.Prefix[m.Rank]; prefix != "" {
+		nick = prefix + " " + nick
+	}
+
+	if m.Suffix != "" {
+		if withSuffix := nick + " (" + m.Suffix + ")"; NickFits(withSuffix) {
+			return withSuffix, true
+		}
+	}
+
+	return nick, NickFits(nick)
+}
@@ -0,0 +1,57 @@
+package members
+
+import (
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/sol-armada/sol-bot/ranks"
+)
+
+func TestCanonicalNick(t *testing.T) {
+	tests := []struct {
+		name   string
+		member Member
+		nick   string
+		want   string
+		fits   bool
+	}{
+		{name: "rank prefix", member: Member{Rank: ranks.Technician}, nick: "SomePilot", want: "[TEC] SomePilot", fits: true},
+		{name: "old prefix replaced", member: Member{Rank: ranks.Lieutenant}, nick: "[TEC] SomePilot", want: "[LT] SomePilot", fits: true},
+		{name: "no prefix for members", member: Member{Rank: ranks.Member}, nick: "[TEC] SomePilot", want: "SomePilot", fits: true},
+		{name: "suffix kept", member: Member{Rank: ranks.Technician, Suffix: "Bob"}, nick: "SomePilot (Bob)", want: "[TEC] SomePilot (Bob)", fits: true},
+		{name: "verified ally", member: Member{AllyOrg: "FRIENDLY", IsAlly: true}, nick: "SomePilot", want: "{FRIENDLY} SomePilot", fits: true},
+		{name: "manual ally keeps tag", member: Member{IsAlly: true}, nick: "{OTHER} SomePilot", want: "{OTHER} SomePilot", fits: true},
+		{name: "tag removed", member: Member{}, nick: "{OTHER} SomePilot", want: "SomePilot", fits: true},
+		{name: "stored suffix added", member: Member{Rank: ranks.Technician, Suffix: "Bob"}, nick: "SomePilot", want: "[TEC] SomePilot (Bob)", fits: true},
+		{name: "suffix dropped", member: Member{Rank: ranks.Technician, Suffix: "Bob"}, nick: "AVeryLongHandleForAPilot (Bob)", want: "[TEC] AVeryLongHandleForAPilot", fits: true},
+		{name: "too long", member: Member{Rank: ranks.Technician}, nick: "AVeryVeryLongHandleForAPilot12", want: "[TEC] AVeryVeryLongHandleForAPilot12", fits: false},
+	}
+
+	for _, tt := range tests {
+		suffix := tt.member.Suffix
+		got, fits := tt.member.CanonicalNick(&discordgo.Member{Nick: tt.nick, User: &discordgo.User{Username: "somepilot"}})
+		if got != tt.want || fits != tt.fits {
+			t.Errorf("%s: CanonicalNick() = %q, %v, want %q, %v", tt.name, got, fits, tt.want, tt.fits)
+		}
+		if tt.member.Suffix != suffix {
+			t.Errorf("%s: CanonicalNick() changed the suffix to %q", tt.name, tt.member.Suffix)
+		}
+	}
+}
+
+func TestGetTrueNickKeepsSuffix(t *testing.T) {
+	member := Member{Suffix: "Bob"}
+
+	// the suffix was dropped to fit, the next pass must not lose it
+	if got := member.GetTrueNick(&discordgo.Member{Nick: "[TEC] AVeryLongHandleForAPilot", User: &discordgo.User{}}); got != "AVeryLongHandleForAPilot" {
+		t.Errorf("GetTrueNick() = %q, want %q", got, "AVeryLongHandleForAPilot")
+	}
+	if member.Suffix != "Bob" {
+		t.Errorf("expected the stored suffix to be kept, got %q", member.Suffix)
+	}
+
+	member.GetTrueNick(&discordgo.Member{Nick: "SomePilot (Rob)", User: &discordgo.User{}})
+	if member.Suffix != "Rob" {
+		t.Errorf("expected the suffix to follow the nick, got %q", member.Suffix)
+	}
+}
@@ -0,0 +1,55 @@
+package members
+
+import (
+	"fmt"
+	"strings"
+)
+
+// OnboardingRSIHandle is the field of the onboarding question asking for the RSI handle. Every flow has one
+const OnboardingRSIHandle = "rsi_handle"
+
+// OnboardingFields are where onboarding answers can be stored on the member
+var OnboardingFields = []string{
+	OnboardingRSIHandle,
+	"playtime",
+	"gameplay",
+	"age",
+	"recruiter",
+	"other",
+	"pronouns",
+	"time_zone",
+}
+
+// OnboardingAnswer is an answer to an onboarding question, kept so officers see every answer
+type OnboardingAnswer struct {
+	Question string `json:"question" bson:"question"`
+	Field    string `json:"field" bson:"field"`
+	Answer   string `json:"answer" bson:"answer"`
+}
+
+// SetOnboardingAnswer stores the answer in the field. The RSI handle is set with SetRSIHandle once it is checked
+func (m *Member) SetOnboardingAnswer(field, answer string) error {
+	answer = strings.TrimSpace(answer)
+
+	switch field {
+	case "", OnboardingRSIHandle:
+	case "playtime":
+		m.LegacyPlaytime = answer
+	case "gameplay":
+		m.LegacyGameplay = answer
+	case "age":
+		m.LegacyAge = answer
+	case "recruiter":
+		m.LegacyRecruiter = answer
+	case "other":
+		m.LegacyOther = answer
+	case "pronouns":
+		m.Pronouns = answer
+	case "time_zone":
+		m.TimeZone = answer
+	default:
+		return fmt.Errorf("unknown onboarding field %q", field)
+	}
+
+	return nil
+}
@@ -1,15 +1,12 @@
 package members
 
 import (
-	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"log/slog"
 	"net/http"
-	"regexp"
 	"slices"
-	"strings"
 	"time"
 
 	"github.com/apex/log"
@@ -18,8 +15,6 @@ import (
 	"github.com/sol-armada/sol-bot/auth"
 	"github.com/sol-armada/sol-bot/ranks"
 	"github.com/sol-armada/sol-bot/settings"
-	"github.com/sol-armada/sol-bot/stores"
-	"go.mongodb.org/mongo-driver/bson"
 )
 
 type Member struct {
@@ -31,6 +26,7 @@ type Member struct {
 	RSIMember      bool       `json:"rsi_member" bson:"rsi_member"`
 	BadAffiliation bool       `json:"bad_affiliation" bson:"bad_affiliation"`
 	Affilations    []string   `json:"affiliations" bson:"affilations"`
+	Dossier        *Dossier   `json:"dossier" bson:"dossier"`
 	Avatar         string     `json:"avatar" bson:"avatar"`
 	Updated        time.Time  `json:"updated" bson:"updated"`
 	Validated      bool       `json:"validated" bson:"validated"`
@@ -41,6 +37,22 @@ type Member struct {
 
 	MemberSince time.Time `json:"member_since" bson:"member_since"`
 
+	// EnemyAffiliations are the enemy orgs the member was in when last checked
+	EnemyAffiliations []string `json:"enemy_affiliations" bson:"enemy_affiliations"`
+	// AllyOrg is the registered ally org the member was verified in. The ally role and tag follow it
+	AllyOrg string `json:"ally_org" bson:"ally_org"`
+	// KeepNickname opts the member out of the bot setting their nickname
+	KeepNickname bool `json:"keep_nickname" bson:"keep_nickname"`
+
+	// RSIHandle is what RSI lookups use, see Handle. Set by onboarding and verification
+	RSIHandle string `json:"rsi_handle" bson:"rsi_handle"`
+
+	// verification, see StartValidation
+	ValidationHandle    string      `json:"validation_handle" bson:"validation_handle"`
+	ValidationExpiresAt *time.Time  `json:"validation_expires_at" bson:"validation_expires_at"`
+	ValidationAttempts  []time.Time `json:"validation_attempts" bson:"validation_attempts"`
+	ValidationCheckedAt *time.Time  `json:"validation_checked_at" bson:"validation_checked_at"`
+
 	IsBot       bool `json:"is_bot" bson:"is_bot"`
 	IsAlly      bool `json:"is_ally" bson:"is_ally"`
 	IsAffiliate bool `json:"is_affiliate" bson:"is_affiliate"`
@@ -68,6 +80,8 @@ type Member struct {
 	TimeZone    string         `json:"time_zone" bson:"time_zone"`
 	Other       string         `json:"other" bson:"other"`
 
+	OnboardingAnswers []OnboardingAnswer `json:"onboarding_answers" bson:"onboarding_answers"`
+
 	LegacyAge       string `json:"legacy_age" bson:"legacy_age"`
 	LegacyPlaytime  string `json:"legacy_playtime" bson:"legacy_playtime"`
 	LegacyGameplay  string `json:"legacy_gameplay" bson:"legacy_gameplay"`
@@ -98,15 +112,13 @@ var (
 	MemberNotFound MemberError = errors.New("member not found")
 )
 
-var membersStore *stores.MembersStore
+var membersStore Store
 
-func Setup() error {
-	storesClient := stores.Get()
-	ms, ok := storesClient.GetMembersStore()
-	if !ok {
+func Setup(store Store) error {
+	if store == nil {
 		return errors.New("members store not found")
 	}
-	membersStore = ms
+	membersStore = store
 
 	return nil
 }
@@ -125,106 +137,28 @@ func New(discordMember *discordgo.Member) *Member {
 }
 
 func Get(id string) (*Member, error) {
-	member := &Member{}
-
-	// check the store
-	cur, err := membersStore.Get(id)
-	if err != nil {
-		return nil, err
-	}
-
-	if cur.Next(context.Background()) {
-		if err := cur.Decode(member); err != nil {
-			return nil, err
-		}
-	}
-
-	if member.Id == "" {
-		return nil, MemberNotFound
-	}
-
-	return member, nil
+	return membersStore.Get(id)
 }
 
 func GetList(ids []string) ([]*Member, error) {
-	cur, err := membersStore.GetList(ids)
-	if err != nil {
-		return nil, err
-	}
-
-	members := []*Member{}
-
-	for cur.Next(context.Background()) {
-		member := &Member{}
-		if err := cur.Decode(member); err != nil {
-			return nil, err
-		}
-		members = append(members, member)
-	}
-
-	return members, nil
+	return membersStore.GetList(ids)
 }
 
 func GetRandom(max int, maxRank ranks.Rank) ([]Member, error) {
-	membersMap, err := membersStore.GetRandom(max, int(maxRank))
-	if err != nil {
-		return nil, err
-	}
-
-	members := []Member{}
-
-	for _, memberMap := range membersMap {
-		j, _ := json.Marshal(memberMap)
-		member := &Member{}
-		if err := json.Unmarshal(j, member); err != nil {
-			return nil, err
-		}
-		members = append(members, *member)
-	}
-
-	return members, nil
+	return membersStore.GetRandom(max, maxRank)
 }
 
 func List(page int) ([]Member, error) {
-	cur, err := membersStore.List(bson.D{{Key: "is_bot", Value: bson.D{{Key: "$eq", Value: false}}}}, page, 100)
-	if err != nil {
-		return nil, err
-	}
-
-	members := []Member{}
-
-	for cur.Next(context.Background()) {
-		member := Member{}
-		if err := cur.Decode(&member); err != nil {
-			return nil, err
-		}
-		members = append(members, member)
-	}
+	return membersStore.List(ListFilter{ExcludeBots: true}, page, 100)
+}
 
-	return members, nil
+// ListAll returns every stored member except bots
+func ListAll() ([]Member, error) {
+	return membersStore.List(ListFilter{ExcludeBots: true}, 0, 0)
 }
 
 func ListByBlueprint(blueprintId string) ([]Member, error) {
-	cur, err := membersStore.List(bson.D{
-		{Key: "blueprintIds", Value: bson.D{
-			{Key: "$in", Value: []string{blueprintId}},
-		}},
-	}, 0, 0)
-	if err != nil {
-		return nil, err
-	}
-
-	members := []Member{}
-
-	for cur.Next(context.Background()) {
-		member := Member{}
-		if err := cur.Decode(&member); err != nil {
-			return nil, err
-		}
-		members = append(members, member)
-	}
-
-	return members, nil
+	return membersStore.List(ListFilter{BlueprintId: blueprintId}, 0, 0)
 }
 
 func (m *Member) GetTrueNick(discordMember *discordgo.Member) string {
@@ -232,18 +166,10 @@ func (m *Member) GetTrueNick(discordMember *discordgo.Member) string {
 		return m.Name
 	}
 
-	trueNick := discordMember.User.Username
-	if discordMember.Nick != "" {
-		regRank := regexp.MustCompile(`\[(.*?)\] `)
-		regAlly := regexp.MustCompile(`\{(.*?)\} `)
-		suffix := regexp.MustCompile(` \((.*?)\)`)
-		s := strings.TrimSpace(suffix.FindString(discordMember.Nick))
-		s = strings.ReplaceAll(s, "(", "")
-		s = strings.ReplaceAll(s, ")", "")
-		m.Suffix = s
-		trueNick = regRank.ReplaceAllString(discordMember.Nick, "")
-		trueNick = regAlly.ReplaceAllString(trueNick, "")
-		trueNick = suffix.ReplaceAllString(trueNick, "")
+	trueNick, suffix := parseNick(discordMember)
+	// a nick without a suffix keeps the stored one, it may have been dropped to fit
+	if suffix != "" {
+		m.Suffix = suffix
 	}
 
 	return trueNick
@@ -252,33 +178,7 @@ func (m *Member) GetTrueNick(discordMember *discordgo.Member) string {
 func (m *Member) Save() error {
 	m.Updated = time.Now().UTC()
 
-	memberMap := m.ToMap()
-
-	memberMap["_id"] = memberMap["id"]
-	delete(memberMap, "id")
-
-	if recruiter, ok := memberMap["recruiter"].(map[string]any); ok {
-		memberMap["recruiter"] = recruiter["id"]
-	}
-
-	if m.MemberSince.IsZero() {
-		memberMap["member_since"] = m.Joined.UTC()
-	}
-
-	// convert go datetimes to mongo datetimes
-	memberMap["joined"] = m.Joined.UTC()
-
-	memberMap["updated"] = m.Updated.UTC()
-
-	if m.ValidatedAt != nil {
-		memberMap["validated_at"] = m.ValidatedAt.UTC()
-	}
-
-	if m.OnboardedAt != nil {
-		memberMap["onboarded_at"] = m.OnboardedAt.UTC()
-	}
-
-	return membersStore.Upsert(m.Id, memberMap)
+	return membersStore.Save(m)
 }
 
 func BulkSave(members []Member) error {
@@ -286,44 +186,16 @@ func BulkSave(members []Member) error {
 		return nil
 	}
 
-	memberMaps := make([]interface{}, 0, len(members))
 	now := time.Now().UTC()
-
-	for _, member := range members {
-		member.Updated = now
-
-		memberMap := member.ToMap()
-		memberMap["_id"] = memberMap["id"]
-		delete(memberMap, "id")
-
-		if recruiter, ok := memberMap["recruiter"].(map[string]any); ok {
-			memberMap["recruiter"] = recruiter["id"]
-		}
-
-		if member.MemberSince.IsZero() {
-			memberMap["member_since"] = member.Joined.UTC()
-		}
-
-		// convert go datetimes to mongo datetimes
-		memberMap["joined"] = member.Joined.UTC()
-		memberMap["updated"] = member.Updated.UTC()
-
-		if member.ValidatedAt != nil {
-			memberMap["validated_at"] = member.ValidatedAt.UTC()
-		}
-
-		if member.OnboardedAt != nil {
-			memberMap["onboarded_at"] = member.OnboardedAt.UTC()
-		}
-
-		memberMaps = append(memberMaps, memberMap)
+	for i := range members {
+		members[i].Updated = now
 	}
 
-	return membersStore.BulkUpsert(memberMaps)
+	return membersStore.BulkSave(members)
 }
 
 func GetStoredMemberIDs() ([]string, error) {
-	return membersStore.GetIDsOnly()
+	return membersStore.GetIDs()
 }
 
 func ValidateDiscordMembers(discordMembers []*discordgo.Member) []*discordgo.Member {
@@ -409,14 +281,11 @@ func (m *Member) Login(code string) error {
 		return err
 	}
 
-	cur, err := membersStore.Get(discordUser.User.ID)
+	stored, err := membersStore.Get(discordUser.User.ID)
 	if err != nil {
 		return errors.Wrap(err, "getting stored member")
 	}
-
-	if err := cur.Decode(m); err != nil {
-		return err
-	}
+	*m = *stored
 
 	m.Avatar = discordUser.Avatar
 	_ = m.Save()
@@ -0,0 +1,104 @@
+package members
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/sol-armada/sol-bot/ranks"
+	"github.com/sol-armada/sol-bot/settings"
+)
+
+// ManagedRoles are the DISCORD.ROLE_IDS keys the role reconciler manages. Roles that aren't set are never touched
+var ManagedRoles = []string{
+	"ADMIRAL",
+	"COMMANDER",
+	"LIEUTENANT",
+	"SPECIALIST",
+	"TECHNICIAN",
+	"MEMBER",
+	"RECRUIT",
+	"AFFILIATE",
+	"ALLY",
+	"GUEST",
+	"VALIDATED",
+}
+
+// RoleDiff is how the member's Discord roles differ from what they should have, as DISCORD.ROLE_IDS keys
+type RoleDiff struct {
+	Add    []string
+	Remove []string
+}
+
+func (d RoleDiff) Empty() bool {
+	return len(d.Add) == 0 && len(d.Remove) == 0
+}
+
+// RoleID returns the Discord role id set for the key, empty if it isn't set
+func RoleID(key string) string {
+	return settings.GetString("DISCORD.ROLE_IDS." + key)
+}
+
+// DesiredRoles returns the DISCORD.ROLE_IDS keys the member should have. Recruits keep their role until they show up
+// in the org on RSI, and the ally role follows the ally registry through AllyOrg
+func (m *Member) DesiredRoles(discordRoles []string) []string {
+	has := func(key string) bool {
+		id := RoleID(key)
+		return id != "" && slices.Contains(discordRoles, id)
+	}
+
+	want := []string{}
+
+	rank := ""
+	if !m.IsAffiliate && m.Rank >= ranks.Admiral && m.Rank <= ranks.Member {
+		rank = strings.ToUpper(m.Rank.String())
+		want = append(want, rank)
+	}
+
+	if m.IsAffiliate {
+		want = append(want, "AFFILIATE")
+	}
+
+	recruit := rank == "" && !m.IsAffiliate && has("RECRUIT")
+	if recruit {
+		want = append(want, "RECRUIT")
+	}
+
+	ally := m.AllyOrg != ""
+	if ally {
+		want = append(want, "ALLY")
+	}
+
+	if rank == "" && !m.IsAffiliate && !recruit && !ally {
+		want = append(want, "GUEST")
+	}
+
+	if m.Validated {
+		want = append(want, "VALIDATED")
+	}
+
+	return want
+}
+
+// RoleDiff compares the member's Discord roles against DesiredRoles
+func (m *Member) RoleDiff(discordRoles []string) RoleDiff {
+	want := m.DesiredRoles(discordRoles)
+
+	diff := RoleDiff{}
+	for _, key := range ManagedRoles {
+		id := RoleID(key)
+		if id == "" {
+			continue
+		}
+
+		wanted := slices.Contains(want, key)
+		has := slices.Contains(discordRoles, id)
+		switch {
+		case wanted && !has:
+			diff.Add = append(diff.Add, key)
+		case !wanted && has:
+			diff.Remove = append(diff.Remove, key)
+		}
+	}
+
+	return diff
+}
@@ -0,0 +1,94 @@
+package members
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/sol-armada/sol-bot/ranks"
+	"github.com/sol-armada/sol-bot/settings"
+)
+
+func TestRoleDiff(t *testing.T) {
+	for _, key := range ManagedRoles {
+		settings.Set("DISCORD.ROLE_IDS."+key, "id-"+key)
+	}
+	settings.Set("DISCORD.ROLE_IDS.COMMANDER", "")
+	defer func() {
+		for _, key := range ManagedRoles {
+			settings.Set("DISCORD.ROLE_IDS."+key, "")
+		}
+	}()
+
+	tests := []struct {
+		name   string
+		member Member
+		roles  []string
+		add    []string
+		remove []string
+	}{
+		{
+			name:   "rank changed",
+			member: Member{Rank: ranks.Technician, Validated: true},
+			roles:  []string{"id-MEMBER", "id-VALIDATED", "other"},
+			add:    []string{"TECHNICIAN"},
+			remove: []string{"MEMBER"},
+		},
+		{
+			name:   "in sync",
+			member: Member{Rank: ranks.Member},
+			roles:  []string{"id-MEMBER"},
+		},
+		{
+			name:   "unset roles ignored",
+			member: Member{Rank: ranks.Commander},
+			roles:  []string{"id-GUEST"},
+			remove: []string{"GUEST"},
+		},
+		{
+			name:   "affiliate",
+			member: Member{Rank: ranks.Member, IsAffiliate: true},
+			roles:  []string{"id-MEMBER"},
+			add:    []string{"AFFILIATE"},
+			remove: []string{"MEMBER"},
+		},
+		{
+			name:   "recruit kept until in the org",
+			member: Member{Rank: ranks.None},
+			roles:  []string{"id-RECRUIT", "id-GUEST"},
+			remove: []string{"GUEST"},
+		},
+		{
+			name:   "recruit promoted",
+			member: Member{Rank: ranks.Member},
+			roles:  []string{"id-RECRUIT"},
+			add:    []string{"MEMBER"},
+			remove: []string{"RECRUIT"},
+		},
+		{
+			name:   "verified ally",
+			member: Member{AllyOrg: "FRIENDLY", IsAlly: true},
+			add:    []string{"ALLY"},
+		},
+		{
+			name:   "ally without a registered org",
+			member: Member{},
+			roles:  []string{"id-ALLY"},
+			add:    []string{"GUEST"},
+			remove: []string{"ALLY"},
+		},
+		{
+			name:   "guest",
+			member: Member{Validated: true},
+			roles:  []string{"id-TECHNICIAN"},
+			add:    []string{"GUEST", "VALIDATED"},
+			remove: []string{"TECHNICIAN"},
+		},
+	}
+
+	for _, tt := range tests {
+		diff := tt.member.RoleDiff(tt.roles)
+		if !slices.Equal(diff.Add, tt.add) || !slices.Equal(diff.Remove, tt.remove) {
+			t.Errorf("%s: RoleDiff() = +%v -%v, want +%v -%v", tt.name, diff.Add, diff.Remove, tt.add, tt.remove)
+		}
+	}
+}
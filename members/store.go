@@ -0,0 +1,45 @@
+package members
+
+import (
+	"context"
+
+	"github.com/sol-armada/sol-bot/ranks"
+)
+
+// Store is the persistence layer the members package works against.
+type Store interface {
+	Get(id string) (*Member, error)
+	GetList(ids []string) ([]*Member, error)
+	GetRandom(max int, maxRank ranks.Rank) ([]Member, error)
+	List(filter ListFilter, page, max int) ([]Member, error)
+	Save(member *Member) error
+	BulkSave(members []Member) error
+	Delete(id string) error
+	GetIDs() ([]string, error)
+	Watch(ctx context.Context, out chan Member) error
+}
+
+// ListFilter narrows the members returned by Store.List. Zero values match everything.
+type ListFilter struct {
+	ExcludeBots bool
+	BlueprintId string
+}
+
+func (f ListFilter) matches(m *Member) bool {
+	if f.ExcludeBots && m.IsBot {
+		return false
+	}
+	if f.BlueprintId != "" {
+		found := false
+		for _, id := range m.BlueprintIds {
+			if id == f.BlueprintId {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
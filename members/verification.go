@@ -0,0 +1,112 @@
+package members
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/sol-armada/sol-bot/settings"
+	"github.com/sol-armada/sol-bot/utils"
+)
+
+var (
+	ErrNoValidation       = errors.New("no validation in progress")
+	ErrValidationExpired  = errors.New("validation code expired")
+	ErrTooManyValidations = errors.New("too many validation attempts")
+)
+
+// AllowValidationAttempt records an attempt to start or check a validation. ErrTooManyValidations is returned,
+// along with when the next attempt is allowed, once FEATURES.VERIFY.MAX_ATTEMPTS were made within FEATURES.VERIFY.ATTEMPT_WINDOW
+func (m *Member) AllowValidationAttempt(now time.Time) (time.Time, error) {
+	window := settings.GetDurationWithDefault("FEATURES.VERIFY.ATTEMPT_WINDOW", time.Hour)
+	maxAttempts := settings.GetIntWithDefault("FEATURES.VERIFY.MAX_ATTEMPTS", 5)
+
+	recent := []time.Time{}
+	for _, attempt := range m.ValidationAttempts {
+		if now.Sub(attempt) < window {
+			recent = append(recent, attempt)
+		}
+	}
+	m.ValidationAttempts = recent
+
+	if len(recent) >= maxAttempts {
+		return recent[0].Add(window), ErrTooManyValidations
+	}
+
+	m.ValidationAttempts = append(m.ValidationAttempts, now)
+	return now, nil
+}
+
+// StartValidation gives the member a new code to put in the bio of the RSI handle. The code expires after FEATURES.VERIFY.CODE_TTL
+func (m *Member) StartValidation(handle string, now time.Time) string {
+	expires := now.Add(settings.GetDurationWithDefault("FEATURES.VERIFY.CODE_TTL", 30*time.Minute))
+
+	m.ValidationCode = utils.GenerateRandomAlphaNumeric(8)
+	m.ValidationHandle = strings.TrimSpace(handle)
+	m.ValidationExpiresAt = &expires
+
+	return m.ValidationCode
+}
+
+// PendingValidation returns the code the member is validating with
+func (m *Member) PendingValidation(now time.Time) (string, error) {
+	if m.ValidationCode == "" || m.ValidationHandle == "" {
+		return "", ErrNoValidation
+	}
+
+	if m.ValidationExpiresAt == nil || now.After(*m.ValidationExpiresAt) {
+		return "", ErrValidationExpired
+	}
+
+	return m.ValidationCode, nil
+}
+
+// CompleteValidation marks the handle the code was for as the member's verified RSI handle
+func (m *Member) CompleteValidation(now time.Time) {
+	m.Validated = true
+	m.ValidatedAt = &now
+	m.ValidationCheckedAt = &now
+	m.RSIHandle = m.ValidationHandle
+
+	m.ValidationCode = ""
+	m.ValidationHandle = ""
+	m.ValidationExpiresAt = nil
+	m.ValidationAttempts = nil
+}
+
+// RevokeValidation is used when the verified handle no longer exists on RSI
+func (m *Member) RevokeValidation() {
+	m.Validated = false
+	m.ValidatedAt = nil
+	m.ValidationCheckedAt = nil
+}
+
+// ValidationRecheckDue reports if the verified handle hasn't been checked within FEATURES.VERIFY.RECHECK_INTERVAL
+func (m *Member) ValidationRecheckDue(now time.Time) bool {
+	if !m.Validated || m.RSIHandle == "" {
+		return false
+	}
+
+	if m.ValidationCheckedAt == nil {
+		return true
+	}
+
+	return now.Sub(*m.ValidationCheckedAt) >= settings.GetDurationWithDefault("FEATURES.VERIFY.RECHECK_INTERVAL", 7*24*time.Hour)
+}
+
+// Handle is the RSI handle used for RSI lookups. Members without one fall back to the name from their nickname
+func (m *Member) Handle() string {
+	if m.RSIHandle != "" {
+		return m.RSIHandle
+	}
+	return m.Name
+}
+
+// SetRSIHandle changes the member's RSI handle. Verification is for a handle, so changing it revokes it
+func (m *Member) SetRSIHandle(handle string) {
+	handle = strings.TrimSpace(handle)
+	if m.RSIHandle != "" && !strings.EqualFold(m.RSIHandle, handle) {
+		m.RevokeValidation()
+	}
+	m.RSIHandle = handle
+}
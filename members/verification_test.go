@@ -0,0 +1,98 @@
+package members
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sol-armada/sol-bot/settings"
+)
+
+func TestValidationAttempts(t *testing.T) {
+	settings.Set("FEATURES.VERIFY.MAX_ATTEMPTS", 2)
+	settings.Set("FEATURES.VERIFY.ATTEMPT_WINDOW", "1h")
+	defer settings.Set("FEATURES.VERIFY.MAX_ATTEMPTS", nil)
+	defer settings.Set("FEATURES.VERIFY.ATTEMPT_WINDOW", nil)
+
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	m := &Member{ValidationAttempts: []time.Time{now.Add(-2 * time.Hour)}}
+
+	for range 2 {
+		if _, err := m.AllowValidationAttempt(now); err != nil {
+			t.Fatalf("expected attempt to be allowed, got %v", err)
+		}
+	}
+
+	retryAt, err := m.AllowValidationAttempt(now.Add(time.Minute))
+	if !errors.Is(err, ErrTooManyValidations) {
+		t.Fatalf("expected ErrTooManyValidations, got %v", err)
+	}
+	if !retryAt.Equal(now.Add(time.Hour)) {
+		t.Errorf("retry at %s, want %s", retryAt, now.Add(time.Hour))
+	}
+	if len(m.ValidationAttempts) != 2 {
+		t.Errorf("expected old attempts to be dropped, got %d attempts", len(m.ValidationAttempts))
+	}
+
+	if _, err := m.AllowValidationAttempt(now.Add(time.Hour)); err != nil {
+		t.Errorf("expected attempt to be allowed after the window, got %v", err)
+	}
+}
+
+func TestValidation(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	m := &Member{Name: "SomePilot"}
+
+	if _, err := m.PendingValidation(now); !errors.Is(err, ErrNoValidation) {
+		t.Errorf("expected ErrNoValidation, got %v", err)
+	}
+
+	code := m.StartValidation(" Some.Pilot ", now)
+	if got, err := m.PendingValidation(now.Add(time.Minute)); err != nil || got != code {
+		t.Errorf("PendingValidation() = %q, %v, want %q", got, err, code)
+	}
+	if _, err := m.PendingValidation(now.Add(31 * time.Minute)); !errors.Is(err, ErrValidationExpired) {
+		t.Errorf("expected ErrValidationExpired, got %v", err)
+	}
+
+	m.CompleteValidation(now)
+	if !m.Validated || m.RSIHandle != "Some.Pilot" || m.ValidationCode != "" || m.ValidationExpiresAt != nil {
+		t.Errorf("unexpected member after validation %+v", m)
+	}
+
+	if m.ValidationRecheckDue(now.Add(24 * time.Hour)) {
+		t.Error("expected no recheck a day after validating")
+	}
+	if !m.ValidationRecheckDue(now.Add(8 * 24 * time.Hour)) {
+		t.Error("expected a recheck a week after validating")
+	}
+
+	m.RevokeValidation()
+	if m.Validated || m.ValidationRecheckDue(now.Add(8*24*time.Hour)) {
+		t.Error("expected revoked member to not be validated")
+	}
+}
+
+func TestSetRSIHandle(t *testing.T) {
+	now := time.Now()
+	m := &Member{Name: "SomePilot"}
+	if m.Handle() != "SomePilot" {
+		t.Errorf("Handle() = %q, expected the name without an rsi handle", m.Handle())
+	}
+
+	m.SetRSIHandle("Some.Pilot")
+	m.Validated, m.ValidatedAt = true, &now
+	if m.Handle() != "Some.Pilot" {
+		t.Errorf("Handle() = %q, want Some.Pilot", m.Handle())
+	}
+
+	m.SetRSIHandle("some.pilot ")
+	if !m.Validated || m.RSIHandle != "some.pilot" {
+		t.Errorf("changing the case of the handle should keep validation, got %+v", m)
+	}
+
+	m.SetRSIHandle("OtherPilot")
+	if m.Validated {
+		t.Error("changing the handle should revoke validation")
+	}
+}
@@ -0,0 +1,14 @@
+package migrations
+
+// migrations must stay ordered by version and versions must never be reused
+var migrations = []Migration{
+	createCollections,
+	createIndexes,
+	normalizeAttendanceStatus,
+	backfillMemberLegacyFields,
+	createTokenHoldIndexes,
+	markRafflesSettled,
+	createRSICacheTTLIndex,
+	seedAllies,
+	backfillRSIHandles,
+}
@@ -0,0 +1,162 @@
+package migrations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const SCHEMA_MIGRATIONS = "schema_migrations"
+
+// Migration is a single versioned change to the database
+type Migration struct {
+	Version     int
+	Description string
+	Up          func(ctx context.Context, db *mongo.Database) error
+	Down        func(ctx context.Context, db *mongo.Database) error
+}
+
+// Status is a migration and whether it has been applied
+type Status struct {
+	Migration
+	Applied   bool
+	AppliedAt time.Time
+}
+
+type record struct {
+	Version     int       `bson:"_id"`
+	Description string    `bson:"description"`
+	AppliedAt   time.Time `bson:"applied_at"`
+}
+
+var (
+	ErrNothingToRollback = errors.New("no applied migrations to roll back")
+	ErrUnknownMigration  = errors.New("applied migration is not known to this version")
+)
+
+type Migrator struct {
+	db         *mongo.Database
+	migrations []Migration
+}
+
+func New(db *mongo.Database) *Migrator {
+	return &Migrator{db: db, migrations: migrations}
+}
+
+// Status returns every known migration in order along with whether it has been applied
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := Status{Migration: migration}
+		if r, ok := applied[migration.Version]; ok {
+			status.Applied = true
+			status.AppliedAt = r.AppliedAt
+		}
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
+
+// Up applies all pending migrations in order and returns the ones it applied
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ran := []Migration{}
+	for _, migration := range m.migrations {
+		if _, ok := applied[migration.Version]; ok {
+			continue
+		}
+
+		if err := migration.Up(ctx, m.db); err != nil {
+			return ran, fmt.Errorf("applying migration %d (%s): %w", migration.Version, migration.Description, err)
+		}
+
+		if _, err := m.collection().InsertOne(ctx, record{
+			Version:     migration.Version,
+			Description: migration.Description,
+			AppliedAt:   time.Now().UTC(),
+		}); err != nil {
+			return ran, fmt.Errorf("recording migration %d: %w", migration.Version, err)
+		}
+
+		ran = append(ran, migration)
+	}
+
+	return ran, nil
+}
+
+// Down rolls back the most recently applied migration
+func (m *Migrator) Down(ctx context.Context) (*Migration, error) {
+	var latest record
+	opts := options.FindOne().SetSort(bson.D{{Key: "_id", Value: -1}})
+	if err := m.collection().FindOne(ctx, bson.D{}, opts).Decode(&latest); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrNothingToRollback
+		}
+		return nil, err
+	}
+
+	var migration *Migration
+	for i := range m.migrations {
+		if m.migrations[i].Version == latest.Version {
+			migration = &m.migrations[i]
+			break
+		}
+	}
+	if migration == nil {
+		return nil, fmt.Errorf("%w: %d", ErrUnknownMigration, latest.Version)
+	}
+
+	if err := migration.Down(ctx, m.db); err != nil {
+		return nil, fmt.Errorf("rolling back migration %d (%s): %w", migration.Version, migration.Description, err)
+	}
+
+	if _, err := m.collection().DeleteOne(ctx, bson.D{{Key: "_id", Value: migration.Version}}); err != nil {
+		return nil, fmt.Errorf("removing migration record %d: %w", migration.Version, err)
+	}
+
+	return migration, nil
+}
+
+func (m *Migrator) applied(ctx context.Context) (map[int]record, error) {
+	cur, err := m.collection().Find(ctx, bson.D{})
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	var records []record
+	if err := cur.All(ctx, &records); err != nil {
+		return nil, err
+	}
+
+	applied := make(map[int]record, len(records))
+	for _, r := range records {
+		applied[r.Version] = r
+	}
+
+	return applied, nil
+}
+
+func (m *Migrator) collection() *mongo.Collection {
+	return m.db.Collection(SCHEMA_MIGRATIONS)
+}
+
+// noop is used as the Down of migrations that only fill in or normalize data
+func noop(context.Context, *mongo.Database) error {
+	return nil
+}
@@ -0,0 +1,54 @@
+package migrations
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/sol-armada/sol-bot/members"
+)
+
+func TestMigrationsOrdered(t *testing.T) {
+	for i, m := range migrations {
+		if m.Up == nil || m.Down == nil {
+			t.Errorf("migration %d is missing up or down", m.Version)
+		}
+		if i > 0 && m.Version <= migrations[i-1].Version {
+			t.Errorf("migration %d is out of order after %d", m.Version, migrations[i-1].Version)
+		}
+	}
+}
+
+func TestParseGameplay(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []members.GameplayType
+	}{
+		{in: "Mining, Hauling and ship combat", want: []members.GameplayType{members.Mining, members.Hauling, members.ShipCombat}},
+		{in: "mining/mining", want: []members.GameplayType{members.Mining}},
+		{in: "a bit of everything", want: []members.GameplayType{}},
+	}
+	for _, tt := range tests {
+		if got := parseGameplay(tt.in); !slices.Equal(got, tt.want) {
+			t.Errorf("parseGameplay(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestLeadingInt(t *testing.T) {
+	tests := []struct {
+		in     string
+		want   int
+		wantOk bool
+	}{
+		{in: "25", want: 25, wantOk: true},
+		{in: " 3 years", want: 3, wantOk: true},
+		{in: "since 2015", wantOk: false},
+		{in: "", wantOk: false},
+	}
+	for _, tt := range tests {
+		got, ok := leadingInt(tt.in)
+		if got != tt.want || ok != tt.wantOk {
+			t.Errorf("leadingInt(%q) = %d, %v, want %d, %v", tt.in, got, ok, tt.want, tt.wantOk)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/sol-armada/sol-bot/stores"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// createCollections creates any missing collections with the options the stores expect.
+// Existing collections are left alone so this is safe against databases created by the stores
+var createCollections = Migration{
+	Version:     1,
+	Description: "create collections",
+	Up: func(ctx context.Context, db *mongo.Database) error {
+		existing, err := db.ListCollectionNames(ctx, bson.D{})
+		if err != nil {
+			return err
+		}
+
+		timeSeries := func(timeField, metaField string) *options.CreateCollectionOptions {
+			return options.CreateCollection().SetTimeSeriesOptions(
+				options.TimeSeries().SetTimeField(timeField).SetMetaField(metaField),
+			)
+		}
+
+		collections := []struct {
+			name stores.Collection
+			opts *options.CreateCollectionOptions
+		}{
+			{name: stores.MEMBERS},
+			{name: stores.ATTENDANCE},
+			{name: stores.CONFIGS},
+			{name: stores.ACTIVITY, opts: timeSeries("when", "meta")},
+			{name: stores.SOS},
+			{name: stores.TOKENS, opts: timeSeries("created_at", "member_id")},
+			{name: stores.RAFFLES},
+			{name: stores.KANBAN},
+			{name: stores.COMMANDS, opts: timeSeries("when", "meta")},
+			{name: stores.GIVEAWAYS},
+			{name: stores.BLUEPRINT},
+		}
+
+		for _, c := range collections {
+			if slices.Contains(existing, string(c.name)) {
+				continue
+			}
+
+			opts := []*options.CreateCollectionOptions{}
+			if c.opts != nil {
+				opts = append(opts, c.opts)
+			}
+
+			if err := db.CreateCollection(ctx, string(c.name), opts...); err != nil {
+				return fmt.Errorf("creating %s: %w", c.name, err)
+			}
+		}
+
+		return nil
+	},
+	// collections hold data, so they are never dropped
+	Down: noop,
+}
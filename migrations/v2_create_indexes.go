@@ -0,0 +1,56 @@
+package migrations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/sol-armada/sol-bot/stores"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var indexes = []struct {
+	collection stores.Collection
+	name       string
+	keys       bson.D
+}{
+	{collection: stores.TOKENS, name: "member_id", keys: bson.D{{Key: "member_id", Value: 1}}},
+	{collection: stores.TOKENS, name: "attendance_id", keys: bson.D{{Key: "attendance_id", Value: 1}}},
+	{collection: stores.ATTENDANCE, name: "members", keys: bson.D{{Key: "members", Value: 1}}},
+	{collection: stores.ATTENDANCE, name: "date_created", keys: bson.D{{Key: "date_created", Value: -1}}},
+	{collection: stores.MEMBERS, name: "name", keys: bson.D{{Key: "name", Value: 1}}},
+	{collection: stores.RAFFLES, name: "createdat", keys: bson.D{{Key: "createdat", Value: -1}}},
+}
+
+var createIndexes = Migration{
+	Version:     2,
+	Description: "create indexes",
+	Up: func(ctx context.Context, db *mongo.Database) error {
+		for _, index := range indexes {
+			if _, err := db.Collection(string(index.collection)).Indexes().CreateOne(ctx, mongo.IndexModel{
+				Keys:    index.keys,
+				Options: options.Index().SetName(index.name),
+			}); err != nil {
+				return fmt.Errorf("creating index %s on %s: %w", index.name, index.collection, err)
+			}
+		}
+
+		return nil
+	},
+	Down: func(ctx context.Context, db *mongo.Database) error {
+		for _, index := range indexes {
+			if _, err := db.Collection(string(index.collection)).Indexes().DropOne(ctx, index.name); err != nil {
+				var cmdErr mongo.CommandError
+				// IndexNotFound
+				if errors.As(err, &cmdErr) && cmdErr.Code == 27 {
+					continue
+				}
+				return fmt.Errorf("dropping index %s on %s: %w", index.name, index.collection, err)
+			}
+		}
+
+		return nil
+	},
+}
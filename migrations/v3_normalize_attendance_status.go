@@ -0,0 +1,71 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/sol-armada/sol-bot/stores"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// normalizeAttendanceStatus makes status the source of truth for attendance records.
+// Older records only have the recorded flag, and some newer ones disagree with it
+var normalizeAttendanceStatus = Migration{
+	Version:     3,
+	Description: "normalize attendance recorded and status",
+	Up: func(ctx context.Context, db *mongo.Database) error {
+		c := db.Collection(string(stores.ATTENDANCE))
+
+		// fill in missing statuses from the legacy flags
+		if _, err := c.UpdateMany(ctx,
+			bson.D{{Key: "$or", Value: bson.A{
+				bson.D{{Key: "status", Value: bson.D{{Key: "$exists", Value: false}}}},
+				bson.D{{Key: "status", Value: nil}},
+				bson.D{{Key: "status", Value: ""}},
+			}}},
+			bson.A{
+				bson.D{{Key: "$set", Value: bson.D{
+					{Key: "status", Value: bson.D{{Key: "$switch", Value: bson.D{
+						{Key: "branches", Value: bson.A{
+							bson.D{
+								{Key: "case", Value: bson.D{{Key: "$eq", Value: bson.A{"$recorded", true}}}},
+								{Key: "then", Value: "recorded"},
+							},
+							bson.D{
+								{Key: "case", Value: bson.D{{Key: "$eq", Value: bson.A{"$active", true}}}},
+								{Key: "then", Value: "active"},
+							},
+						}},
+						{Key: "default", Value: "created"},
+					}}}},
+				}}},
+			},
+		); err != nil {
+			return err
+		}
+
+		// make the recorded flag agree with the status
+		if _, err := c.UpdateMany(ctx,
+			bson.D{
+				{Key: "status", Value: "recorded"},
+				{Key: "recorded", Value: bson.D{{Key: "$ne", Value: true}}},
+			},
+			bson.D{{Key: "$set", Value: bson.D{{Key: "recorded", Value: true}}}},
+		); err != nil {
+			return err
+		}
+
+		if _, err := c.UpdateMany(ctx,
+			bson.D{
+				{Key: "status", Value: bson.D{{Key: "$ne", Value: "recorded"}}},
+				{Key: "recorded", Value: true},
+			},
+			bson.D{{Key: "$set", Value: bson.D{{Key: "recorded", Value: false}}}},
+		); err != nil {
+			return err
+		}
+
+		return nil
+	},
+	Down: noop,
+}
@@ -0,0 +1,144 @@
+package migrations
+
+import (
+	"context"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/sol-armada/sol-bot/members"
+	"github.com/sol-armada/sol-bot/stores"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// backfillMemberLegacyFields fills the structured onboarding fields from the free text Legacy* fields.
+// Structured fields that are already set win, and the legacy values are kept as they are
+var backfillMemberLegacyFields = Migration{
+	Version:     4,
+	Description: "backfill member onboarding fields from legacy fields",
+	Up: func(ctx context.Context, db *mongo.Database) error {
+		c := db.Collection(string(stores.MEMBERS))
+
+		cur, err := c.Find(ctx, bson.D{{Key: "$or", Value: bson.A{
+			bson.D{{Key: "legacy_age", Value: bson.D{{Key: "$nin", Value: bson.A{nil, ""}}}}},
+			bson.D{{Key: "legacy_playtime", Value: bson.D{{Key: "$nin", Value: bson.A{nil, ""}}}}},
+			bson.D{{Key: "legacy_gameplay", Value: bson.D{{Key: "$nin", Value: bson.A{nil, ""}}}}},
+			bson.D{{Key: "legacy_recruiter", Value: bson.D{{Key: "$nin", Value: bson.A{nil, ""}}}}},
+			bson.D{{Key: "legacy_other", Value: bson.D{{Key: "$nin", Value: bson.A{nil, ""}}}}},
+		}}})
+		if err != nil {
+			return err
+		}
+		defer cur.Close(ctx)
+
+		for cur.Next(ctx) {
+			var doc struct {
+				Id              string   `bson:"_id"`
+				Age             int      `bson:"age"`
+				Playtime        int      `bson:"playtime"`
+				Gameplay        []string `bson:"gameplay"`
+				Recruiter       *string  `bson:"recruiter"`
+				Other           string   `bson:"other"`
+				LegacyAge       string   `bson:"legacy_age"`
+				LegacyPlaytime  string   `bson:"legacy_playtime"`
+				LegacyGameplay  string   `bson:"legacy_gameplay"`
+				LegacyRecruiter string   `bson:"legacy_recruiter"`
+				LegacyOther     string   `bson:"legacy_other"`
+			}
+			if err := cur.Decode(&doc); err != nil {
+				return err
+			}
+
+			set := bson.D{}
+
+			if age, ok := leadingInt(doc.LegacyAge); ok && doc.Age == 0 {
+				set = append(set, bson.E{Key: "age", Value: age})
+			}
+
+			if playtime, ok := leadingInt(doc.LegacyPlaytime); ok && doc.Playtime == 0 {
+				set = append(set, bson.E{Key: "playtime", Value: playtime})
+			}
+
+			if gameplay := parseGameplay(doc.LegacyGameplay); len(gameplay) > 0 && len(doc.Gameplay) == 0 {
+				set = append(set, bson.E{Key: "gameplay", Value: gameplay})
+			}
+
+			if doc.LegacyRecruiter != "" && (doc.Recruiter == nil || *doc.Recruiter == "") {
+				recruiterId, err := findMemberIdByName(ctx, c, doc.LegacyRecruiter)
+				if err != nil {
+					return err
+				}
+				if recruiterId != "" && recruiterId != doc.Id {
+					set = append(set, bson.E{Key: "recruiter", Value: recruiterId})
+				}
+			}
+
+			if doc.LegacyOther != "" && doc.Other == "" {
+				set = append(set, bson.E{Key: "other", Value: doc.LegacyOther})
+			}
+
+			if len(set) == 0 {
+				continue
+			}
+
+			if _, err := c.UpdateByID(ctx, doc.Id, bson.D{{Key: "$set", Value: set}}); err != nil {
+				return err
+			}
+		}
+
+		return cur.Err()
+	},
+	Down: noop,
+}
+
+var leadingDigits = regexp.MustCompile(`^\s*(\d+)`)
+
+func leadingInt(s string) (int, bool) {
+	match := leadingDigits.FindStringSubmatch(s)
+	if match == nil {
+		return 0, false
+	}
+
+	i, err := strconv.Atoi(match[1])
+	if err != nil || i == 0 {
+		return 0, false
+	}
+
+	return i, true
+}
+
+var gameplaySeparators = regexp.MustCompile(`\s*(?:,|/|&|\band\b|\n)\s*`)
+
+func parseGameplay(s string) []members.GameplayType {
+	gameplay := []members.GameplayType{}
+	for _, part := range gameplaySeparators.Split(strings.ToLower(s), -1) {
+		g := members.ToGameplayType(strings.ReplaceAll(strings.TrimSpace(part), " ", "_"))
+		if g == members.Unknown || slices.Contains(gameplay, g) {
+			continue
+		}
+		gameplay = append(gameplay, g)
+	}
+	return gameplay
+}
+
+func findMemberIdByName(ctx context.Context, c *mongo.Collection, name string) (string, error) {
+	var result struct {
+		Id string `bson:"_id"`
+	}
+
+	name = strings.TrimPrefix(strings.TrimSpace(name), "@")
+	opts := options.FindOne().
+		SetProjection(bson.D{{Key: "_id", Value: 1}}).
+		SetCollation(&options.Collation{Locale: "en", Strength: 2})
+	if err := c.FindOne(ctx, bson.D{{Key: "name", Value: name}}, opts).Decode(&result); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return "", nil
+		}
+		return "", err
+	}
+
+	return result.Id, nil
+}
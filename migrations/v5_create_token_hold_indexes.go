@@ -0,0 +1,51 @@
+package migrations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/sol-armada/sol-bot/stores"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var tokenHoldIndexes = []struct {
+	name string
+	keys bson.D
+}{
+	{name: "status_member_id", keys: bson.D{{Key: "status", Value: 1}, {Key: "member_id", Value: 1}}},
+	{name: "reference", keys: bson.D{{Key: "reference", Value: 1}}},
+}
+
+var createTokenHoldIndexes = Migration{
+	Version:     5,
+	Description: "create token hold indexes",
+	Up: func(ctx context.Context, db *mongo.Database) error {
+		for _, index := range tokenHoldIndexes {
+			if _, err := db.Collection(string(stores.TOKEN_HOLDS)).Indexes().CreateOne(ctx, mongo.IndexModel{
+				Keys:    index.keys,
+				Options: options.Index().SetName(index.name),
+			}); err != nil {
+				return fmt.Errorf("creating index %s on %s: %w", index.name, stores.TOKEN_HOLDS, err)
+			}
+		}
+
+		return nil
+	},
+	Down: func(ctx context.Context, db *mongo.Database) error {
+		for _, index := range tokenHoldIndexes {
+			if _, err := db.Collection(string(stores.TOKEN_HOLDS)).Indexes().DropOne(ctx, index.name); err != nil {
+				var cmdErr mongo.CommandError
+				// IndexNotFound
+				if errors.As(err, &cmdErr) && cmdErr.Code == 27 {
+					continue
+				}
+				return fmt.Errorf("dropping index %s on %s: %w", index.name, stores.TOKEN_HOLDS, err)
+			}
+		}
+
+		return nil
+	},
+}
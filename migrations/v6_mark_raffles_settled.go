@@ -0,0 +1,56 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sol-armada/sol-bot/stores"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// markRafflesSettled marks raffles that ended before settling was tracked. Raffles still holding tokens
+// stopped partway through settling, so they are left for the next finish to settle
+var markRafflesSettled = Migration{
+	Version:     6,
+	Description: "mark ended raffles without active holds settled",
+	Up: func(ctx context.Context, db *mongo.Database) error {
+		cur, err := db.Collection(string(stores.RAFFLES)).Find(ctx, bson.D{
+			{Key: "ended", Value: true},
+			{Key: "settled", Value: bson.D{{Key: "$ne", Value: true}}},
+		})
+		if err != nil {
+			return fmt.Errorf("finding ended raffles: %w", err)
+		}
+		defer cur.Close(ctx)
+
+		for cur.Next(ctx) {
+			var raffle struct {
+				Id string `bson:"_id"`
+			}
+			if err := cur.Decode(&raffle); err != nil {
+				return fmt.Errorf("decoding raffle: %w", err)
+			}
+
+			active, err := db.Collection(string(stores.TOKEN_HOLDS)).CountDocuments(ctx, bson.D{
+				{Key: "reference", Value: "raffle:" + raffle.Id},
+				{Key: "status", Value: "active"},
+			})
+			if err != nil {
+				return fmt.Errorf("counting holds for raffle %s: %w", raffle.Id, err)
+			}
+			if active > 0 {
+				continue
+			}
+
+			if _, err := db.Collection(string(stores.RAFFLES)).UpdateByID(ctx, raffle.Id, bson.D{
+				{Key: "$set", Value: bson.D{{Key: "settled", Value: true}}},
+			}); err != nil {
+				return fmt.Errorf("marking raffle %s settled: %w", raffle.Id, err)
+			}
+		}
+
+		return cur.Err()
+	},
+	Down: noop,
+}
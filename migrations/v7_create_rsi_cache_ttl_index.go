@@ -0,0 +1,41 @@
+package migrations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/sol-armada/sol-bot/stores"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const rsiCacheTTLIndex = "expires_at_ttl"
+
+var createRSICacheTTLIndex = Migration{
+	Version:     7,
+	Description: "expire rsi cache entries",
+	Up: func(ctx context.Context, db *mongo.Database) error {
+		if _, err := db.Collection(string(stores.RSI_CACHE)).Indexes().CreateOne(ctx, mongo.IndexModel{
+			Keys:    bson.D{{Key: "expires_at", Value: 1}},
+			Options: options.Index().SetName(rsiCacheTTLIndex).SetExpireAfterSeconds(0),
+		}); err != nil {
+			return fmt.Errorf("creating index %s on %s: %w", rsiCacheTTLIndex, stores.RSI_CACHE, err)
+		}
+
+		return nil
+	},
+	Down: func(ctx context.Context, db *mongo.Database) error {
+		if _, err := db.Collection(string(stores.RSI_CACHE)).Indexes().DropOne(ctx, rsiCacheTTLIndex); err != nil {
+			var cmdErr mongo.CommandError
+			// IndexNotFound
+			if errors.As(err, &cmdErr) && cmdErr.Code == 27 {
+				return nil
+			}
+			return fmt.Errorf("dropping index %s on %s: %w", rsiCacheTTLIndex, stores.RSI_CACHE, err)
+		}
+
+		return nil
+	},
+}
@@ -0,0 +1,57 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sol-armada/sol-bot/settings"
+	"github.com/sol-armada/sol-bot/stores"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// seededBy marks allies that came from the ALLIES setting so Down only removes those
+const seededBy = "settings"
+
+var seedAllies = Migration{
+	Version:     8,
+	Description: "seed ally registry from the allies setting",
+	Up: func(ctx context.Context, db *mongo.Database) error {
+		coll := db.Collection(string(stores.ALLIES))
+		now := time.Now().UTC()
+
+		for _, sid := range settings.GetStringSlice("ALLIES") {
+			sid = strings.ToUpper(strings.TrimSpace(sid))
+			if sid == "" {
+				continue
+			}
+
+			// never overwrite an ally officers already set up
+			if _, err := coll.UpdateOne(ctx,
+				bson.D{{Key: "_id", Value: sid}},
+				bson.D{{Key: "$setOnInsert", Value: bson.D{
+					{Key: "contact_id", Value: ""},
+					{Key: "notes", Value: ""},
+					{Key: "added_by", Value: seededBy},
+					{Key: "created_at", Value: now},
+					{Key: "updated_at", Value: now},
+				}}},
+				options.Update().SetUpsert(true),
+			); err != nil {
+				return fmt.Errorf("seeding ally %s: %w", sid, err)
+			}
+		}
+
+		return nil
+	},
+	Down: func(ctx context.Context, db *mongo.Database) error {
+		if _, err := db.Collection(string(stores.ALLIES)).DeleteMany(ctx, bson.D{{Key: "added_by", Value: seededBy}}); err != nil {
+			return fmt.Errorf("removing seeded allies: %w", err)
+		}
+
+		return nil
+	},
+}
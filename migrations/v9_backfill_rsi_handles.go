@@ -0,0 +1,32 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sol-armada/sol-bot/stores"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// backfillRSIHandles copies the name of validated members into their RSI handle. Validation checked the bio
+// of the page at their name, so it is known to be their handle
+var backfillRSIHandles = Migration{
+	Version:     9,
+	Description: "backfill rsi handles from validated names",
+	Up: func(ctx context.Context, db *mongo.Database) error {
+		if _, err := db.Collection(string(stores.MEMBERS)).UpdateMany(ctx,
+			bson.D{
+				{Key: "validated", Value: true},
+				{Key: "name", Value: bson.D{{Key: "$nin", Value: bson.A{nil, ""}}}},
+				{Key: "rsi_handle", Value: bson.D{{Key: "$in", Value: bson.A{nil, ""}}}},
+			},
+			bson.A{bson.D{{Key: "$set", Value: bson.D{{Key: "rsi_handle", Value: "$name"}}}}},
+		); err != nil {
+			return fmt.Errorf("backfilling rsi handles: %w", err)
+		}
+
+		return nil
+	},
+	Down: noop,
+}
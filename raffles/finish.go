@@ -0,0 +1,71 @@
+package raffles
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// drawMu stops the End button and the scheduler from drawing the same raffle twice
+var drawMu sync.Mutex
+
+// Finish draws the winners, settles the held tokens and announces the result in the raffle's channel.
+// Finishing a raffle that already settled does nothing
+func (r *Raffle) Finish(s *discordgo.Session) error {
+	drawMu.Lock()
+	defer drawMu.Unlock()
+
+	stored, err := Get(r.Id)
+	if err != nil {
+		return err
+	}
+	*r = *stored
+
+	if r.Ended && r.Settled {
+		return nil
+	}
+
+	// a raffle that ended without settling is retried from the settle
+	if !r.Ended {
+		if _, err := r.PickWinner(); err != nil {
+			if !errors.Is(err, ErrNoEntries) {
+				return err
+			}
+
+			r.Ended = true
+			if err := r.Save(); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := r.Settle(); err != nil {
+		return err
+	}
+
+	if len(r.Winners) == 0 {
+		return r.UpdateMessage(s)
+	}
+
+	var winnerNames strings.Builder
+	for j, winnerId := range r.Winners {
+		winnerNames.WriteString("<@")
+		winnerNames.WriteString(winnerId)
+		winnerNames.WriteString(">")
+		if j < len(r.Winners)-1 {
+			if j == len(r.Winners)-2 {
+				winnerNames.WriteString(" and ")
+			}
+			winnerNames.WriteString(", ")
+		}
+	}
+
+	if _, err := s.ChannelMessageSend(r.ChannelId, fmt.Sprintf("🎊 Congratulations to %s! They have won the raffle! 🎊", winnerNames.String())); err != nil {
+		return err
+	}
+
+	return r.UpdateMessage(s)
+}
@@ -56,7 +56,7 @@ func (r *Raffle) GetEmbed() (*discordgo.MessageEmbed, error) {
 			})
 		}
 
-		tokenAmount, err := tokens.GetBalanceByMemberId(memberId)
+		tokenAmount, err := tokens.GetAvailableBalanceByMemberId(memberId)
 		if err != nil {
 			return nil, err
 		}
@@ -106,6 +106,38 @@ func (r *Raffle) GetEmbed() (*discordgo.MessageEmbed, error) {
 		})
 	}
 
+	if r.Scheduled() && !r.Ended {
+		feilds = append(feilds, &discordgo.MessageEmbedField{
+			Name:   "Ends",
+			Value:  fmt.Sprintf("<t:%d:R> (<t:%d:f>)", r.EndTime.Unix(), r.EndTime.Unix()),
+			Inline: false,
+		})
+	}
+
+	if rules := r.Rules.Describe(); len(rules) > 0 {
+		feilds = append(feilds, &discordgo.MessageEmbedField{
+			Name:   "Rules",
+			Value:  strings.Join(rules, "\n"),
+			Inline: false,
+		})
+	}
+
+	if r.Ended && r.Seed != "" {
+		feilds = append(feilds, &discordgo.MessageEmbedField{
+			Name:   "Draw Seed",
+			Value:  "`" + r.Seed + "`",
+			Inline: false,
+		})
+	}
+
+	if r.SeedHash != "" {
+		feilds = append(feilds, &discordgo.MessageEmbedField{
+			Name:   "Draw Commitment",
+			Value:  "`" + r.SeedHash + "`",
+			Inline: false,
+		})
+	}
+
 	title := r.Name + " Raffle"
 	if r.Test {
 		title = "[TEST] " + title
@@ -116,10 +148,12 @@ func (r *Raffle) GetEmbed() (*discordgo.MessageEmbed, error) {
 		Fields: feilds,
 	}
 
+	footer := "Raffle ID: " + r.Id
 	if r.AttedanceId != "" {
-		embed.Footer = &discordgo.MessageEmbedFooter{
-			Text: "Attendance ID: " + r.AttedanceId,
-		}
+		footer += " | Attendance ID: " + r.AttedanceId
+	}
+	embed.Footer = &discordgo.MessageEmbedFooter{
+		Text: footer,
 	}
 
 	return embed, nil
@@ -0,0 +1,47 @@
+package raffles
+
+import (
+	"slices"
+
+	"github.com/sol-armada/sol-bot/tokens"
+)
+
+// History returns raffles matching the filter, newest first. Test raffles are never included
+func History(filter ListFilter, limit int) ([]*Raffle, error) {
+	filter.ExcludeTest = true
+	return rafflesStore.List(filter, limit)
+}
+
+// MemberSummary is a member's raffle record, not counting test raffles
+type MemberSummary struct {
+	Entries     int
+	Wins        int
+	TokensSpent int
+}
+
+func GetMemberSummary(memberId string) (*MemberSummary, error) {
+	entered, err := History(ListFilter{EndedOnly: true, Entrant: memberId}, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &MemberSummary{Entries: len(entered)}
+	for _, raffle := range entered {
+		if slices.Contains(raffle.Winners, memberId) {
+			summary.Wins++
+		}
+	}
+
+	records, err := tokens.GetByMemberId(memberId)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, record := range records {
+		if record.Reason == tokens.ReasonWonRaffle {
+			summary.TokensSpent -= record.Amount
+		}
+	}
+
+	return summary, nil
+}
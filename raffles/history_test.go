@@ -0,0 +1,61 @@
+package raffles
+
+import (
+	"testing"
+
+	"github.com/sol-armada/sol-bot/tokens"
+)
+
+func TestHistoryAndMemberSummary(t *testing.T) {
+	setupStores(t, "a", "b")
+	if err := tokens.Setup(tokens.NewMemoryStore()); err != nil {
+		t.Fatal(err)
+	}
+
+	raffles := []*Raffle{
+		{Id: "1", Prize: "Cutlass Black", Tickets: map[string]int{"a": 2, "b": 1}, Winners: []string{"a"}, Ended: true},
+		{Id: "2", Prize: "Pickles", AttedanceId: "event", Tickets: map[string]int{"a": 1, "b": 3}, Winners: []string{"b"}, Ended: true},
+		{Id: "3", Prize: "Cutlass Red", Tickets: map[string]int{"a": 5}, Winners: []string{"a"}, Ended: true, Test: true},
+	}
+	for _, r := range raffles {
+		if err := r.Save(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name   string
+		filter ListFilter
+		want   int
+	}{
+		{name: "all", filter: ListFilter{}, want: 2},
+		{name: "prize ignores case", filter: ListFilter{Prize: "cutlass"}, want: 1},
+		{name: "attendance", filter: ListFilter{AttendanceId: "event"}, want: 1},
+		{name: "winner", filter: ListFilter{Winner: "a"}, want: 1},
+		{name: "entrant", filter: ListFilter{Entrant: "b"}, want: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := History(tt.filter, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != tt.want {
+				t.Errorf("History() returned %d raffles, want %d", len(got), tt.want)
+			}
+		})
+	}
+
+	if err := tokens.New("a", -2, tokens.ReasonWonRaffle, nil, nil, nil).Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	summary, err := GetMemberSummary("a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary.Entries != 2 || summary.Wins != 1 || summary.TokensSpent != 2 {
+		t.Errorf("unexpected summary %+v", summary)
+	}
+}
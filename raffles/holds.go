@@ -0,0 +1,90 @@
+package raffles
+
+import (
+	"errors"
+
+	"github.com/sol-armada/sol-bot/tokens"
+)
+
+// HoldReference is the reference entries hold tokens against
+func (r *Raffle) HoldReference() string {
+	return "raffle:" + r.Id
+}
+
+// Enter sets the member's tickets, holding that many tokens until the raffle is settled.
+// Returns ErrTooManyTickets over the rules' cap and tokens.ErrInsufficientTokens if the member can't cover the tickets
+func (r *Raffle) Enter(memberId string, amount int) error {
+	if r.Rules.MaxTickets > 0 && amount > r.Rules.MaxTickets {
+		return ErrTooManyTickets
+	}
+
+	previous, entered := r.Tickets[memberId]
+	if _, err := tokens.PlaceHold(memberId, r.HoldReference(), amount); err != nil {
+		return err
+	}
+
+	if err := r.AddTicket(memberId, amount).Save(); err != nil {
+		// put the hold back to what the stored tickets cover so the tokens aren't left locked
+		if !entered {
+			r.RemoveTicket(memberId)
+			return errors.Join(err, tokens.ReleaseHold(memberId, r.HoldReference()))
+		}
+
+		r.AddTicket(memberId, previous)
+		_, holdErr := tokens.PlaceHold(memberId, r.HoldReference(), previous)
+		return errors.Join(err, holdErr)
+	}
+
+	return nil
+}
+
+// Withdraw removes the member's tickets and releases their hold
+func (r *Raffle) Withdraw(memberId string) error {
+	if err := tokens.ReleaseHold(memberId, r.HoldReference()); err != nil {
+		return err
+	}
+
+	return r.RemoveTicket(memberId).Save()
+}
+
+// Settle spends the winners' holds and releases everyone else's, then marks the raffle settled.
+// Test raffles release all holds. Every step can be repeated, so a failed settle is retried by finishing again
+func (r *Raffle) Settle() error {
+	if !r.Test {
+		for _, winnerId := range r.Winners {
+			held, err := tokens.HasHold(winnerId, r.HoldReference())
+			if err != nil {
+				return err
+			}
+
+			if !held {
+				// entered before holds existed, so take the tickets directly
+				if err := tokens.ConsumeUnheld(winnerId, r.HoldReference(), r.Tickets[winnerId], tokens.ReasonWonRaffle, &r.AttedanceId); err != nil {
+					return err
+				}
+				continue
+			}
+
+			// a released hold has nothing left to spend
+			if err := tokens.ConsumeHold(winnerId, r.HoldReference(), tokens.ReasonWonRaffle, &r.AttedanceId); err != nil && !errors.Is(err, tokens.ErrHoldNotFound) {
+				return err
+			}
+		}
+	}
+
+	if err := tokens.ReleaseHolds(r.HoldReference()); err != nil {
+		return err
+	}
+
+	r.Settled = true
+	return r.Save()
+}
+
+// Cancel releases every hold and deletes the raffle
+func (r *Raffle) Cancel() error {
+	if err := tokens.ReleaseHolds(r.HoldReference()); err != nil {
+		return err
+	}
+
+	return r.Delete()
+}
@@ -0,0 +1,99 @@
+package raffles
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sol-armada/sol-bot/tokens"
+)
+
+func TestSettleRetry(t *testing.T) {
+	setupStores(t, "a", "b", "c")
+	if err := tokens.Setup(tokens.NewMemoryStore()); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, id := range []string{"a", "b", "c"} {
+		if err := tokens.New(id, 10, tokens.ReasonOther, nil, nil, nil).Save(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	r := New("test", "", "Ship", false, Rules{})
+	if err := r.Enter("a", 4); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Enter("b", 3); err != nil {
+		t.Fatal(err)
+	}
+	// c entered before holds existed
+	r.AddTicket("c", 2)
+	r.Winners = []string{"a", "c"}
+	r.Ended = true
+
+	// settling again after a failure must not charge anyone twice
+	for range 2 {
+		if err := r.Settle(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	stored, err := Get(r.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !stored.Settled {
+		t.Error("expected the raffle to be settled")
+	}
+
+	want := map[string]int{"a": 6, "b": 10, "c": 8}
+	for id, balance := range want {
+		if got, _ := tokens.GetAvailableBalanceByMemberId(id); got != balance {
+			t.Errorf("%s has %d available, want %d", id, got, balance)
+		}
+	}
+}
+
+// failingStore fails every save
+type failingStore struct {
+	Store
+}
+
+func (failingStore) Save(*Raffle) error {
+	return errors.New("save failed")
+}
+
+func TestEnterSaveFails(t *testing.T) {
+	setupStores(t, "a")
+	if err := tokens.Setup(tokens.NewMemoryStore()); err != nil {
+		t.Fatal(err)
+	}
+	if err := tokens.New("a", 10, tokens.ReasonOther, nil, nil, nil).Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	r := New("test", "", "Ship", false, Rules{})
+	if err := r.Enter("a", 4); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Setup(failingStore{NewMemoryStore()}); err != nil {
+		t.Fatal(err)
+	}
+
+	// the hold goes back to the stored tickets when the save fails
+	if err := r.Enter("a", 6); err == nil {
+		t.Fatal("expected the failed save to be returned")
+	}
+	if held, _ := tokens.GetHeldByMemberId("a"); held != 4 {
+		t.Errorf("expected 4 held after a failed change, got %d", held)
+	}
+
+	r.RemoveTicket("a")
+	if err := r.Enter("a", 6); err == nil {
+		t.Fatal("expected the failed save to be returned")
+	}
+	if held, _ := tokens.GetHeldByMemberId("a"); held != 0 {
+		t.Errorf("expected nothing held after a failed entry, got %d", held)
+	}
+}
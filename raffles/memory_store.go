@@ -0,0 +1,95 @@
+package raffles
+
+import (
+	"maps"
+	"slices"
+	"sync"
+)
+
+type memoryStore struct {
+	mu      sync.RWMutex
+	raffles map[string]*Raffle
+}
+
+var _ Store = (*memoryStore)(nil)
+
+// NewMemoryStore returns a Store that keeps raffles in memory. Used for tests
+func NewMemoryStore() Store {
+	return &memoryStore{raffles: map[string]*Raffle{}}
+}
+
+func (s *memoryStore) Get(id string) (*Raffle, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	raffle, ok := s.raffles[id]
+	if !ok {
+		return nil, ErrRaffleNotFound
+	}
+
+	return cloneRaffle(raffle), nil
+}
+
+func (s *memoryStore) GetLatest() (*Raffle, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var latest *Raffle
+	for _, raffle := range s.raffles {
+		if latest == nil || raffle.CreatedAt.After(latest.CreatedAt) {
+			latest = raffle
+		}
+	}
+
+	if latest == nil {
+		return nil, nil
+	}
+
+	return cloneRaffle(latest), nil
+}
+
+func (s *memoryStore) List(filter ListFilter, limit int) ([]*Raffle, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	raffles := []*Raffle{}
+	for _, raffle := range s.raffles {
+		if filter.matches(raffle) {
+			raffles = append(raffles, cloneRaffle(raffle))
+		}
+	}
+
+	slices.SortFunc(raffles, func(a, b *Raffle) int { return b.CreatedAt.Compare(a.CreatedAt) })
+
+	if limit > 0 && len(raffles) > limit {
+		raffles = raffles[:limit]
+	}
+
+	return raffles, nil
+}
+
+func (s *memoryStore) Save(raffle *Raffle) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.raffles[raffle.Id] = cloneRaffle(raffle)
+	return nil
+}
+
+func (s *memoryStore) Delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.raffles, id)
+	return nil
+}
+
+func cloneRaffle(r *Raffle) *Raffle {
+	c := *r
+	c.Tickets = maps.Clone(r.Tickets)
+	c.Winners = slices.Clone(r.Winners)
+	c.DrawEntries = slices.Clone(r.DrawEntries)
+	c.Reminders = slices.Clone(r.Reminders)
+	c.RemindersSent = slices.Clone(r.RemindersSent)
+	return &c
+}
@@ -0,0 +1,135 @@
+package raffles
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"time"
+
+	"github.com/sol-armada/sol-bot/stores"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type mongoStore struct {
+	store *stores.RaffleStore
+}
+
+var _ Store = (*mongoStore)(nil)
+
+// NewMongoStore wraps the mongo raffles collection as a Store
+func NewMongoStore(store *stores.RaffleStore) Store {
+	if store == nil {
+		return nil
+	}
+	return &mongoStore{store: store}
+}
+
+func (s *mongoStore) Get(id string) (*Raffle, error) {
+	raffle := &Raffle{}
+	if err := s.store.Get(id).Decode(raffle); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrRaffleNotFound
+		}
+		return nil, err
+	}
+
+	return raffle, nil
+}
+
+func (s *mongoStore) GetLatest() (*Raffle, error) {
+	cur, err := s.store.GetLatest()
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer cur.Close(context.TODO())
+
+	if !cur.Next(context.TODO()) {
+		return nil, nil
+	}
+
+	latestRaffle := &Raffle{}
+	if err := cur.Decode(latestRaffle); err != nil {
+		return nil, err
+	}
+
+	return latestRaffle, nil
+}
+
+func (s *mongoStore) List(filter ListFilter, limit int) ([]*Raffle, error) {
+	cur, err := s.store.List(filterToBSON(filter), int64(limit))
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(context.TODO())
+
+	raffles := []*Raffle{}
+	for cur.Next(context.TODO()) {
+		raffle := &Raffle{}
+		if err := cur.Decode(raffle); err != nil {
+			return nil, err
+		}
+		raffles = append(raffles, raffle)
+	}
+
+	return raffles, nil
+}
+
+func (s *mongoStore) Save(raffle *Raffle) error {
+	return s.store.Upsert(raffle.Id, raffle)
+}
+
+func (s *mongoStore) Delete(id string) error {
+	return s.store.Delete(id)
+}
+
+func filterToBSON(f ListFilter) bson.D {
+	filter := bson.D{}
+
+	if f.EndedOnly {
+		filter = append(filter, bson.E{Key: "ended", Value: true})
+	}
+
+	if f.ExcludeTest {
+		filter = append(filter, bson.E{Key: "test", Value: bson.M{"$ne": true}})
+	}
+
+	createdAt := bson.M{}
+	if !f.Since.IsZero() {
+		createdAt["$gt"] = f.Since
+	}
+	if !f.Until.IsZero() {
+		createdAt["$lt"] = f.Until
+	}
+	if len(createdAt) > 0 {
+		filter = append(filter, bson.E{Key: "createdat", Value: createdAt})
+	}
+
+	if f.Scheduled {
+		filter = append(filter,
+			bson.E{Key: "settled", Value: bson.M{"$ne": true}},
+			bson.E{Key: "endtime", Value: bson.M{"$gt": time.Time{}}},
+		)
+	}
+
+	if f.Prize != "" {
+		filter = append(filter, bson.E{Key: "prize", Value: bson.M{"$regex": regexp.QuoteMeta(f.Prize), "$options": "i"}})
+	}
+
+	if f.AttendanceId != "" {
+		filter = append(filter, bson.E{Key: "attedanceid", Value: f.AttendanceId})
+	}
+
+	if f.Winner != "" {
+		filter = append(filter, bson.E{Key: "winners", Value: f.Winner})
+	}
+
+	if f.Entrant != "" {
+		filter = append(filter, bson.E{Key: "tickets." + f.Entrant, Value: bson.M{"$exists": true}})
+	}
+
+	return filter
+}
@@ -1,20 +1,15 @@
 package raffles
 
 import (
-	"context"
-	"crypto/rand"
 	"errors"
 	"fmt"
-	"math/big"
 	"slices"
 	"strings"
 	"time"
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/rs/xid"
-	"github.com/sol-armada/sol-bot/members"
-	"github.com/sol-armada/sol-bot/stores"
-	"go.mongodb.org/mongo-driver/mongo"
+	"github.com/sol-armada/sol-bot/fairdraw"
 )
 
 type Raffle struct {
@@ -26,31 +21,45 @@ type Raffle struct {
 	Tickets     map[string]int `json:"tickets"`
 	Winners     []string       `json:"winners"`
 	Ended       bool           `json:"ended"`
+	Settled     bool           `json:"settled"` // winners' tokens are spent and everyone else's released
 	Test        bool           `json:"test"`
-	ChannelId   string         `json:"channel_id"`
-	MessageId   string         `json:"message_id"`
-	CreatedAt   time.Time      `json:"created_at"`
-	UpdatedAt   time.Time      `json:"updated_at"`
+	// SeedHash is published when the raffle starts, Seed is only revealed once it ends
+	Seed        string           `json:"seed"`
+	SeedHash    string           `json:"seed_hash"`
+	DrawEntries []fairdraw.Entry `json:"draw_entries"`
+	Rules       Rules            `json:"rules"`
+	// EndTime is set for scheduled raffles, which draw themselves
+	EndTime       time.Time       `json:"end_time"`
+	Reminders     []time.Duration `json:"reminders"`
+	RemindersSent []time.Duration `json:"reminders_sent"`
+	ChannelId     string          `json:"channel_id"`
+	MessageId     string          `json:"message_id"`
+	CreatedAt     time.Time       `json:"created_at"`
+	UpdatedAt     time.Time       `json:"updated_at"`
+	// DrawAttempts counts failed scheduled draws, each backing off the next until NextDrawAt
+	DrawAttempts int       `json:"draw_attempts"`
+	DrawError    string    `json:"draw_error"`
+	NextDrawAt   time.Time `json:"next_draw_at"`
 }
 
-var rafflesStore *stores.RaffleStore
+var rafflesStore Store
 
 var (
-	ErrNoEntries error = errors.New("no entries in raffle")
+	ErrNoEntries      error = errors.New("no entries in raffle")
+	ErrRaffleNotFound error = errors.New("raffle not found")
+	ErrRaffleNotDrawn error = errors.New("raffle has not been drawn")
 )
 
-func Setup() error {
-	storesClient := stores.Get()
-	rs, ok := storesClient.GetRafflesStore()
-	if !ok {
+func Setup(store Store) error {
+	if store == nil {
 		return errors.New("raffles store not found")
 	}
-	rafflesStore = rs
+	rafflesStore = store
 
 	return nil
 }
 
-func New(name, attendanceId, prize string, test bool) *Raffle {
+func New(name, attendanceId, prize string, test bool, rules Rules) *Raffle {
 	n := time.Now().UTC()
 
 	prizeSplit := strings.Split(prize, ":")
@@ -65,6 +74,8 @@ func New(name, attendanceId, prize string, test bool) *Raffle {
 		_, _ = fmt.Sscanf(prizeQtyStr, "%d", &quantity)
 	}
 
+	seed := fairdraw.NewSeed()
+
 	return &Raffle{
 		Id:          xid.New().String(),
 		Name:        name,
@@ -74,24 +85,21 @@ func New(name, attendanceId, prize string, test bool) *Raffle {
 		Tickets:     map[string]int{},
 		CreatedAt:   n,
 		UpdatedAt:   n,
+		Seed:        seed,
+		SeedHash:    fairdraw.Commit(seed),
+		Rules:       rules,
 
 		Test: test,
 	}
 }
 
 func Get(id string) (*Raffle, error) {
-	raffle := &Raffle{}
-
-	if err := rafflesStore.Get(id).Decode(raffle); err != nil {
-		return nil, err
-	}
-
-	return raffle, nil
+	return rafflesStore.Get(id)
 }
 
 func (r *Raffle) Save() error {
 	r.UpdatedAt = time.Now().UTC()
-	return rafflesStore.Upsert(r.Id, r)
+	return rafflesStore.Save(r)
 }
 
 func (r *Raffle) SetMessage(message *discordgo.Message) *Raffle {
@@ -110,52 +118,49 @@ func (r *Raffle) RemoveTicket(memberId string) *Raffle {
 	return r
 }
 
-func (r *Raffle) PickWinner() ([]*members.Member, error) {
+// PickWinner draws the winners and ends the raffle. Winners are recorded by id, members who left since entering
+// still win so the draw can be verified
+func (r *Raffle) PickWinner() ([]string, error) {
 	if r.Ended {
 		return nil, errors.New("raffle has ended")
 	}
 
-	// pick a winner based on weighted random
-	memberIds := []string{}
-	for memberId, tickets := range r.Tickets {
-		for range tickets {
-			memberIds = append(memberIds, memberId)
-		}
+	if len(r.Tickets) == 0 {
+		return nil, ErrNoEntries
 	}
 
-	if len(memberIds) == 0 {
-		return nil, ErrNoEntries
+	history, err := r.history()
+	if err != nil {
+		return nil, err
 	}
 
-	winners := []*members.Member{}
-	potentialWinners := slices.Clone(memberIds)
-	for range r.Quantity {
-		if len(potentialWinners) == 0 {
-			break
-		}
+	entries := r.drawEntries(history, time.Now().UTC())
+	if len(entries) == 0 {
+		return nil, ErrNoEntries
+	}
 
-		selected, err := rand.Int(rand.Reader, big.NewInt(int64(len(potentialWinners))))
-		if err != nil {
-			return nil, err
-		}
+	// raffles started before draws were committed get their seed now
+	if r.Seed == "" {
+		r.Seed = fairdraw.NewSeed()
+		r.SeedHash = fairdraw.Commit(r.Seed)
+	}
+	r.DrawEntries = entries
 
-		winner, err := members.Get(potentialWinners[selected.Int64()])
-		if err != nil {
-			return nil, err
-		}
+	r.Winners = append(r.Winners, fairdraw.Draw(r.Seed, r.Id, entries, max(r.Quantity, 1))...)
+	r.Ended = true
 
-		winners = append(winners, winner)
-		r.Winners = append(r.Winners, winner.Id)
+	return r.Winners, r.Save()
+}
 
-		// remove all entries of the winner from potentialWinners
-		newPotentialWinners := slices.DeleteFunc(potentialWinners, func(id string) bool {
-			return id == winner.Id
-		})
-		potentialWinners = newPotentialWinners
+// drawEntries weights each member by their tickets and the raffle's rules. Members weighted out are left off
+func (r *Raffle) drawEntries(history []*Raffle, now time.Time) []fairdraw.Entry {
+	entries := []fairdraw.Entry{}
+	for memberId, tickets := range r.Tickets {
+		if weight := r.Rules.weight(memberId, tickets, history, now); weight > 0 {
+			entries = append(entries, fairdraw.Entry{Id: memberId, Weight: weight})
+		}
 	}
-	r.Ended = true
-
-	return winners, r.Save()
+	return entries
 }
 
 func (r *Raffle) GetTickets() string {
@@ -194,21 +199,7 @@ func (r *Raffle) GetTickets() string {
 }
 
 func (r *Raffle) GetLatest() (*Raffle, error) {
-	latestRaffle := &Raffle{}
-	cur, err := rafflesStore.GetLatest()
-	if err != nil && err != mongo.ErrNoDocuments {
-		return nil, err
-	}
-
-	if !cur.Next(context.TODO()) {
-		return nil, nil
-	}
-
-	if err := cur.Decode(latestRaffle); err != nil {
-		return nil, err
-	}
-
-	return latestRaffle, nil
+	return rafflesStore.GetLatest()
 }
 
 func (r *Raffle) MemberWonLast(id string) (bool, error) {
@@ -0,0 +1,147 @@
+package raffles
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sol-armada/sol-bot/members"
+)
+
+func setupStores(t *testing.T, memberIds ...string) {
+	t.Helper()
+
+	if err := members.Setup(members.NewMemoryStore()); err != nil {
+		t.Fatal(err)
+	}
+	if err := Setup(NewMemoryStore()); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, id := range memberIds {
+		if err := (&members.Member{Id: id}).Save(); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestPickWinner(t *testing.T) {
+	setupStores(t, "a", "b", "c")
+
+	r := New("test", "", "Ship:2", true, Rules{})
+	r.AddTicket("a", 5).AddTicket("b", 1).AddTicket("c", 1)
+
+	winners, err := r.PickWinner()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(winners) != 2 {
+		t.Fatalf("expected 2 winners, got %d", len(winners))
+	}
+	if winners[0] == winners[1] {
+		t.Errorf("member %s won twice", winners[0])
+	}
+
+	stored, err := Get(r.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !stored.Ended || len(stored.Winners) != 2 {
+		t.Errorf("expected stored raffle to be ended with 2 winners, got %+v", stored)
+	}
+
+	if _, err := r.PickWinner(); err == nil {
+		t.Error("expected error picking winner of ended raffle")
+	}
+}
+
+func TestPickWinnerMoreWinnersThanEntries(t *testing.T) {
+	setupStores(t, "a")
+
+	r := New("test", "", "Ship:3", true, Rules{})
+	r.AddTicket("a", 2)
+
+	winners, err := r.PickWinner()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(winners) != 1 {
+		t.Errorf("expected 1 winner, got %d", len(winners))
+	}
+}
+
+func TestPickWinnerMemberGone(t *testing.T) {
+	setupStores(t)
+
+	// the member left after entering, the draw still goes through
+	r := New("test", "", "Ship:1", true, Rules{})
+	r.AddTicket("gone", 1)
+
+	winners, err := r.PickWinner()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(winners) != 1 || winners[0] != "gone" {
+		t.Errorf("expected gone to win, got %v", winners)
+	}
+}
+
+func TestPickWinnerNoEntries(t *testing.T) {
+	setupStores(t)
+
+	r := New("test", "", "Ship", true, Rules{})
+	if _, err := r.PickWinner(); !errors.Is(err, ErrNoEntries) {
+		t.Errorf("expected ErrNoEntries, got %v", err)
+	}
+}
+
+func TestMemberWonLast(t *testing.T) {
+	setupStores(t, "a")
+
+	r := New("test", "", "Ship", true, Rules{})
+	r.AddTicket("a", 1)
+	if _, err := r.PickWinner(); err != nil {
+		t.Fatal(err)
+	}
+
+	won, err := New("next", "", "Ship", true, Rules{}).MemberWonLast("a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !won {
+		t.Error("expected member to have won the last raffle")
+	}
+}
+
+func TestVerify(t *testing.T) {
+	setupStores(t, "a", "b", "c")
+
+	r := New("test", "", "Ship", true, Rules{})
+	r.AddTicket("a", 3).AddTicket("b", 1).AddTicket("c", 2)
+
+	if _, err := r.Verify(); !errors.Is(err, ErrRaffleNotDrawn) {
+		t.Errorf("expected ErrRaffleNotDrawn before the draw, got %v", err)
+	}
+
+	if _, err := r.PickWinner(); err != nil {
+		t.Fatal(err)
+	}
+
+	stored, err := Get(r.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	v, err := stored.Verify()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !v.Valid() {
+		t.Errorf("expected stored raffle to verify, got %+v", v)
+	}
+
+	stored.Seed = "tampered"
+	if v, _ := stored.Verify(); v.CommitmentValid {
+		t.Error("a tampered seed should not match the commitment")
+	}
+}
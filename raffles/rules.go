@@ -0,0 +1,206 @@
+package raffles
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/sol-armada/sol-bot/settings"
+)
+
+type CooldownMode string
+
+const (
+	CooldownOff        CooldownMode = "off"
+	CooldownExclude    CooldownMode = "exclude"
+	CooldownDownweight CooldownMode = "downweight"
+)
+
+// historyLookback is how many past raffles are checked for cooldowns and losing streaks
+const historyLookback = 50
+
+var (
+	ErrOnCooldown      = errors.New("member is on raffle cooldown")
+	ErrTooManyTickets  = errors.New("too many tickets")
+	ErrInvalidCooldown = errors.New("invalid cooldown mode")
+)
+
+// Rules are the fairness rules a raffle runs with. They are copied onto the raffle when it starts
+// so changing the settings doesn't change raffles that are already running
+type Rules struct {
+	CooldownMode CooldownMode `json:"cooldown_mode"`
+	// CooldownRaffles puts members that won any of the last N raffles on cooldown
+	CooldownRaffles int `json:"cooldown_raffles"`
+	// CooldownDays puts members that won a raffle in the last N days on cooldown
+	CooldownDays int `json:"cooldown_days"`
+	// CooldownWeight multiplies the weight of members on cooldown when down-weighting
+	CooldownWeight float64 `json:"cooldown_weight"`
+	// PityBonus adds this fraction of weight for every raffle in a row the member entered without winning
+	PityBonus float64 `json:"pity_bonus"`
+	// PityMax caps the pity bonus. 0 means no cap
+	PityMax float64 `json:"pity_max"`
+	// MaxTickets caps the tickets a member can enter. 0 means no cap
+	MaxTickets int `json:"max_tickets"`
+}
+
+// LoadRules reads the rules from the FEATURES.RAFFLES settings. By default the winner of the
+// last raffle can't enter the next one, which is how raffles have always worked
+func LoadRules() (Rules, error) {
+	rules := Rules{
+		CooldownMode:    CooldownMode(strings.ToLower(settings.GetStringWithDefault("FEATURES.RAFFLES.COOLDOWN_MODE", string(CooldownExclude)))),
+		CooldownRaffles: settings.GetIntWithDefault("FEATURES.RAFFLES.COOLDOWN_RAFFLES", 1),
+		CooldownDays:    settings.GetInt("FEATURES.RAFFLES.COOLDOWN_DAYS"),
+		CooldownWeight:  settings.GetFloat64WithDefault("FEATURES.RAFFLES.COOLDOWN_WEIGHT", 0.5),
+		PityBonus:       settings.GetFloat64WithDefault("FEATURES.RAFFLES.PITY_BONUS", 0),
+		PityMax:         settings.GetFloat64WithDefault("FEATURES.RAFFLES.PITY_MAX", 0),
+		MaxTickets:      settings.GetInt("FEATURES.RAFFLES.MAX_TICKETS"),
+	}
+
+	if !slices.Contains([]CooldownMode{CooldownOff, CooldownExclude, CooldownDownweight}, rules.CooldownMode) {
+		return rules, fmt.Errorf("%w: %s", ErrInvalidCooldown, rules.CooldownMode)
+	}
+
+	return rules, nil
+}
+
+func (rules Rules) hasCooldown() bool {
+	return rules.CooldownMode != CooldownOff && rules.CooldownMode != "" && (rules.CooldownRaffles > 0 || rules.CooldownDays > 0)
+}
+
+// Describe returns a line for every active rule, used in the raffle embed
+func (rules Rules) Describe() []string {
+	lines := []string{}
+
+	if rules.hasCooldown() {
+		windows := []string{}
+		if rules.CooldownRaffles == 1 {
+			windows = append(windows, "the last raffle")
+		} else if rules.CooldownRaffles > 1 {
+			windows = append(windows, fmt.Sprintf("the last %d raffles", rules.CooldownRaffles))
+		}
+		if rules.CooldownDays > 0 {
+			windows = append(windows, fmt.Sprintf("the last %d days", rules.CooldownDays))
+		}
+
+		window := strings.Join(windows, " or ")
+		switch rules.CooldownMode {
+		case CooldownExclude:
+			lines = append(lines, "Winners of "+window+" can't enter")
+		case CooldownDownweight:
+			lines = append(lines, fmt.Sprintf("Winners of %s enter at %g%% weight", window, rules.CooldownWeight*100))
+		}
+	}
+
+	if rules.PityBonus > 0 {
+		line := fmt.Sprintf("+%g%% weight for every raffle in a row entered without winning", rules.PityBonus*100)
+		if rules.PityMax > 0 {
+			line += fmt.Sprintf(" (up to +%g%%)", rules.PityMax*100)
+		}
+		lines = append(lines, line)
+	}
+
+	if rules.MaxTickets > 0 {
+		lines = append(lines, fmt.Sprintf("Max %d tickets per member", rules.MaxTickets))
+	}
+
+	return lines
+}
+
+// onCooldown reports if the member won a recent raffle. history is ended raffles, newest first
+func (rules Rules) onCooldown(memberId string, history []*Raffle, now time.Time) bool {
+	if !rules.hasCooldown() {
+		return false
+	}
+
+	since := now.AddDate(0, 0, -rules.CooldownDays)
+	for i, raffle := range history {
+		if !slices.Contains(raffle.Winners, memberId) {
+			continue
+		}
+
+		if i < rules.CooldownRaffles {
+			return true
+		}
+
+		if rules.CooldownDays > 0 && raffle.CreatedAt.After(since) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// losingStreak counts the raffles in a row the member entered without winning
+func losingStreak(memberId string, history []*Raffle) int {
+	streak := 0
+	for _, raffle := range history {
+		if _, entered := raffle.Tickets[memberId]; !entered {
+			continue
+		}
+
+		if slices.Contains(raffle.Winners, memberId) {
+			break
+		}
+
+		streak++
+	}
+	return streak
+}
+
+// weight is the member's chance in the draw relative to everyone else
+func (rules Rules) weight(memberId string, tickets int, history []*Raffle, now time.Time) float64 {
+	if rules.MaxTickets > 0 {
+		tickets = min(tickets, rules.MaxTickets)
+	}
+
+	weight := float64(tickets)
+
+	if rules.onCooldown(memberId, history, now) {
+		switch rules.CooldownMode {
+		case CooldownExclude:
+			return 0
+		case CooldownDownweight:
+			weight *= rules.CooldownWeight
+		}
+	}
+
+	if rules.PityBonus > 0 {
+		bonus := float64(losingStreak(memberId, history)) * rules.PityBonus
+		if rules.PityMax > 0 {
+			bonus = min(bonus, rules.PityMax)
+		}
+		weight *= 1 + bonus
+	}
+
+	return weight
+}
+
+// history returns the ended, non test raffles before this one, newest first
+func (r *Raffle) history() ([]*Raffle, error) {
+	history, err := rafflesStore.List(ListFilter{EndedOnly: true, ExcludeTest: true}, max(r.Rules.CooldownRaffles, historyLookback)+1)
+	if err != nil {
+		return nil, err
+	}
+
+	return slices.DeleteFunc(history, func(h *Raffle) bool { return h.Id == r.Id }), nil
+}
+
+// CanEnter returns ErrOnCooldown if the rules keep the member out of the raffle
+func (r *Raffle) CanEnter(memberId string) error {
+	if r.Rules.CooldownMode != CooldownExclude {
+		return nil
+	}
+
+	history, err := r.history()
+	if err != nil {
+		return err
+	}
+
+	if r.Rules.onCooldown(memberId, history, time.Now().UTC()) {
+		return ErrOnCooldown
+	}
+
+	return nil
+}
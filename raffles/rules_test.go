@@ -0,0 +1,61 @@
+package raffles
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRulesWeight(t *testing.T) {
+	now := time.Now()
+
+	// newest first
+	history := []*Raffle{
+		{Id: "3", Tickets: map[string]int{"a": 1, "b": 1}, Winners: []string{"a"}, CreatedAt: now.Add(-24 * time.Hour)},
+		{Id: "2", Tickets: map[string]int{"b": 1, "c": 1}, Winners: []string{"c"}, CreatedAt: now.Add(-10 * 24 * time.Hour)},
+		{Id: "1", Tickets: map[string]int{"b": 1}, Winners: []string{"b"}, CreatedAt: now.Add(-20 * 24 * time.Hour)},
+	}
+
+	tests := []struct {
+		name     string
+		rules    Rules
+		memberId string
+		tickets  int
+		want     float64
+	}{
+		{name: "no rules", rules: Rules{}, memberId: "a", tickets: 4, want: 4},
+		{name: "excluded last winner", rules: Rules{CooldownMode: CooldownExclude, CooldownRaffles: 1}, memberId: "a", tickets: 4, want: 0},
+		{name: "older winner not excluded", rules: Rules{CooldownMode: CooldownExclude, CooldownRaffles: 1}, memberId: "c", tickets: 4, want: 4},
+		{name: "excluded by days", rules: Rules{CooldownMode: CooldownExclude, CooldownDays: 14}, memberId: "c", tickets: 4, want: 0},
+		{name: "down weighted", rules: Rules{CooldownMode: CooldownDownweight, CooldownRaffles: 2, CooldownWeight: 0.5}, memberId: "c", tickets: 4, want: 2},
+		{name: "cooldown off", rules: Rules{CooldownMode: CooldownOff, CooldownRaffles: 5}, memberId: "a", tickets: 4, want: 4},
+		{name: "pity for two losses", rules: Rules{PityBonus: 0.25}, memberId: "b", tickets: 4, want: 6},
+		{name: "pity capped", rules: Rules{PityBonus: 0.25, PityMax: 0.25}, memberId: "b", tickets: 4, want: 5},
+		{name: "tickets capped", rules: Rules{MaxTickets: 3}, memberId: "d", tickets: 10, want: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rules.weight(tt.memberId, tt.tickets, history, now); got != tt.want {
+				t.Errorf("weight() = %g, want %g", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCanEnter(t *testing.T) {
+	setupStores(t, "a", "b")
+
+	last := New("last", "", "Ship", false, Rules{})
+	last.AddTicket("a", 1)
+	if _, err := last.PickWinner(); err != nil {
+		t.Fatal(err)
+	}
+
+	next := New("next", "", "Ship", false, Rules{CooldownMode: CooldownExclude, CooldownRaffles: 1})
+	if err := next.CanEnter("a"); err != ErrOnCooldown {
+		t.Errorf("expected last winner to be on cooldown, got %v", err)
+	}
+	if err := next.CanEnter("b"); err != nil {
+		t.Errorf("expected b to be able to enter, got %v", err)
+	}
+}
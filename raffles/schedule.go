@@ -0,0 +1,218 @@
+package raffles
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/sol-armada/sol-bot/settings"
+	"github.com/sol-armada/sol-bot/utils"
+)
+
+// watchInterval is how often scheduled raffles are checked for reminders and draws
+const watchInterval = 15 * time.Second
+
+// failed scheduled draws are retried with a growing delay until they have failed maxDrawAttempts times
+const (
+	maxDrawAttempts = 10
+	maxDrawBackoff  = time.Hour
+)
+
+// LoadReminders reads FEATURES.RAFFLES.REMINDERS, how long before the end of a scheduled raffle to
+// post a reminder, e.g. ["1h", "10m"]. Defaults to an hour and ten minutes before
+func LoadReminders() ([]time.Duration, error) {
+	raw := []string{"1h", "10m"}
+	if settings.IsSet("FEATURES.RAFFLES.REMINDERS") {
+		raw = settings.GetStringSlice("FEATURES.RAFFLES.REMINDERS")
+	}
+
+	reminders := []time.Duration{}
+	for _, r := range raw {
+		d, err := utils.StringToDuration(r)
+		if err != nil {
+			return nil, fmt.Errorf("parsing raffle reminder %q: %w", r, err)
+		}
+		reminders = append(reminders, d)
+	}
+
+	return reminders, nil
+}
+
+// Schedule makes the raffle draw itself after the duration, reminding members before it ends.
+// Reminders longer than the duration are dropped
+func (r *Raffle) Schedule(duration time.Duration, reminders []time.Duration) *Raffle {
+	r.EndTime = time.Now().UTC().Add(duration)
+	r.Reminders = slices.DeleteFunc(slices.Clone(reminders), func(d time.Duration) bool {
+		return d <= 0 || d >= duration
+	})
+	r.RemindersSent = nil
+	return r
+}
+
+func (r *Raffle) Scheduled() bool {
+	return !r.EndTime.IsZero()
+}
+
+// dueReminder returns the reminder that should be posted now, if any. Reminders missed while the bot
+// was down are skipped so only the closest one is posted
+func (r *Raffle) dueReminder(now time.Time) (time.Duration, bool) {
+	var due time.Duration
+	found := false
+	for _, reminder := range r.Reminders {
+		if slices.Contains(r.RemindersSent, reminder) {
+			continue
+		}
+
+		if now.Before(r.EndTime.Add(-reminder)) {
+			continue
+		}
+
+		if !found || reminder < due {
+			due = reminder
+			found = true
+		}
+	}
+
+	return due, found
+}
+
+// markRemindersSent marks every reminder at or before the due one as sent
+func (r *Raffle) markRemindersSent(due time.Duration) {
+	for _, reminder := range r.Reminders {
+		if reminder >= due && !slices.Contains(r.RemindersSent, reminder) {
+			r.RemindersSent = append(r.RemindersSent, reminder)
+		}
+	}
+}
+
+// drawDue reports if the scheduler should draw the raffle now
+func (r *Raffle) drawDue(now time.Time) bool {
+	return !now.Before(r.EndTime) && r.DrawAttempts < maxDrawAttempts && !now.Before(r.NextDrawAt)
+}
+
+// recordDrawFailure backs off the next draw, returning true once the raffle failed too often to retry
+func (r *Raffle) recordDrawFailure(now time.Time, err error) bool {
+	r.DrawAttempts++
+	r.DrawError = err.Error()
+	r.NextDrawAt = now.Add(min(watchInterval<<r.DrawAttempts, maxDrawBackoff))
+	return r.DrawAttempts >= maxDrawAttempts
+}
+
+// drawFailed records the failed draw on the stored raffle and alerts the error channel when giving up
+func drawFailed(logger *slog.Logger, s *discordgo.Session, id string, now time.Time, drawErr error) {
+	// the draw may have changed the raffle before failing, so only the failure is saved
+	raffle, err := Get(id)
+	if err != nil {
+		logger.Error("getting raffle to record failed draw", "error", err)
+		return
+	}
+
+	gaveUp := raffle.recordDrawFailure(now, drawErr)
+	if err := raffle.Save(); err != nil {
+		logger.Error("saving failed raffle draw", "error", err)
+		return
+	}
+
+	if !gaveUp {
+		logger.Warn("drawing scheduled raffle, retrying later", "error", drawErr, "attempts", raffle.DrawAttempts, "next_draw_at", raffle.NextDrawAt)
+		return
+	}
+
+	logger.Error("drawing scheduled raffle, giving up", "error", drawErr, "attempts", raffle.DrawAttempts)
+	if _, err := s.ChannelMessageSendComplex(settings.GetString("DISCORD.ERROR_CHANNEL_ID"), &discordgo.MessageSend{
+		Embeds: []*discordgo.MessageEmbed{
+			{
+				Title:       "Scheduled raffle draw failed",
+				Description: fmt.Sprintf("The **%s** raffle failed to draw %d times and won't be retried. End it by hand once the problem is fixed.", raffle.Name, raffle.DrawAttempts),
+				Fields: []*discordgo.MessageEmbedField{
+					{Name: "Raffle", Value: raffle.Id, Inline: true},
+					{Name: "Error", Value: drawErr.Error()},
+				},
+			},
+		},
+	}); err != nil {
+		logger.Error("sending raffle draw alert", "error", err)
+	}
+}
+
+func (r *Raffle) remind(s *discordgo.Session) error {
+	content := fmt.Sprintf("⏰ The **%s** raffle ends <t:%d:R>! Get your entries in.", r.Name, r.EndTime.Unix())
+	allowed := &discordgo.MessageAllowedMentions{}
+
+	if roleId := settings.GetString("FEATURES.RAFFLES.REMINDER_ROLE_ID"); roleId != "" {
+		content = fmt.Sprintf("<@&%s> %s", roleId, content)
+		allowed.Roles = []string{roleId}
+	}
+
+	_, err := s.ChannelMessageSendComplex(r.ChannelId, &discordgo.MessageSend{
+		Content:         content,
+		AllowedMentions: allowed,
+		Reference: &discordgo.MessageReference{
+			MessageID: r.MessageId,
+			ChannelID: r.ChannelId,
+		},
+	})
+	return err
+}
+
+// Watch posts reminders for and draws scheduled raffles until the context is done.
+// All of the scheduling state lives on the stored raffle so it picks up where it left off after a restart
+func Watch(ctx context.Context, s *discordgo.Session) {
+	logger := slog.Default().With("func", "raffles.Watch")
+
+	ticker := time.NewTicker(watchInterval)
+	defer ticker.Stop()
+
+	for {
+		checkScheduled(logger, s, time.Now().UTC())
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func checkScheduled(logger *slog.Logger, s *discordgo.Session, now time.Time) {
+	scheduled, err := rafflesStore.List(ListFilter{Scheduled: true}, 0)
+	if err != nil {
+		logger.Error("listing scheduled raffles", "error", err)
+		return
+	}
+
+	for _, raffle := range scheduled {
+		logger := logger.With("raffle_id", raffle.Id)
+
+		if !now.Before(raffle.EndTime) {
+			if !raffle.drawDue(now) {
+				continue
+			}
+
+			logger.Debug("drawing scheduled raffle")
+			if err := raffle.Finish(s); err != nil {
+				drawFailed(logger, s, raffle.Id, now, err)
+			}
+			continue
+		}
+
+		due, ok := raffle.dueReminder(now)
+		if !ok {
+			continue
+		}
+
+		// mark it first so a failing channel can't spam reminders every tick
+		raffle.markRemindersSent(due)
+		if err := raffle.Save(); err != nil {
+			logger.Error("saving raffle reminders", "error", err)
+			continue
+		}
+
+		if err := raffle.remind(s); err != nil {
+			logger.Error("sending raffle reminder", "error", err)
+		}
+	}
+}
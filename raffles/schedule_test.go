@@ -0,0 +1,92 @@
+package raffles
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestDueReminder(t *testing.T) {
+	r := New("test", "", "Ship", true, Rules{}).Schedule(2*time.Hour, []time.Duration{time.Hour, 10 * time.Minute, 3 * time.Hour})
+
+	if len(r.Reminders) != 2 {
+		t.Fatalf("expected reminders longer than the duration to be dropped, got %v", r.Reminders)
+	}
+
+	if _, ok := r.dueReminder(r.EndTime.Add(-90 * time.Minute)); ok {
+		t.Error("expected no reminder due 90 minutes before the end")
+	}
+
+	due, ok := r.dueReminder(r.EndTime.Add(-30 * time.Minute))
+	if !ok || due != time.Hour {
+		t.Errorf("expected the hour reminder to be due, got %v %v", due, ok)
+	}
+
+	// both are due after a restart, only the closest is sent and both are marked
+	due, ok = r.dueReminder(r.EndTime.Add(-5 * time.Minute))
+	if !ok || due != 10*time.Minute {
+		t.Errorf("expected the 10 minute reminder to be due, got %v %v", due, ok)
+	}
+	r.markRemindersSent(due)
+
+	if _, ok := r.dueReminder(r.EndTime.Add(-time.Minute)); ok {
+		t.Error("expected every reminder to be marked as sent")
+	}
+}
+
+func TestListScheduled(t *testing.T) {
+	setupStores(t)
+
+	scheduled := New("scheduled", "", "Ship", true, Rules{}).Schedule(time.Hour, nil)
+	ended := New("ended", "", "Ship", true, Rules{}).Schedule(time.Hour, nil)
+	ended.Ended = true
+	ended.Settled = true
+	// ended but failed to settle, so the scheduler retries it
+	unsettled := New("unsettled", "", "Ship", true, Rules{}).Schedule(time.Hour, nil)
+	unsettled.Ended = true
+	manual := New("manual", "", "Ship", true, Rules{})
+
+	for _, r := range []*Raffle{scheduled, ended, unsettled, manual} {
+		if err := r.Save(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	list, err := rafflesStore.List(ListFilter{Scheduled: true}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 2 || slices.ContainsFunc(list, func(r *Raffle) bool { return r.Id == ended.Id || r.Id == manual.Id }) {
+		t.Errorf("expected the running and unsettled scheduled raffles, got %d raffles", len(list))
+	}
+}
+
+func TestDrawBackoff(t *testing.T) {
+	r := New("test", "", "Ship", true, Rules{}).Schedule(time.Hour, nil)
+	now := r.EndTime
+
+	if !r.drawDue(now) {
+		t.Fatal("expected the draw to be due at the end time")
+	}
+
+	if r.recordDrawFailure(now, errors.New("member not found")) {
+		t.Fatal("expected the first failure to be retried")
+	}
+	if r.drawDue(now.Add(watchInterval)) {
+		t.Error("expected the retry to back off")
+	}
+	if !r.drawDue(now.Add(2 * watchInterval)) {
+		t.Error("expected the retry to be due after the backoff")
+	}
+
+	for range maxDrawAttempts - 2 {
+		r.recordDrawFailure(now, errors.New("member not found"))
+	}
+	if !r.recordDrawFailure(now, errors.New("member not found")) {
+		t.Errorf("expected to give up after %d attempts", maxDrawAttempts)
+	}
+	if r.drawDue(now.Add(24 * time.Hour)) {
+		t.Error("expected no more draws after giving up")
+	}
+}
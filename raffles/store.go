@@ -0,0 +1,79 @@
+package raffles
+
+import (
+	"slices"
+	"strings"
+	"time"
+)
+
+// Store is the persistence layer the raffles package works against
+type Store interface {
+	Get(id string) (*Raffle, error)
+	// GetLatest returns the most recently created raffle, or nil if there are none
+	GetLatest() (*Raffle, error)
+	// List returns raffles matching the filter, newest first. A limit of 0 returns all of them
+	List(filter ListFilter, limit int) ([]*Raffle, error)
+	Save(raffle *Raffle) error
+	Delete(id string) error
+}
+
+// ListFilter narrows the raffles returned by Store.List. Zero values match everything
+type ListFilter struct {
+	// EndedOnly matches raffles that have been drawn
+	EndedOnly bool
+	// ExcludeTest drops test raffles
+	ExcludeTest bool
+	// Since matches raffles created after the time
+	Since time.Time
+	// Until matches raffles created before the time
+	Until time.Time
+	// Scheduled matches raffles with an end time that haven't been drawn and settled
+	Scheduled bool
+	// Prize matches raffles with the text in their prize, ignoring case
+	Prize string
+	AttendanceId string
+	// Winner matches raffles the member won
+	Winner string
+	// Entrant matches raffles the member has tickets in
+	Entrant string
+}
+
+func (f ListFilter) matches(r *Raffle) bool {
+	if f.EndedOnly && !r.Ended {
+		return false
+	}
+
+	if f.ExcludeTest && r.Test {
+		return false
+	}
+
+	if !f.Since.IsZero() && !r.CreatedAt.After(f.Since) {
+		return false
+	}
+
+	if !f.Until.IsZero() && !r.CreatedAt.Before(f.Until) {
+		return false
+	}
+
+	if f.Scheduled && (r.Settled || !r.Scheduled()) {
+		return false
+	}
+
+	if f.Prize != "" && !strings.Contains(strings.ToLower(r.Prize), strings.ToLower(f.Prize)) {
+		return false
+	}
+
+	if f.AttendanceId != "" && r.AttedanceId != f.AttendanceId {
+		return false
+	}
+
+	if f.Winner != "" && !slices.Contains(r.Winners, f.Winner) {
+		return false
+	}
+
+	if _, entered := r.Tickets[f.Entrant]; f.Entrant != "" && !entered {
+		return false
+	}
+
+	return true
+}
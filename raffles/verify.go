@@ -0,0 +1,36 @@
+package raffles
+
+import (
+	"slices"
+
+	"github.com/sol-armada/sol-bot/fairdraw"
+)
+
+// Verification is the result of recomputing a raffle draw from its stored data
+type Verification struct {
+	// CommitmentValid is true if the revealed seed hashes to the published commitment
+	CommitmentValid bool
+	// Winners are the recomputed winners
+	Winners []string
+	// WinnersMatch is true if the recomputed winners match the recorded ones
+	WinnersMatch bool
+}
+
+func (v *Verification) Valid() bool {
+	return v.CommitmentValid && v.WinnersMatch
+}
+
+// Verify recomputes the draw from the revealed seed and the entries recorded at draw time
+func (r *Raffle) Verify() (*Verification, error) {
+	if !r.Ended || r.Seed == "" || len(r.DrawEntries) == 0 {
+		return nil, ErrRaffleNotDrawn
+	}
+
+	winners := fairdraw.Draw(r.Seed, r.Id, r.DrawEntries, max(r.Quantity, 1))
+
+	return &Verification{
+		CommitmentValid: fairdraw.Verify(r.Seed, r.SeedHash),
+		Winners:         winners,
+		WinnersMatch:    slices.Equal(winners, r.Winners),
+	}, nil
+}
@@ -3,9 +3,6 @@ package rsi
 import (
 	"context"
 	"fmt"
-	"strings"
-
-	"github.com/gocolly/colly/v2"
 )
 
 // Application represents an organization application
@@ -30,55 +27,15 @@ func (client *RSIClient) GetApplications(ctx context.Context, page int, pageSize
 		pageSize = 100
 	}
 
-	c := client.createCollector()
-	var applications []Application
-	var err error
-
-	c.OnResponse(func(r *colly.Response) {
-		if r.StatusCode == 404 {
-			err = ErrUserNotFound
-		} else if r.StatusCode != 200 {
-			err = fmt.Errorf("%w: status code %d", ErrRequestFailed, r.StatusCode)
-		}
-	})
-
-	// Parse application entries
-	c.OnHTML("table.DataTable tbody tr", func(e *colly.HTMLElement) {
-		app := Application{}
-
-		// Extract handle
-		handleElement := e.DOM.Find("td:nth-child(1) a")
-		app.Handle = strings.TrimSpace(handleElement.Text())
-
-		// Extract avatar
-		avatarElement := e.DOM.Find("td:nth-child(1) img")
-		if src, exists := avatarElement.Attr("src"); exists {
-			app.Avatar = src
-		}
-
-		// Extract note
-		app.Note = strings.TrimSpace(e.DOM.Find("td:nth-child(2)").Text())
-
-		// Extract date
-		app.Date = strings.TrimSpace(e.DOM.Find("td:nth-child(3)").Text())
-
-		if app.Handle != "" {
-			applications = append(applications, app)
-		}
-	})
-
-	url := fmt.Sprintf("https://robertsspaceindustries.com/orgs/%s/admin/applications?page=%d&pagesize=%d",
-		client.orgSID, page, pageSize)
-
-	if visitErr := c.Visit(url); visitErr != nil {
-		return nil, fmt.Errorf("%w: %v", ErrRequestFailed, visitErr)
-	}
+	url := fmt.Sprintf("%s/orgs/%s/admin/applications?page=%d&pagesize=%d",
+		client.baseURL, client.orgSID, page, pageSize)
 
+	body, err := client.fetch(ctx, url)
 	if err != nil {
 		return nil, err
 	}
 
-	return applications, nil
+	return parseApplications(body)
 }
 
 // GetAllApplications retrieves all pending applications by paginating through all pages
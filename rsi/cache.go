@@ -0,0 +1,135 @@
+package rsi
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/sol-armada/sol-bot/settings"
+	"github.com/sol-armada/sol-bot/stores"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Cache keeps parsed RSI pages so repeat lookups don't hit RSI
+type Cache interface {
+	// Get decodes the cached value into out and reports if there was one
+	Get(key string, out any) (bool, error)
+	Set(key string, value any, ttl time.Duration) error
+}
+
+var cache Cache
+
+// SetCache sets the cache every RSIClient shares. Without one, every lookup goes to RSI
+func SetCache(c Cache) {
+	cache = c
+}
+
+func cacheTTL() time.Duration {
+	return settings.GetDurationWithDefault("RSI.CACHE_TTL", 6*time.Hour)
+}
+
+// cached returns the cached value for the key, or loads, caches and returns it
+func cached[T any](key string, load func() (T, error)) (T, error) {
+	if cache != nil {
+		var value T
+		ok, err := cache.Get(key, &value)
+		if err == nil && ok {
+			return value, nil
+		}
+	}
+
+	value, err := load()
+	if err != nil {
+		return value, err
+	}
+
+	if cache != nil {
+		// a failed cache write only costs a future request
+		_ = cache.Set(key, value, cacheTTL())
+	}
+
+	return value, nil
+}
+
+type mongoCache struct {
+	store *stores.RSICacheStore
+}
+
+var _ Cache = (*mongoCache)(nil)
+
+// NewMongoCache wraps the mongo rsi cache collection as a Cache
+func NewMongoCache(store *stores.RSICacheStore) Cache {
+	if store == nil {
+		return nil
+	}
+	return &mongoCache{store: store}
+}
+
+func (c *mongoCache) Get(key string, out any) (bool, error) {
+	entry := struct {
+		Value bson.RawValue `bson:"value"`
+	}{}
+	if err := c.store.Get(key).Decode(&entry); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	if err := entry.Value.Unmarshal(out); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (c *mongoCache) Set(key string, value any, ttl time.Duration) error {
+	return c.store.Set(key, value, time.Now().Add(ttl))
+}
+
+type memoryCache struct {
+	mu      sync.Mutex
+	entries map[string]memoryCacheEntry
+}
+
+type memoryCacheEntry struct {
+	kind      bsontype.Type
+	value     []byte
+	expiresAt time.Time
+}
+
+var _ Cache = (*memoryCache)(nil)
+
+// NewMemoryCache returns a Cache that keeps entries in memory. Used for tests
+func NewMemoryCache() Cache {
+	return &memoryCache{entries: map[string]memoryCacheEntry{}}
+}
+
+func (c *memoryCache) Get(key string, out any) (bool, error) {
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	c.mu.Unlock()
+
+	if !ok || time.Now().After(entry.expiresAt) {
+		return false, nil
+	}
+
+	// round trip through bson like the mongo cache so both behave the same
+	raw := bson.RawValue{Type: entry.kind, Value: entry.value}
+	return true, raw.Unmarshal(out)
+}
+
+func (c *memoryCache) Set(key string, value any, ttl time.Duration) error {
+	kind, b, err := bson.MarshalValue(value)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[key] = memoryCacheEntry{kind: kind, value: b, expiresAt: time.Now().Add(ttl)}
+	return nil
+}
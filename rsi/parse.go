@@ -0,0 +1,306 @@
+package rsi
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/antchfx/htmlquery"
+	"github.com/sol-armada/sol-bot/members"
+	"golang.org/x/net/html"
+)
+
+// selector is a query the scrapers depend on. They are named so the selector health check can say which one RSI broke
+type selector struct {
+	name  string
+	query string
+	// css selectors are queried with goquery, the rest are XPath
+	css bool
+	// required selectors match on every healthy page they are checked against
+	required bool
+}
+
+var (
+	primaryOrgSIDSelector = selector{
+		name:     "primary org SID",
+		query:    `//div[contains(@class, "org main")]//div[@class="info"]//span[contains(text(), "SID")]/following-sibling::strong`,
+		required: true,
+	}
+	primaryOrgRankSelector = selector{
+		name:     "primary org rank",
+		query:    `//div[contains(@class, "org main")]//div[@class="info"]//span[contains(text(), "rank")]/following-sibling::strong`,
+		required: true,
+	}
+	orgsContentSelector = selector{
+		name:     "organizations list",
+		query:    `//div[contains(@class, "orgs-content")]`,
+		required: true,
+	}
+	affiliationSIDSelector = selector{
+		name:  "affiliation SIDs",
+		query: `//div[contains(@class, "orgs-content")]//div[contains(@class, "org affiliation")]//div[@class="info"]//span[contains(text(), "SID")]/following-sibling::strong`,
+	}
+	redactedSelector = selector{
+		name:  "redacted main org",
+		query: `//div[contains(@class, "org main")]//div[contains(@class,"member-visibility-restriction")]`,
+	}
+	publicProfileSelector = selector{
+		name:     "public profile",
+		query:    `//div[@id="public-profile"]`,
+		required: true,
+	}
+	handleSelector = selector{
+		name:     "handle",
+		query:    profileEntry("Handle name"),
+		required: true,
+	}
+	monikerSelector = selector{
+		name:  "moniker",
+		query: `//div[@id="public-profile"]//div[contains(@class, "profile")]//div[@class="info"]/p[contains(@class, "entry")][1]/strong`,
+	}
+	titleSelector = selector{
+		name:  "title",
+		query: `//div[@id="public-profile"]//div[contains(@class, "profile")]//div[@class="info"]//span[@class="icon"]/following-sibling::span[contains(@class, "value")]`,
+	}
+	avatarSelector = selector{
+		name:  "avatar",
+		query: `//div[@id="public-profile"]//div[contains(@class, "profile")]//div[@class="thumb"]//img`,
+	}
+	recordSelector = selector{
+		name:  "citizen record",
+		query: `//div[@id="public-profile"]//p[contains(@class, "citizen-record")]/strong`,
+	}
+	enlistedSelector = selector{
+		name:     "enlisted",
+		query:    profileEntry("Enlisted"),
+		required: true,
+	}
+	locationSelector = selector{
+		name:  "location",
+		query: profileEntry("Location"),
+	}
+	fluencySelector = selector{
+		name:  "fluency",
+		query: profileEntry("Fluency"),
+	}
+	bioSelector = selector{
+		name:  "bio",
+		query: `//div[@id="public-profile"]//div[contains(@class, "bio")]/div`,
+	}
+	applicationRowSelector = selector{
+		name:  "application rows",
+		query: "table.DataTable tbody tr",
+		css:   true,
+	}
+	rosterMemberSelector = selector{
+		name:     "roster members",
+		query:    "li.member-item",
+		css:      true,
+		required: true,
+	}
+)
+
+// profileSelectors are checked against a citizen's profile page
+var profileSelectors = []selector{publicProfileSelector, handleSelector, monikerSelector, titleSelector, avatarSelector, recordSelector, enlistedSelector, locationSelector, fluencySelector, bioSelector}
+
+// profileEntry selects the value of a labeled entry on the citizen's profile
+func profileEntry(label string) string {
+	return `//div[@id="public-profile"]//p[contains(@class, "entry")][span[contains(text(), "` + label + `")]]/strong`
+}
+
+// citizenOrgsSelectors are checked against a citizen's organizations page
+var citizenOrgsSelectors = []selector{primaryOrgSIDSelector, primaryOrgRankSelector, orgsContentSelector, affiliationSIDSelector, redactedSelector}
+
+// count returns how many nodes the selector matches in the page
+func (s selector) count(body []byte) (int, error) {
+	if s.css {
+		doc, err := goquery.NewDocumentFromReader(bytes.NewReader(body))
+		if err != nil {
+			return 0, err
+		}
+		return doc.Find(s.query).Length(), nil
+	}
+
+	doc, err := htmlquery.Parse(bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	return len(htmlquery.Find(doc, s.query)), nil
+}
+
+func parseHTML(body []byte) (*html.Node, error) {
+	doc, err := htmlquery.Parse(bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("%w: parsing page: %v", ErrRequestFailed, err)
+	}
+	return doc, nil
+}
+
+// findText returns the trimmed text of the selector's first match
+func findText(doc *html.Node, s selector) string {
+	node := htmlquery.FindOne(doc, s.query)
+	if node == nil {
+		return ""
+	}
+	return strings.TrimSpace(htmlquery.InnerText(node))
+}
+
+// parseCitizenOrgs reads a citizen's organizations page
+func parseCitizenOrgs(body []byte) (citizenOrgs, error) {
+	doc, err := parseHTML(body)
+	if err != nil {
+		return citizenOrgs{}, err
+	}
+
+	orgs := citizenOrgs{
+		PrimaryRank:  findText(doc, primaryOrgRankSelector),
+		Redacted:     htmlquery.FindOne(doc, redactedSelector.query) != nil,
+		Affiliations: []string{},
+	}
+
+	// a main org without a SID shows as None, no main org at all leaves it empty
+	if node := htmlquery.FindOne(doc, primaryOrgSIDSelector.query); node != nil {
+		orgs.PrimaryOrg = strings.TrimSpace(htmlquery.InnerText(node))
+		if orgs.PrimaryOrg == "" {
+			orgs.PrimaryOrg = "None"
+		}
+	}
+
+	for _, node := range htmlquery.Find(doc, affiliationSIDSelector.query) {
+		if sid := strings.TrimSpace(htmlquery.InnerText(node)); sid != "" {
+			orgs.Affiliations = append(orgs.Affiliations, sid)
+		}
+	}
+
+	return orgs, nil
+}
+
+// parseBio reads the bio from a citizen's profile page
+func parseBio(body []byte) (string, error) {
+	doc, err := parseHTML(body)
+	if err != nil {
+		return "", err
+	}
+
+	return findText(doc, bioSelector), nil
+}
+
+// enlistedLayout is how RSI writes the enlisted date, like Jan 5, 2016
+const enlistedLayout = "Jan 2, 2006"
+
+// parseDossier reads the public record from a citizen's profile page. Relative avatar urls are made absolute with baseURL
+func parseDossier(body []byte, baseURL string) (*members.Dossier, error) {
+	doc, err := parseHTML(body)
+	if err != nil {
+		return nil, err
+	}
+
+	dossier := &members.Dossier{
+		Handle:       findText(doc, handleSelector),
+		Moniker:      findText(doc, monikerSelector),
+		RecordNumber: findText(doc, recordSelector),
+		Location:     strings.Join(strings.Fields(findText(doc, locationSelector)), " "),
+		Fluency:      []string{},
+		Title:        findText(doc, titleSelector),
+	}
+
+	if strings.EqualFold(dossier.RecordNumber, "n/a") {
+		dossier.RecordNumber = ""
+	}
+
+	if enlisted := findText(doc, enlistedSelector); enlisted != "" {
+		if t, err := time.Parse(enlistedLayout, enlisted); err == nil {
+			dossier.Enlisted = t
+		}
+	}
+
+	for _, language := range strings.Split(findText(doc, fluencySelector), ",") {
+		if language = strings.TrimSpace(language); language != "" {
+			dossier.Fluency = append(dossier.Fluency, language)
+		}
+	}
+
+	if node := htmlquery.FindOne(doc, avatarSelector.query); node != nil {
+		dossier.Avatar = htmlquery.SelectAttr(node, "src")
+		if strings.HasPrefix(dossier.Avatar, "/") {
+			dossier.Avatar = baseURL + dossier.Avatar
+		}
+	}
+
+	return dossier, nil
+}
+
+// parseApplications reads the rows of the org's applications page
+func parseApplications(body []byte) ([]Application, error) {
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("%w: parsing page: %v", ErrRequestFailed, err)
+	}
+
+	var applications []Application
+	doc.Find(applicationRowSelector.query).Each(func(_ int, row *goquery.Selection) {
+		app := Application{
+			Handle: strings.TrimSpace(row.Find("td:nth-child(1) a").Text()),
+			Note:   strings.TrimSpace(row.Find("td:nth-child(2)").Text()),
+			Date:   strings.TrimSpace(row.Find("td:nth-child(3)").Text()),
+		}
+
+		if src, exists := row.Find("td:nth-child(1) img").Attr("src"); exists {
+			app.Avatar = src
+		}
+
+		if app.Handle != "" {
+			applications = append(applications, app)
+		}
+	})
+
+	return applications, nil
+}
+
+var starsWidth = regexp.MustCompile(`width:\s*(\d+)%`)
+
+// parseRoster reads the members listed on a page of the org's members
+func parseRoster(body []byte) ([]RosterMember, error) {
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("%w: parsing page: %v", ErrRequestFailed, err)
+	}
+
+	roster := []RosterMember{}
+	doc.Find(rosterMemberSelector.query).Each(func(_ int, item *goquery.Selection) {
+		member := RosterMember{
+			Handle:      strings.TrimSpace(item.Find(".nick").Text()),
+			DisplayName: strings.TrimSpace(item.Find(".name").Text()),
+			Rank:        strings.TrimSpace(item.Find(".rank").Text()),
+			Visibility:  VisibilityVisible,
+		}
+
+		switch {
+		case item.HasClass("org-visibility-R"):
+			member.Visibility = VisibilityRedacted
+		case item.HasClass("org-visibility-H"):
+			member.Visibility = VisibilityHidden
+		}
+
+		// stars are drawn as a bar, 20% per star
+		if style, ok := item.Find(".stars").Attr("style"); ok {
+			if m := starsWidth.FindStringSubmatch(style); m != nil {
+				width, _ := strconv.Atoi(m[1])
+				member.Stars = width / 20
+			}
+		}
+
+		if member.Visibility != VisibilityVisible {
+			member.Handle = ""
+			member.DisplayName = ""
+		}
+
+		roster = append(roster, member)
+	})
+
+	return roster, nil
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/gocolly/colly/v2"
+	"github.com/sol-armada/sol-bot/allies"
 	"github.com/sol-armada/sol-bot/members"
 	"github.com/sol-armada/sol-bot/ranks"
 	"github.com/sol-armada/sol-bot/settings"
@@ -35,16 +36,22 @@ type RSIClient struct {
 	token   string
 	orgSID  string
 	allies  []string
+	isAlly  func(sid string) bool
 	timeout time.Duration
+	baseURL string
 }
 
 // Config holds the configuration for the RSI client
 type Config struct {
-	Token   string
-	Device  string
-	OrgSID  string
-	Allies  []string
+	Token  string
+	Device string
+	OrgSID string
+	Allies []string
+	// IsAlly looks orgs up in the ally registry. Allies is used when it isn't set
+	IsAlly  func(sid string) bool
 	Timeout time.Duration
+	// BaseURL is where RSI is requested from, RsiBaseURL unless set
+	BaseURL string
 }
 
 // NewClient creates a new RSI client with the given configuration
@@ -52,12 +59,17 @@ func NewClient(config Config) (*RSIClient, error) {
 	if config.Timeout == 0 {
 		config.Timeout = 30 * time.Second
 	}
+	if config.BaseURL == "" {
+		config.BaseURL = RsiBaseURL
+	}
 
 	return &RSIClient{
 		token:   fmt.Sprintf("Rsi-Token=%s; _rsi_device=%s;", config.Token, config.Device),
 		orgSID:  config.OrgSID,
 		allies:  config.Allies,
+		isAlly:  config.IsAlly,
 		timeout: config.Timeout,
+		baseURL: strings.TrimSuffix(config.BaseURL, "/"),
 	}, nil
 }
 
@@ -68,6 +80,7 @@ func NewDefaultClient() (*RSIClient, error) {
 		Device:  settings.GetString("RSI.DEVICE"),
 		OrgSID:  settings.GetString("rsi_org_sid"),
 		Allies:  settings.GetStringSlice("ALLIES"),
+		IsAlly:  registeredAlly,
 		Timeout: 30 * time.Second,
 	}
 
@@ -86,6 +99,28 @@ func (c *RSIClient) createCollector() *colly.Collector {
 	return collector
 }
 
+// citizenOrgs is what the citizen's organizations page says about them
+type citizenOrgs struct {
+	PrimaryOrg   string   `bson:"primary_org"`
+	PrimaryRank  string   `bson:"primary_rank"`
+	Redacted     bool     `bson:"redacted"`
+	Affiliations []string `bson:"affiliations"`
+}
+
+// getCitizenOrgs retrieves the citizen's organizations, from the cache when it can
+func (client *RSIClient) getCitizenOrgs(ctx context.Context, handle string) (citizenOrgs, error) {
+	handle = strings.ReplaceAll(handle, ".", "")
+
+	return cached("citizen-orgs:"+strings.ToLower(handle), func() (citizenOrgs, error) {
+		body, err := client.fetch(ctx, fmt.Sprintf("%s/citizens/%s/organizations", client.baseURL, handle))
+		if err != nil {
+			return citizenOrgs{}, err
+		}
+
+		return parseCitizenOrgs(body)
+	})
+}
+
 // UpdateRsiInfo updates member information from RSI website
 func (client *RSIClient) UpdateRsiInfo(ctx context.Context, member *members.Member) error {
 	member.RSIMember = false
@@ -96,61 +131,29 @@ func (client *RSIClient) UpdateRsiInfo(ctx context.Context, member *members.Memb
 	member.PrimaryOrg = ""
 	member.Affilations = []string{}
 
-	c := client.createCollector()
-	var err error
-
-	c.OnResponse(func(r *colly.Response) {
-		switch r.StatusCode {
-		case 404:
-			err = ErrUserNotFound
-		case 403:
-			err = ErrForbidden
-		case 200:
-			// OK, do nothing
-		default:
-			err = fmt.Errorf("%w: status code %d", ErrRequestFailed, r.StatusCode)
-		}
-	})
+	orgs, err := client.getCitizenOrgs(ctx, member.Handle())
+	if err != nil {
+		return err
+	}
 
-	c.OnXML(`//div[contains(@class, "org main")]//div[@class="info"]//span[contains(text(), "SID")]/following-sibling::strong`, func(e *colly.XMLElement) {
-		if e.Text == "" {
-			e.Text = "None"
-		}
-		member.PrimaryOrg = e.Text
-	})
+	member.PrimaryOrg = orgs.PrimaryOrg
 
-	c.OnXML(`//div[contains(@class, "org main")]//div[@class="info"]//span[contains(text(), "rank")]/following-sibling::strong`, func(e *colly.XMLElement) {
-		if member.PrimaryOrg == client.orgSID {
-			member.Rank = ranks.GetRankByRSIRankName(e.Text)
-			member.IsGuest = false
-		}
-	})
+	if member.PrimaryOrg == client.orgSID {
+		member.Rank = ranks.GetRankByRSIRankName(orgs.PrimaryRank)
+		member.IsGuest = false
+	}
 
-	c.OnXML(`//div[contains(@class, "orgs-content")]`, func(e *colly.XMLElement) {
-		member.Affilations = e.ChildTexts(`//div[contains(@class, "org affiliation")]//div[@class="info"]//span[contains(text(), "SID")]/following-sibling::strong`)
-		if utils.StringSliceContains(member.Affilations, client.orgSID) {
-			member.IsAffiliate = true
-			member.Rank = ranks.Member
-			member.IsGuest = false
-			member.IsAlly = false
-		}
-	})
+	member.Affilations = orgs.Affiliations
+	if utils.StringSliceContains(member.Affilations, client.orgSID) {
+		member.IsAffiliate = true
+		member.Rank = ranks.Member
+		member.IsGuest = false
+		member.IsAlly = false
+	}
 
-	c.OnXML(`//div[contains(@class, "org main")]//div[contains(@class,"member-visibility-restriction")]`, func(e *colly.XMLElement) {
+	if orgs.Redacted {
 		member.PrimaryOrg = "REDACTED"
 		member.IsGuest = true
-	})
-
-	url := fmt.Sprintf("%s/citizens/%s/organizations", RsiBaseURL, strings.ReplaceAll(member.Name, ".", ""))
-	if visitErr := c.Visit(url); visitErr != nil {
-		if strings.Contains(visitErr.Error(), "Not Found") {
-			return ErrUserNotFound
-		}
-		return fmt.Errorf("%w: %v", ErrRequestFailed, visitErr)
-	}
-
-	if err != nil {
-		return err
 	}
 
 	member.RSIMember = true
@@ -162,70 +165,49 @@ func (client *RSIClient) UpdateRsiInfo(ctx context.Context, member *members.Memb
 	return nil
 }
 
-// isAllyOrg checks if an organization is in the allies list
+// isAllyOrg checks if an organization is an ally
 func (client *RSIClient) isAllyOrg(org string) bool {
+	if client.isAlly != nil {
+		return client.isAlly(org)
+	}
 	return utils.StringSliceContains(client.allies, org)
 }
 
-// ValidHandle checks if an RSI handle exists
-func (client *RSIClient) ValidHandle(ctx context.Context, handle string) bool {
-	c := client.createCollector()
-	exists := true
-
-	c.OnResponse(func(r *colly.Response) {
-		if r.StatusCode != 200 {
-			exists = false
-		}
-	})
+// registeredAlly checks the ally registry, falling back to the ALLIES setting if the registry can't be read
+func registeredAlly(sid string) bool {
+	ok, err := allies.IsAlly(sid)
+	if err != nil {
+		return utils.StringSliceContains(settings.GetStringSlice("ALLIES"), sid)
+	}
+	return ok
+}
 
-	if err := c.Visit(fmt.Sprintf("%s/citizens/%s/organizations", RsiBaseURL, handle)); err != nil {
-		if strings.Contains(err.Error(), "Not Found") {
-			exists = false
+// ValidHandle checks if an RSI handle exists. Any error but the handle missing means RSI couldn't be asked,
+// like while requests are backed off, so the handle should be checked again later
+func (client *RSIClient) ValidHandle(ctx context.Context, handle string) (bool, error) {
+	if _, err := client.getCitizenOrgs(ctx, handle); err != nil {
+		if errors.Is(err, ErrUserNotFound) {
+			return false, nil
 		}
+		return false, err
 	}
 
-	return exists
+	return true, nil
 }
 
 // IsMemberOfOrg checks if a handle is a member of a specific organization
 func (client *RSIClient) IsMemberOfOrg(ctx context.Context, handle string, org string) (bool, error) {
-	c := client.createCollector()
-
-	var orgs []string
-	var err error
-
-	c.OnResponse(func(r *colly.Response) {
-		if r.StatusCode == 404 {
-			err = ErrUserNotFound
-		} else if r.StatusCode != 200 {
-			err = fmt.Errorf("%w: status code %d", ErrRequestFailed, r.StatusCode)
-		}
-	})
-
-	// Get primary organization
-	c.OnXML(`//div[contains(@class, "org main")]//div[@class="info"]//span[contains(text(), "SID")]/following-sibling::strong`, func(e *colly.XMLElement) {
-		if e.Text != "" && e.Text != "None" {
-			orgs = append(orgs, e.Text)
-		}
-	})
-
-	// Get affiliated organizations
-	c.OnXML(`//div[contains(@class, "orgs-content")]`, func(e *colly.XMLElement) {
-		affiliations := e.ChildTexts(`//div[contains(@class, "org affiliation")]//div[@class="info"]//span[contains(text(), "SID")]/following-sibling::strong`)
-		orgs = append(orgs, affiliations...)
-	})
-
-	if visitErr := c.Visit(fmt.Sprintf("%s/citizens/%s/organizations", RsiBaseURL, handle)); visitErr != nil {
-		if strings.Contains(visitErr.Error(), "Not Found") {
-			return false, ErrUserNotFound
-		}
-		return false, fmt.Errorf("%w: %v", ErrRequestFailed, visitErr)
-	}
-
+	citizen, err := client.getCitizenOrgs(ctx, handle)
 	if err != nil {
 		return false, err
 	}
 
+	var orgs []string
+	if citizen.PrimaryOrg != "" && citizen.PrimaryOrg != "None" {
+		orgs = append(orgs, citizen.PrimaryOrg)
+	}
+	orgs = append(orgs, citizen.Affiliations...)
+
 	for _, o := range orgs {
 		if strings.EqualFold(o, org) {
 			return true, nil
@@ -235,42 +217,35 @@ func (client *RSIClient) IsMemberOfOrg(ctx context.Context, handle string, org s
 	return false, nil
 }
 
-// GetBio retrieves the bio for an RSI handle
+// GetBio retrieves the bio for an RSI handle.
+// Not cached, validation waits for the member to change their bio
 func (client *RSIClient) GetBio(ctx context.Context, handle string) (string, error) {
-	c := client.createCollector()
-
-	var err error
-	bio := ""
-
-	c.OnResponse(func(r *colly.Response) {
-		switch r.StatusCode {
-		case 404:
-			err = ErrUserNotFound
-		case 403:
-			err = ErrForbidden
-		default:
-			if r.StatusCode != 200 {
-				err = fmt.Errorf("%w: status code %d", ErrRequestFailed, r.StatusCode)
-			}
-		}
-	})
+	body, err := client.fetch(ctx, fmt.Sprintf("%s/citizens/%s", client.baseURL, handle))
+	if err != nil {
+		return "", err
+	}
 
-	c.OnXML(`//div[@id="public-profile"]//div[contains(@class, "bio")]/div`, func(e *colly.XMLElement) {
-		bio = e.Text
-	})
+	return parseBio(body)
+}
+
+// GetDossier retrieves the public record from the citizen's profile page, from the cache when it can
+func (client *RSIClient) GetDossier(ctx context.Context, handle string) (*members.Dossier, error) {
+	handle = strings.ReplaceAll(handle, ".", "")
 
-	if visitErr := c.Visit(fmt.Sprintf("%s/citizens/%s", RsiBaseURL, handle)); visitErr != nil {
-		if strings.Contains(visitErr.Error(), "Not Found") {
-			return "", ErrUserNotFound
+	return cached("citizen-dossier:"+strings.ToLower(handle), func() (*members.Dossier, error) {
+		body, err := client.fetch(ctx, fmt.Sprintf("%s/citizens/%s", client.baseURL, handle))
+		if err != nil {
+			return nil, err
 		}
-		return "", fmt.Errorf("%w: %v", ErrRequestFailed, visitErr)
-	}
 
-	if err != nil {
-		return "", err
-	}
+		dossier, err := parseDossier(body, client.baseURL)
+		if err != nil {
+			return nil, err
+		}
+		dossier.UpdatedAt = time.Now().UTC()
 
-	return bio, nil
+		return dossier, nil
+	})
 }
 
 // Backward compatibility functions using a default client
@@ -300,9 +275,9 @@ func UpdateRsiInfo(member *members.Member) error {
 
 // ValidHandle checks if an RSI handle exists using the default client
 // Deprecated: Use RSIClient.ValidHandle with context instead
-func ValidHandle(handle string) bool {
+func ValidHandle(handle string) (bool, error) {
 	if err := initDefaultClient(); err != nil {
-		return false
+		return false, err
 	}
 	return defaultClient.ValidHandle(context.Background(), handle)
 }
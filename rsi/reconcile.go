@@ -0,0 +1,94 @@
+package rsi
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/sol-armada/sol-bot/members"
+	"github.com/sol-armada/sol-bot/ranks"
+)
+
+// RankMismatch is a member whose stored rank doesn't match their rank on the RSI roster
+type RankMismatch struct {
+	Member     members.Member
+	RosterRank string
+	Expected   ranks.Rank
+}
+
+// RosterReport is the difference between the RSI roster and the stored members
+type RosterReport struct {
+	// NotOnDiscord are roster members without a stored member of the same handle
+	NotOnDiscord []RosterMember
+	// NotOnRoster are stored members who claim to be in the org but are not on the roster
+	NotOnRoster []members.Member
+	// RankMismatches are members on both whose ranks differ
+	RankMismatches []RankMismatch
+	// Unlisted counts redacted and hidden roster members, who can't be matched by handle
+	Unlisted int
+}
+
+// Reconcile compares the org roster to the stored members. Members who left, bots and
+// affiliates' ranks are ignored since affiliates are always stored as members
+func Reconcile(orgSID string, roster []RosterMember, mmbrs []members.Member) *RosterReport {
+	report := &RosterReport{}
+
+	byHandle := map[string]RosterMember{}
+	for _, rm := range roster {
+		if rm.Visibility != VisibilityVisible || rm.Handle == "" {
+			report.Unlisted++
+			continue
+		}
+		byHandle[normalizeHandle(rm.Handle)] = rm
+	}
+
+	matched := map[string]bool{}
+	for _, member := range mmbrs {
+		if member.IsBot || member.LeftAt != nil {
+			continue
+		}
+
+		handle := normalizeHandle(member.Handle())
+		rm, ok := byHandle[handle]
+		if !ok {
+			if claimsMembership(orgSID, member) {
+				report.NotOnRoster = append(report.NotOnRoster, member)
+			}
+			continue
+		}
+		matched[handle] = true
+
+		expected := ranks.GetRankByRSIRankName(rm.Rank)
+		if !member.IsAffiliate && expected != ranks.None && member.Rank != expected {
+			report.RankMismatches = append(report.RankMismatches, RankMismatch{
+				Member:     member,
+				RosterRank: rm.Rank,
+				Expected:   expected,
+			})
+		}
+	}
+
+	for handle, rm := range byHandle {
+		if !matched[handle] {
+			report.NotOnDiscord = append(report.NotOnDiscord, rm)
+		}
+	}
+
+	slices.SortFunc(report.NotOnDiscord, func(a, b RosterMember) int { return strings.Compare(a.Handle, b.Handle) })
+	slices.SortFunc(report.NotOnRoster, func(a, b members.Member) int { return strings.Compare(a.Name, b.Name) })
+	slices.SortFunc(report.RankMismatches, func(a, b RankMismatch) int { return strings.Compare(a.Member.Name, b.Member.Name) })
+
+	return report
+}
+
+// claimsMembership is a member stored as being in the org, either from their RSI profile or an org rank
+func claimsMembership(orgSID string, member members.Member) bool {
+	if strings.EqualFold(member.PrimaryOrg, orgSID) || member.IsAffiliate {
+		return true
+	}
+
+	return member.Rank >= ranks.Admiral && member.Rank <= ranks.Member
+}
+
+func normalizeHandle(handle string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(handle), ".", ""))
+}
@@ -0,0 +1,48 @@
+package rsi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sol-armada/sol-bot/members"
+	"github.com/sol-armada/sol-bot/ranks"
+)
+
+func TestReconcile(t *testing.T) {
+	left := time.Now()
+
+	roster := []RosterMember{
+		{Handle: "Alpha", Rank: "Technician", Visibility: VisibilityVisible},
+		{Handle: "Bravo", Rank: "Member", Visibility: VisibilityVisible},
+		{Handle: "Charlie", Rank: "Member", Visibility: VisibilityVisible},
+		{Visibility: VisibilityRedacted},
+	}
+
+	mmbrs := []members.Member{
+		// matches, ranks agree
+		{Id: "1", Name: "alpha", Rank: ranks.Technician, PrimaryOrg: "ORG"},
+		// matches, rank is off
+		{Id: "2", Name: "Bravo", Rank: ranks.Specialist, PrimaryOrg: "ORG"},
+		// claims membership but isn't listed
+		{Id: "3", Name: "Delta", Rank: ranks.Member, PrimaryOrg: "ORG"},
+		// a guest that isn't listed is fine
+		{Id: "4", Name: "Echo", Rank: ranks.Guest, IsGuest: true},
+		// left the server, so ignored
+		{Id: "5", Name: "Foxtrot", Rank: ranks.Member, LeftAt: &left},
+	}
+
+	report := Reconcile("ORG", roster, mmbrs)
+
+	if len(report.NotOnDiscord) != 1 || report.NotOnDiscord[0].Handle != "Charlie" {
+		t.Errorf("expected Charlie not on discord, got %+v", report.NotOnDiscord)
+	}
+	if len(report.NotOnRoster) != 1 || report.NotOnRoster[0].Id != "3" {
+		t.Errorf("expected Delta not on the roster, got %+v", report.NotOnRoster)
+	}
+	if len(report.RankMismatches) != 1 || report.RankMismatches[0].Member.Id != "2" || report.RankMismatches[0].Expected != ranks.Member {
+		t.Errorf("expected Bravo's rank to mismatch, got %+v", report.RankMismatches)
+	}
+	if report.Unlisted != 1 {
+		t.Errorf("expected 1 unlisted member, got %d", report.Unlisted)
+	}
+}
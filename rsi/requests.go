@@ -0,0 +1,143 @@
+package rsi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gocolly/colly/v2"
+	"github.com/sol-armada/sol-bot/settings"
+)
+
+// ErrRateLimited is returned without calling RSI while requests are backing off after a 403 or 429
+var ErrRateLimited = errors.New("rsi is rate limiting requests, backing off")
+
+const (
+	minBackoff = time.Minute
+	maxBackoff = 30 * time.Minute
+)
+
+// requestGate is a token bucket shared by every RSIClient so the whole process stays under RSI's limits.
+// When RSI refuses a request, every request fails fast until the backoff passes, doubling on each refusal
+type requestGate struct {
+	mu     sync.Mutex
+	rate   float64 // tokens per second
+	burst  float64
+	tokens float64
+	last   time.Time
+
+	backoff      time.Duration
+	blockedUntil time.Time
+}
+
+func newRequestGate(perMinute, burst int) *requestGate {
+	return &requestGate{
+		rate:   float64(perMinute) / 60,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+var (
+	requests     *requestGate
+	requestsOnce sync.Once
+)
+
+func gate() *requestGate {
+	requestsOnce.Do(func() {
+		requests = newRequestGate(
+			max(settings.GetIntWithDefault("RSI.REQUESTS_PER_MINUTE", 30), 1),
+			max(settings.GetIntWithDefault("RSI.BURST", 5), 1),
+		)
+	})
+	return requests
+}
+
+// wait takes a token, waiting for one if needed. Returns ErrRateLimited while backing off
+func (g *requestGate) wait(ctx context.Context) error {
+	for {
+		g.mu.Lock()
+		now := time.Now()
+		if now.Before(g.blockedUntil) {
+			g.mu.Unlock()
+			return ErrRateLimited
+		}
+
+		g.tokens = min(g.burst, g.tokens+now.Sub(g.last).Seconds()*g.rate)
+		g.last = now
+
+		if g.tokens >= 1 {
+			g.tokens--
+			g.mu.Unlock()
+			return nil
+		}
+
+		delay := time.Duration((1 - g.tokens) / g.rate * float64(time.Second))
+		g.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+// throttled starts or extends the backoff after RSI refused a request
+func (g *requestGate) throttled() time.Duration {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.backoff = min(max(g.backoff*2, minBackoff), maxBackoff)
+	g.blockedUntil = time.Now().Add(g.backoff)
+	return g.backoff
+}
+
+// succeeded resets the backoff
+func (g *requestGate) succeeded() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.backoff = 0
+}
+
+// fetch requests the page through the shared gate and maps the response status to an error.
+// Every request to RSI goes through here
+func (client *RSIClient) fetch(ctx context.Context, url string) ([]byte, error) {
+	g := gate()
+	if err := g.wait(ctx); err != nil {
+		return nil, err
+	}
+
+	c := client.createCollector()
+
+	status := 0
+	var body []byte
+	c.OnResponse(func(r *colly.Response) {
+		status = r.StatusCode
+		body = r.Body
+	})
+	c.OnError(func(r *colly.Response, _ error) {
+		status = r.StatusCode
+	})
+
+	visitErr := c.Visit(url)
+
+	switch {
+	case status == 403 || status == 429:
+		g.throttled()
+		return nil, ErrForbidden
+	case status == 404:
+		return nil, ErrUserNotFound
+	case status >= 200 && status < 300:
+		g.succeeded()
+		return body, nil
+	case visitErr != nil:
+		return nil, fmt.Errorf("%w: %v", ErrRequestFailed, visitErr)
+	default:
+		return nil, fmt.Errorf("%w: status code %d", ErrRequestFailed, status)
+	}
+}
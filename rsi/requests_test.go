@@ -0,0 +1,77 @@
+package rsi
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRequestGate(t *testing.T) {
+	g := newRequestGate(60, 2)
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		if err := g.wait(ctx); err != nil {
+			t.Fatalf("wait %d within burst: %v", i, err)
+		}
+	}
+
+	// the bucket is empty so the next request has to wait for a token
+	timeout, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if err := g.wait(timeout); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("wait on empty bucket = %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	if backoff := g.throttled(); backoff != minBackoff {
+		t.Errorf("first backoff = %s, want %s", backoff, minBackoff)
+	}
+	if backoff := g.throttled(); backoff != 2*minBackoff {
+		t.Errorf("second backoff = %s, want %s", backoff, 2*minBackoff)
+	}
+	if err := g.wait(ctx); !errors.Is(err, ErrRateLimited) {
+		t.Errorf("wait while backing off = %v, want %v", err, ErrRateLimited)
+	}
+
+	g.succeeded()
+	g.blockedUntil = time.Time{}
+	if backoff := g.throttled(); backoff != minBackoff {
+		t.Errorf("backoff after success = %s, want %s", backoff, minBackoff)
+	}
+}
+
+func TestCached(t *testing.T) {
+	SetCache(NewMemoryCache())
+	defer SetCache(nil)
+
+	loads := 0
+	load := func() (citizenOrgs, error) {
+		loads++
+		return citizenOrgs{PrimaryOrg: "SOLARMADA", Affiliations: []string{"ALLY"}}, nil
+	}
+
+	for i := 0; i < 2; i++ {
+		orgs, err := cached("citizen-orgs:test", load)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if orgs.PrimaryOrg != "SOLARMADA" || len(orgs.Affiliations) != 1 || orgs.Affiliations[0] != "ALLY" {
+			t.Errorf("cached orgs = %+v", orgs)
+		}
+	}
+	if loads != 1 {
+		t.Errorf("loaded %d times, want 1", loads)
+	}
+
+	failed := func() ([]RosterMember, error) { return nil, ErrForbidden }
+	if _, err := cached("roster:test", failed); !errors.Is(err, ErrForbidden) {
+		t.Errorf("err = %v, want %v", err, ErrForbidden)
+	}
+	roster, err := cached("roster:test", func() ([]RosterMember, error) {
+		return []RosterMember{{Handle: "someone"}}, nil
+	})
+	if err != nil || len(roster) != 1 {
+		t.Errorf("failures should not be cached, got %v %v", roster, err)
+	}
+}
@@ -0,0 +1,86 @@
+package rsi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+type Visibility string
+
+const (
+	VisibilityVisible  Visibility = "visible"
+	VisibilityRedacted Visibility = "redacted"
+	VisibilityHidden   Visibility = "hidden"
+)
+
+// RosterMember is a member listed on the org's members page. Redacted and hidden members have no handle
+type RosterMember struct {
+	Handle      string
+	DisplayName string
+	Rank        string
+	Stars       int
+	Visibility  Visibility
+}
+
+// GetRosterPage retrieves one page of the organization's members
+// URL: https://robertsspaceindustries.com/orgs/SOLARMADA/members?page=1&pagesize=32
+func (client *RSIClient) GetRosterPage(ctx context.Context, page int, pageSize int) ([]RosterMember, error) {
+	if client.orgSID == "" {
+		return nil, fmt.Errorf("%w: organization SID is required", ErrInvalidConfig)
+	}
+
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 100
+	}
+
+	key := fmt.Sprintf("roster:%s:%d:%d", strings.ToLower(client.orgSID), page, pageSize)
+	return cached(key, func() ([]RosterMember, error) {
+		return client.fetchRosterPage(ctx, page, pageSize)
+	})
+}
+
+func (client *RSIClient) fetchRosterPage(ctx context.Context, page int, pageSize int) ([]RosterMember, error) {
+	url := fmt.Sprintf("%s/orgs/%s/members?page=%d&pagesize=%d", client.baseURL, client.orgSID, page, pageSize)
+	body, err := client.fetch(ctx, url)
+	if err != nil {
+		if errors.Is(err, ErrUserNotFound) {
+			return nil, fmt.Errorf("%w: organization %s not found", ErrRequestFailed, client.orgSID)
+		}
+		return nil, err
+	}
+
+	return parseRoster(body)
+}
+
+// GetRoster retrieves every member of the organization by paginating through all pages
+func (client *RSIClient) GetRoster(ctx context.Context) ([]RosterMember, error) {
+	var roster []RosterMember
+	page := 1
+	pageSize := 100
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		members, err := client.GetRosterPage(ctx, page, pageSize)
+		if err != nil {
+			return nil, err
+		}
+
+		roster = append(roster, members...)
+
+		if len(members) < pageSize {
+			break
+		}
+
+		page++
+	}
+
+	return roster, nil
+}
@@ -0,0 +1,369 @@
+package rsi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sol-armada/sol-bot/members"
+	"github.com/sol-armada/sol-bot/ranks"
+)
+
+// fixtureRoutes maps request paths to the recorded page served for them
+var fixtureRoutes = map[string]string{
+	"/citizens/MainOrgPilot/organizations":   "citizen_main_org.html",
+	"/citizens/AffiliatePilot/organizations": "citizen_affiliate.html",
+	"/citizens/RedactedPilot/organizations":  "citizen_redacted.html",
+	"/citizens/LonePilot/organizations":      "citizen_no_org.html",
+	"/citizens/MainOrgPilot":                 "citizen_profile.html",
+	"/citizens/LonePilot":                    "citizen_profile_new.html",
+	"/orgs/SOLARMADA/admin/applications":     "applications.html",
+	"/orgs/SOLARMADA/members":                "roster.html",
+}
+
+func readFixture(t *testing.T, name string) []byte {
+	t.Helper()
+	body, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return body
+}
+
+// newFixtureClient starts a server for the recorded pages and returns a client pointed at it along with
+// how many requests the server has handled. Every test gets its own request gate so backoff doesn't leak
+func newFixtureClient(t *testing.T) (*RSIClient, *atomic.Int32) {
+	t.Helper()
+
+	requestsOnce.Do(func() {})
+	requests = newRequestGate(60000, 1000)
+
+	hits := &atomic.Int32{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+
+		if strings.HasPrefix(r.URL.Path, "/citizens/Throttled") {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+
+		fixture, ok := fixtureRoutes[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write(readFixture(t, "citizen_not_found.html"))
+			return
+		}
+		_, _ = w.Write(readFixture(t, fixture))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(Config{
+		Token:   "token",
+		Device:  "device",
+		OrgSID:  "SOLARMADA",
+		Allies:  []string{"FRIENDLY"},
+		BaseURL: server.URL,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return client, hits
+}
+
+func TestUpdateRsiInfo(t *testing.T) {
+	client, _ := newFixtureClient(t)
+
+	tests := []struct {
+		handle       string
+		err          error
+		rsiMember    bool
+		primaryOrg   string
+		rank         ranks.Rank
+		guest        bool
+		affiliate    bool
+		ally         bool
+		affiliations []string
+	}{
+		{handle: "MainOrgPilot", rsiMember: true, primaryOrg: "SOLARMADA", rank: ranks.Technician, affiliations: []string{"FRIENDLY"}},
+		{handle: "AffiliatePilot", rsiMember: true, primaryOrg: "FRIENDLY", rank: ranks.Member, affiliate: true, ally: true, affiliations: []string{"SOLARMADA", "HAULERS"}},
+		{handle: "RedactedPilot", rsiMember: true, primaryOrg: "REDACTED", rank: ranks.Member, guest: true, affiliate: true, affiliations: []string{"SOLARMADA"}},
+		{handle: "LonePilot", rsiMember: true, primaryOrg: "", rank: ranks.None, guest: true, affiliations: []string{}},
+		{handle: "Missing.Pilot", err: ErrUserNotFound, rank: ranks.None, guest: true, affiliations: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.handle, func(t *testing.T) {
+			member := &members.Member{Name: tt.handle, Rank: ranks.Admiral, RSIMember: true}
+
+			err := client.UpdateRsiInfo(context.Background(), member)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("err = %v, want %v", err, tt.err)
+			}
+
+			if member.RSIMember != tt.rsiMember {
+				t.Errorf("RSIMember = %t, want %t", member.RSIMember, tt.rsiMember)
+			}
+			if member.PrimaryOrg != tt.primaryOrg {
+				t.Errorf("PrimaryOrg = %q, want %q", member.PrimaryOrg, tt.primaryOrg)
+			}
+			if member.Rank != tt.rank {
+				t.Errorf("Rank = %s, want %s", member.Rank, tt.rank)
+			}
+			if member.IsGuest != tt.guest {
+				t.Errorf("IsGuest = %t, want %t", member.IsGuest, tt.guest)
+			}
+			if member.IsAffiliate != tt.affiliate {
+				t.Errorf("IsAffiliate = %t, want %t", member.IsAffiliate, tt.affiliate)
+			}
+			if member.IsAlly != tt.ally {
+				t.Errorf("IsAlly = %t, want %t", member.IsAlly, tt.ally)
+			}
+			if strings.Join(member.Affilations, ",") != strings.Join(tt.affiliations, ",") {
+				t.Errorf("Affilations = %v, want %v", member.Affilations, tt.affiliations)
+			}
+		})
+	}
+}
+
+func TestIsMemberOfOrg(t *testing.T) {
+	client, _ := newFixtureClient(t)
+
+	tests := []struct {
+		handle string
+		org    string
+		want   bool
+		err    error
+	}{
+		{handle: "MainOrgPilot", org: "SOLARMADA", want: true},
+		{handle: "MainOrgPilot", org: "friendly", want: true},
+		{handle: "AffiliatePilot", org: "SOLARMADA", want: true},
+		{handle: "RedactedPilot", org: "SOLARMADA", want: true},
+		{handle: "LonePilot", org: "SOLARMADA", want: false},
+		{handle: "MainOrgPilot", org: "NONE", want: false},
+		{handle: "MissingPilot", org: "SOLARMADA", err: ErrUserNotFound},
+	}
+
+	for _, tt := range tests {
+		got, err := client.IsMemberOfOrg(context.Background(), tt.handle, tt.org)
+		if !errors.Is(err, tt.err) {
+			t.Errorf("IsMemberOfOrg(%s, %s) err = %v, want %v", tt.handle, tt.org, err, tt.err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("IsMemberOfOrg(%s, %s) = %t, want %t", tt.handle, tt.org, got, tt.want)
+		}
+	}
+}
+
+func TestValidHandle(t *testing.T) {
+	client, _ := newFixtureClient(t)
+
+	if valid, err := client.ValidHandle(context.Background(), "LonePilot"); err != nil || !valid {
+		t.Errorf("LonePilot should be a valid handle, got %t, %v", valid, err)
+	}
+	if valid, err := client.ValidHandle(context.Background(), "MissingPilot"); err != nil || valid {
+		t.Errorf("MissingPilot should not be a valid handle, got %t, %v", valid, err)
+	}
+}
+
+func TestGetBio(t *testing.T) {
+	client, _ := newFixtureClient(t)
+
+	bio, err := client.GetBio(context.Background(), "MainOrgPilot")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "Flying with Sol Armada since 2950. sol-verify-4f2a9c"; bio != want {
+		t.Errorf("bio = %q, want %q", bio, want)
+	}
+
+	if _, err := client.GetBio(context.Background(), "MissingPilot"); !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("missing citizen err = %v, want %v", err, ErrUserNotFound)
+	}
+}
+
+func TestGetDossier(t *testing.T) {
+	client, _ := newFixtureClient(t)
+
+	dossier, err := client.GetDossier(context.Background(), "MainOrgPilot")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if dossier.Handle != "MainOrgPilot" || dossier.Moniker != "Main Org Pilot" || dossier.Title != "Colonel" {
+		t.Errorf("names = %q %q %q", dossier.Handle, dossier.Moniker, dossier.Title)
+	}
+	if dossier.RecordNumber != "#1234567" {
+		t.Errorf("RecordNumber = %q, want #1234567", dossier.RecordNumber)
+	}
+	if want := time.Date(2016, time.January, 5, 0, 0, 0, 0, time.UTC); !dossier.Enlisted.Equal(want) {
+		t.Errorf("Enlisted = %s, want %s", dossier.Enlisted, want)
+	}
+	if dossier.Location != "United States, California" {
+		t.Errorf("Location = %q", dossier.Location)
+	}
+	if strings.Join(dossier.Fluency, ",") != "English,German" {
+		t.Errorf("Fluency = %v", dossier.Fluency)
+	}
+	if !strings.HasSuffix(dossier.Avatar, "/media/avatars/mainorgpilot.jpg") || !strings.HasPrefix(dossier.Avatar, "http") {
+		t.Errorf("Avatar = %q, want an absolute url", dossier.Avatar)
+	}
+
+	now := time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC)
+	if warnings := dossier.Warnings(now); len(warnings) != 0 {
+		t.Errorf("established account has warnings %v", warnings)
+	}
+
+	dossier, err = client.GetDossier(context.Background(), "LonePilot")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dossier.RecordNumber != "" || len(dossier.Fluency) != 0 {
+		t.Errorf("new account record %q fluency %v", dossier.RecordNumber, dossier.Fluency)
+	}
+	want := []string{"Enlisted 9 days ago", "No citizen record", "Default avatar"}
+	if warnings := dossier.Warnings(now); strings.Join(warnings, ",") != strings.Join(want, ",") {
+		t.Errorf("warnings = %v, want %v", warnings, want)
+	}
+
+	if _, err := client.GetDossier(context.Background(), "MissingPilot"); !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("missing citizen err = %v, want %v", err, ErrUserNotFound)
+	}
+}
+
+func TestGetApplications(t *testing.T) {
+	client, _ := newFixtureClient(t)
+
+	apps, err := client.GetApplications(context.Background(), 1, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Application{
+		{Handle: "NewRecruit", Note: "Heard about you from a friend, looking for a mining crew.", Date: "Oct 17, 2026", Avatar: "/media/avatars/newrecruit.jpg"},
+		{Handle: "Quiet.Pilot", Date: "Oct 18, 2026"},
+	}
+	if len(apps) != len(want) {
+		t.Fatalf("got %d applications, want %d: %+v", len(apps), len(want), apps)
+	}
+	for i := range want {
+		if apps[i] != want[i] {
+			t.Errorf("application %d = %+v, want %+v", i, apps[i], want[i])
+		}
+	}
+}
+
+func TestGetRoster(t *testing.T) {
+	client, _ := newFixtureClient(t)
+
+	roster, err := client.GetRoster(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []RosterMember{
+		{Handle: "MainOrgPilot", DisplayName: "Main Org Pilot", Rank: "Technician", Stars: 2, Visibility: VisibilityVisible},
+		{Rank: "Member", Stars: 1, Visibility: VisibilityRedacted},
+		{Rank: "Member", Visibility: VisibilityHidden},
+	}
+	if len(roster) != len(want) {
+		t.Fatalf("got %d roster members, want %d: %+v", len(roster), len(want), roster)
+	}
+	for i := range want {
+		if roster[i] != want[i] {
+			t.Errorf("roster member %d = %+v, want %+v", i, roster[i], want[i])
+		}
+	}
+}
+
+func TestRequestsUseCache(t *testing.T) {
+	client, hits := newFixtureClient(t)
+	SetCache(NewMemoryCache())
+	defer SetCache(nil)
+
+	for i := 0; i < 2; i++ {
+		member := &members.Member{Name: "MainOrgPilot"}
+		if err := client.UpdateRsiInfo(context.Background(), member); err != nil {
+			t.Fatal(err)
+		}
+		if member.PrimaryOrg != "SOLARMADA" {
+			t.Errorf("PrimaryOrg = %q, want SOLARMADA", member.PrimaryOrg)
+		}
+	}
+	if ok, _ := client.IsMemberOfOrg(context.Background(), "MainOrgPilot", "SOLARMADA"); !ok {
+		t.Error("cached page should still show the org")
+	}
+
+	for i := 0; i < 2; i++ {
+		dossier, err := client.GetDossier(context.Background(), "MainOrgPilot")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if dossier.RecordNumber != "#1234567" || len(dossier.Fluency) != 2 {
+			t.Errorf("dossier = %+v", dossier)
+		}
+	}
+
+	if got := hits.Load(); got != 2 {
+		t.Errorf("server was hit %d times, want 1", got)
+	}
+}
+
+func TestRequestsBackOffWhenThrottled(t *testing.T) {
+	client, hits := newFixtureClient(t)
+
+	if _, err := client.GetBio(context.Background(), "Throttled"); !errors.Is(err, ErrForbidden) {
+		t.Fatalf("throttled err = %v, want %v", err, ErrForbidden)
+	}
+
+	// every request fails fast until the backoff passes, without reaching RSI
+	if _, err := client.GetBio(context.Background(), "MainOrgPilot"); !errors.Is(err, ErrRateLimited) {
+		t.Errorf("err while backing off = %v, want %v", err, ErrRateLimited)
+	}
+	if got := hits.Load(); got != 1 {
+		t.Errorf("server was hit %d times, want 1", got)
+	}
+}
+
+func TestCheckSelectors(t *testing.T) {
+	client, _ := newFixtureClient(t)
+
+	checks, err := client.CheckSelectors(context.Background(), "MainOrgPilot")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, check := range checks {
+		if !check.Healthy() {
+			t.Errorf("%s on %s page should be healthy, matched %d", check.Name, check.Page, check.Matches)
+		}
+	}
+
+	// a page missing the main org looks like RSI changed its markup
+	checks, err = checkPage("organizations", readFixture(t, "citizen_no_org.html"), citizenOrgsSelectors)
+	if err != nil {
+		t.Fatal(err)
+	}
+	broken := map[string]bool{}
+	for _, check := range checks {
+		if !check.Healthy() {
+			broken[check.Name] = true
+		}
+	}
+	for _, name := range []string{primaryOrgSIDSelector.name, primaryOrgRankSelector.name} {
+		if !broken[name] {
+			t.Errorf("%s should be broken on a page without a main org", name)
+		}
+	}
+	if broken[affiliationSIDSelector.name] {
+		t.Error("affiliations are optional and should not be reported broken")
+	}
+}
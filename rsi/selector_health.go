@@ -0,0 +1,74 @@
+package rsi
+
+import (
+	"context"
+	"fmt"
+)
+
+// SelectorCheck is how many nodes one of the scraper's selectors matched on a live page
+type SelectorCheck struct {
+	Page     string
+	Name     string
+	Matches  int
+	Required bool
+}
+
+// Healthy is false when a required selector matched nothing, meaning RSI changed the page
+func (c SelectorCheck) Healthy() bool {
+	return !c.Required || c.Matches > 0
+}
+
+type selectorPage struct {
+	name      string
+	url       string
+	selectors []selector
+}
+
+// CheckSelectors fetches live pages for the handle and reports how each selector matched them.
+// The handle should be a visible member of the org's main org so every required selector has something to match.
+// Pages are never read from the cache
+func (client *RSIClient) CheckSelectors(ctx context.Context, handle string) ([]SelectorCheck, error) {
+	pages := []selectorPage{
+		{"organizations", fmt.Sprintf("%s/citizens/%s/organizations", client.baseURL, handle), citizenOrgsSelectors},
+		{"profile", fmt.Sprintf("%s/citizens/%s", client.baseURL, handle), profileSelectors},
+	}
+	if client.orgSID != "" {
+		roster := fmt.Sprintf("%s/orgs/%s/members?page=1&pagesize=32", client.baseURL, client.orgSID)
+		pages = append(pages, selectorPage{"roster", roster, []selector{rosterMemberSelector}})
+	}
+
+	checks := []SelectorCheck{}
+	for _, page := range pages {
+		body, err := client.fetch(ctx, page.url)
+		if err != nil {
+			return nil, fmt.Errorf("fetching %s page: %w", page.name, err)
+		}
+
+		pageChecks, err := checkPage(page.name, body, page.selectors)
+		if err != nil {
+			return nil, err
+		}
+		checks = append(checks, pageChecks...)
+	}
+
+	return checks, nil
+}
+
+func checkPage(page string, body []byte, selectors []selector) ([]SelectorCheck, error) {
+	checks := make([]SelectorCheck, 0, len(selectors))
+	for _, s := range selectors {
+		matches, err := s.count(body)
+		if err != nil {
+			return nil, fmt.Errorf("checking %s on %s page: %w", s.name, page, err)
+		}
+
+		checks = append(checks, SelectorCheck{
+			Page:     page,
+			Name:     s.name,
+			Matches:  matches,
+			Required: s.required,
+		})
+	}
+
+	return checks, nil
+}
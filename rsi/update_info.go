@@ -1,6 +1,7 @@
 package rsi
 
 import (
+	"context"
 	"errors"
 	"log/slog"
 
@@ -15,12 +16,45 @@ func UpdateMemberRSIInfo(member *members.Member, rsiBackoff *utils.ExponentialBa
 		return UpdateRsiInfo(member)
 	})
 
+	if err == nil {
+		RefreshDossier(member, logger)
+		return nil
+	}
+
 	if errors.Is(err, ErrUserNotFound) {
 		logger.Debug("rsi user not found", "error", err)
 		member.RSIMember = false
+		member.Dossier = nil
 		return nil
 	}
 
 	logger.Warn("failed to update RSI info after retries", "error", err)
 	return err
 }
+
+// RefreshDossier updates the member's dossier when it is stale
+func RefreshDossier(member *members.Member, logger *slog.Logger) {
+	if member.Dossier.Stale() {
+		UpdateDossier(member, logger)
+	}
+}
+
+// UpdateDossier replaces the member's dossier. A failed update keeps the old dossier
+func UpdateDossier(member *members.Member, logger *slog.Logger) {
+	if err := initDefaultClient(); err != nil {
+		logger.Warn("refreshing dossier", "error", err)
+		return
+	}
+
+	dossier, err := defaultClient.GetDossier(context.Background(), member.Handle())
+	if err != nil {
+		if errors.Is(err, ErrUserNotFound) {
+			member.Dossier = nil
+			return
+		}
+		logger.Warn("refreshing dossier", "error", err)
+		return
+	}
+
+	member.Dossier = dossier
+}
@@ -1,6 +1,8 @@
 package settings
 
 import (
+	"time"
+
 	"github.com/spf13/viper"
 )
 
@@ -57,6 +59,20 @@ func GetIntWithDefault(key string, val int) int {
 	return setting.GetInt(key)
 }
 
+func GetFloat64WithDefault(key string, val float64) float64 {
+	if !setting.IsSet(key) {
+		return val
+	}
+	return setting.GetFloat64(key)
+}
+
+func GetDurationWithDefault(key string, val time.Duration) time.Duration {
+	if !setting.IsSet(key) {
+		return val
+	}
+	return setting.GetDuration(key)
+}
+
 func GetString(key string) string {
 	return setting.GetString(key)
 }
@@ -0,0 +1,53 @@
+package stores
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type AlliesStore struct {
+	*store
+}
+
+const ALLIES Collection = "allies"
+
+func newAlliesStore(ctx context.Context, client *mongo.Client, database string) *AlliesStore {
+	_ = client.Database(database).CreateCollection(ctx, string(ALLIES))
+	s := &store{
+		Collection: client.Database(database).Collection(string(ALLIES)),
+		ctx:        ctx,
+	}
+	return &AlliesStore{s}
+}
+
+func (c *Client) GetAlliesStore() (*AlliesStore, bool) {
+	if c.stores == nil {
+		return nil, false
+	}
+	return c.stores.allies, true
+}
+
+func (s *AlliesStore) Get(sid string) *mongo.SingleResult {
+	return s.FindOne(s.ctx, bson.D{{Key: "_id", Value: sid}})
+}
+
+func (s *AlliesStore) List() (*mongo.Cursor, error) {
+	return s.Find(s.ctx, bson.D{}, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+}
+
+func (s *AlliesStore) Upsert(sid string, ally any) error {
+	opts := options.Replace().SetUpsert(true)
+	_, err := s.ReplaceOne(s.ctx, bson.D{{Key: "_id", Value: sid}}, ally, opts)
+	return err
+}
+
+func (s *AlliesStore) Delete(sid string) (bool, error) {
+	res, err := s.DeleteOne(s.ctx, bson.D{{Key: "_id", Value: sid}})
+	if err != nil {
+		return false, err
+	}
+	return res.DeletedCount > 0, nil
+}
@@ -0,0 +1,45 @@
+package stores
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type ApplicationsStore struct {
+	*store
+}
+
+const APPLICATIONS Collection = "applications"
+
+func newApplicationsStore(ctx context.Context, client *mongo.Client, database string) *ApplicationsStore {
+	_ = client.Database(database).CreateCollection(ctx, string(APPLICATIONS))
+	s := &store{
+		Collection: client.Database(database).Collection(string(APPLICATIONS)),
+		ctx:        ctx,
+	}
+	return &ApplicationsStore{s}
+}
+
+func (c *Client) GetApplicationsStore() (*ApplicationsStore, bool) {
+	if c.stores == nil {
+		return nil, false
+	}
+	return c.stores.applications, true
+}
+
+func (s *ApplicationsStore) Get(id string) *mongo.SingleResult {
+	return s.FindOne(s.ctx, bson.D{{Key: "_id", Value: id}})
+}
+
+func (s *ApplicationsStore) Upsert(id string, application any) error {
+	opts := options.Replace().SetUpsert(true)
+	_, err := s.ReplaceOne(s.ctx, bson.D{{Key: "_id", Value: id}}, application, opts)
+	return err
+}
+
+func (s *ApplicationsStore) GetByStatus(status string) (*mongo.Cursor, error) {
+	return s.Find(s.ctx, bson.D{{Key: "status", Value: status}})
+}
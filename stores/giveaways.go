@@ -6,6 +6,7 @@ import (
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type GiveawaysStore struct {
@@ -36,16 +37,52 @@ func (g *GiveawaysStore) GetAll() (*mongo.Cursor, error) {
 	})
 }
 
-func (g *GiveawaysStore) UpsertAll(giveaways map[string]any) error {
-	var models []mongo.WriteModel
-	for id, giveaway := range giveaways {
-		models = append(models, mongo.NewReplaceOneModel().SetFilter(bson.D{{Key: "_id", Value: id}}).SetReplacement(giveaway).SetUpsert(true))
+// GetUnsettled returns ended giveaways whose token holds have not been settled
+func (g *GiveawaysStore) GetUnsettled() (*mongo.Cursor, error) {
+	return g.Find(g.ctx, bson.D{
+		{Key: "ended", Value: true},
+		{Key: "settled", Value: bson.M{"$ne": true}},
+	})
+}
+
+// History returns giveaways matching the filter, most recently ended first. A limit of 0 returns all of them
+func (g *GiveawaysStore) History(filter bson.D, limit int64) (*mongo.Cursor, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "endtime", Value: -1}})
+	if limit > 0 {
+		opts.SetLimit(limit)
 	}
+	return g.Find(g.ctx, filter, opts)
+}
+
+func (g *GiveawaysStore) Get(id string) *mongo.SingleResult {
+	return g.FindOne(g.ctx, bson.D{{Key: "_id", Value: id}})
+}
 
-	_, err := g.BulkWrite(g.ctx, models)
+func (g *GiveawaysStore) Insert(id string, giveaway any) error {
+	_, err := g.ReplaceOne(g.ctx, bson.D{{Key: "_id", Value: id}}, giveaway, options.Replace().SetUpsert(true))
 	if err != nil {
-		return errors.Join(err, errors.New("failed to upsert giveaways to store"))
+		return errors.Join(err, errors.New("failed to insert giveaway to store"))
 	}
-
 	return nil
 }
+
+// ReplaceVersion replaces the giveaway only if the stored version matches. Returns false if it did not.
+// Giveaways saved before versioning have no version and match version 0
+func (g *GiveawaysStore) ReplaceVersion(id string, version int, giveaway any) (bool, error) {
+	filter := bson.D{{Key: "_id", Value: id}}
+	if version == 0 {
+		filter = append(filter, bson.E{Key: "$or", Value: bson.A{
+			bson.M{"version": 0},
+			bson.M{"version": bson.M{"$exists": false}},
+		}})
+	} else {
+		filter = append(filter, bson.E{Key: "version", Value: version})
+	}
+
+	res, err := g.ReplaceOne(g.ctx, filter, giveaway)
+	if err != nil {
+		return false, errors.Join(err, errors.New("failed to update giveaway in store"))
+	}
+
+	return res.MatchedCount == 1, nil
+}
@@ -30,30 +30,38 @@ type Config struct {
 
 // StoreRegistry provides type-safe access to all stores
 type StoreRegistry struct {
-	members    *MembersStore
-	attendance *AttendanceStore
-	configs    *ConfigsStore
-	activity   *ActivityStore
-	sos        *SOSStore
-	tokens     *TokenStore
-	raffles    *RaffleStore
-	kanban     *KanbanStore
-	commands   *CommandsStore
-	giveaways  *GiveawaysStore
-	blueprints *BlueprintStore
+	members      *MembersStore
+	attendance   *AttendanceStore
+	configs      *ConfigsStore
+	activity     *ActivityStore
+	sos          *SOSStore
+	tokens       *TokenStore
+	tokenHolds   *TokenHoldStore
+	raffles      *RaffleStore
+	kanban       *KanbanStore
+	commands     *CommandsStore
+	giveaways    *GiveawaysStore
+	blueprints   *BlueprintStore
+	applications *ApplicationsStore
+	rsiCache     *RSICacheStore
+	allies       *AlliesStore
 }
 
 // Store accessor methods
-func (s *StoreRegistry) Members() *MembersStore       { return s.members }
-func (s *StoreRegistry) Attendance() *AttendanceStore { return s.attendance }
-func (s *StoreRegistry) Configs() *ConfigsStore       { return s.configs }
-func (s *StoreRegistry) Activity() *ActivityStore     { return s.activity }
-func (s *StoreRegistry) SOS() *SOSStore               { return s.sos }
-func (s *StoreRegistry) Tokens() *TokenStore          { return s.tokens }
-func (s *StoreRegistry) Raffles() *RaffleStore        { return s.raffles }
-func (s *StoreRegistry) Kanban() *KanbanStore         { return s.kanban }
-func (s *StoreRegistry) Commands() *CommandsStore     { return s.commands }
-func (s *StoreRegistry) Giveaways() *GiveawaysStore   { return s.giveaways }
+func (s *StoreRegistry) Members() *MembersStore           { return s.members }
+func (s *StoreRegistry) Attendance() *AttendanceStore     { return s.attendance }
+func (s *StoreRegistry) Configs() *ConfigsStore           { return s.configs }
+func (s *StoreRegistry) Activity() *ActivityStore         { return s.activity }
+func (s *StoreRegistry) SOS() *SOSStore                   { return s.sos }
+func (s *StoreRegistry) Tokens() *TokenStore              { return s.tokens }
+func (s *StoreRegistry) TokenHolds() *TokenHoldStore      { return s.tokenHolds }
+func (s *StoreRegistry) Raffles() *RaffleStore            { return s.raffles }
+func (s *StoreRegistry) Kanban() *KanbanStore             { return s.kanban }
+func (s *StoreRegistry) Commands() *CommandsStore         { return s.commands }
+func (s *StoreRegistry) Giveaways() *GiveawaysStore       { return s.giveaways }
+func (s *StoreRegistry) Applications() *ApplicationsStore { return s.applications }
+func (s *StoreRegistry) RSICache() *RSICacheStore         { return s.rsiCache }
+func (s *StoreRegistry) Allies() *AlliesStore             { return s.allies }
 
 type Client struct {
 	*mongo.Client
@@ -100,24 +108,32 @@ func New(ctx context.Context, host string, port int, username, password, databas
 	activityStore := newActivityStore(ctx, mongoClient, database)
 	sosStore := newSOSStore(ctx, mongoClient, database)
 	tokensStore := newTokensStore(ctx, mongoClient, database)
+	tokenHoldsStore := newTokenHoldsStore(ctx, mongoClient, database)
 	rafflesStore := newRafflesStore(ctx, mongoClient, database)
 	kanbanStore := newKanbanStore(ctx, mongoClient, database)
 	commandsStore := newCommandsStore(ctx, mongoClient, database)
 	giveawaysStore := newGiveawaysStore(ctx, mongoClient, database)
 	BlueprintStore := newBlueprintStore(ctx, mongoClient, database)
+	applicationsStore := newApplicationsStore(ctx, mongoClient, database)
+	rsiCacheStore := newRSICacheStore(ctx, mongoClient, database)
+	alliesStore := newAlliesStore(ctx, mongoClient, database)
 
 	storeRegistry := &StoreRegistry{
-		members:    membersStore,
-		configs:    configsStore,
-		attendance: attendanceStore,
-		activity:   activityStore,
-		sos:        sosStore,
-		tokens:     tokensStore,
-		raffles:    rafflesStore,
-		kanban:     kanbanStore,
-		commands:   commandsStore,
-		giveaways:  giveawaysStore,
-		blueprints: BlueprintStore,
+		members:      membersStore,
+		configs:      configsStore,
+		attendance:   attendanceStore,
+		activity:     activityStore,
+		sos:          sosStore,
+		tokens:       tokensStore,
+		tokenHolds:   tokenHoldsStore,
+		raffles:      rafflesStore,
+		kanban:       kanbanStore,
+		commands:     commandsStore,
+		giveaways:    giveawaysStore,
+		blueprints:   BlueprintStore,
+		applications: applicationsStore,
+		rsiCache:     rsiCacheStore,
+		allies:       alliesStore,
 	}
 
 	newClient := &Client{
@@ -145,6 +161,11 @@ func (c *Client) Stores() *StoreRegistry {
 	return c.stores
 }
 
+// Database returns the underlying mongo database. Used by migrations and maintenance tooling
+func (c *Client) Database() *mongo.Database {
+	return c.database
+}
+
 // GetCollection is deprecated, use Stores() instead
 func (c *Client) GetCollection(collection Collection) (any, bool) {
 	switch collection {
@@ -160,6 +181,8 @@ func (c *Client) GetCollection(collection Collection) (any, bool) {
 		return c.stores.sos, true
 	case TOKENS:
 		return c.stores.tokens, true
+	case TOKEN_HOLDS:
+		return c.stores.tokenHolds, true
 	case RAFFLES:
 		return c.stores.raffles, true
 	case KANBAN:
@@ -168,6 +191,12 @@ func (c *Client) GetCollection(collection Collection) (any, bool) {
 		return c.stores.commands, true
 	case GIVEAWAYS:
 		return c.stores.giveaways, true
+	case APPLICATIONS:
+		return c.stores.applications, true
+	case RSI_CACHE:
+		return c.stores.rsiCache, true
+	case ALLIES:
+		return c.stores.allies, true
 	default:
 		return nil, false
 	}
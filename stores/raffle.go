@@ -40,6 +40,15 @@ func (s *RaffleStore) GetLatest() (*mongo.Cursor, error) {
 	return s.Find(s.ctx, bson.D{}, opts)
 }
 
+// List returns raffles matching the filter, newest first. A limit of 0 returns all of them
+func (s *RaffleStore) List(filter bson.D, limit int64) (*mongo.Cursor, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "createdat", Value: -1}})
+	if limit > 0 {
+		opts.SetLimit(limit)
+	}
+	return s.Find(s.ctx, filter, opts)
+}
+
 func (s *RaffleStore) Upsert(id string, raffle any) error {
 	opts := options.FindOneAndReplace().SetUpsert(true)
 	if err := s.FindOneAndReplace(s.ctx, bson.D{{Key: "_id", Value: id}}, raffle, opts).Err(); err != nil {
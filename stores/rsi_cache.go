@@ -0,0 +1,51 @@
+package stores
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type RSICacheStore struct {
+	*store
+}
+
+const RSI_CACHE Collection = "rsi_cache"
+
+func newRSICacheStore(ctx context.Context, client *mongo.Client, database string) *RSICacheStore {
+	_ = client.Database(database).CreateCollection(ctx, string(RSI_CACHE))
+	s := &store{
+		Collection: client.Database(database).Collection(string(RSI_CACHE)),
+		ctx:        ctx,
+	}
+	return &RSICacheStore{s}
+}
+
+func (c *Client) GetRSICacheStore() (*RSICacheStore, bool) {
+	if c.stores == nil {
+		return nil, false
+	}
+	return c.stores.rsiCache, true
+}
+
+// Get returns the entry if it has not expired. Mongo's TTL monitor only removes expired entries
+// about once a minute, so expiry is checked here too
+func (s *RSICacheStore) Get(key string) *mongo.SingleResult {
+	return s.FindOne(s.ctx, bson.D{
+		{Key: "_id", Value: key},
+		{Key: "expires_at", Value: bson.M{"$gt": time.Now().UTC()}},
+	})
+}
+
+func (s *RSICacheStore) Set(key string, value any, expiresAt time.Time) error {
+	opts := options.Replace().SetUpsert(true)
+	_, err := s.ReplaceOne(s.ctx, bson.D{{Key: "_id", Value: key}}, bson.D{
+		{Key: "_id", Value: key},
+		{Key: "value", Value: value},
+		{Key: "expires_at", Value: expiresAt.UTC()},
+	}, opts)
+	return err
+}
@@ -0,0 +1,49 @@
+package stores
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type TokenHoldStore struct {
+	*store
+}
+
+const TOKEN_HOLDS Collection = "token_holds"
+
+func newTokenHoldsStore(ctx context.Context, client *mongo.Client, database string) *TokenHoldStore {
+	_ = client.Database(database).CreateCollection(ctx, string(TOKEN_HOLDS))
+	s := &store{
+		Collection: client.Database(database).Collection(string(TOKEN_HOLDS)),
+		ctx:        ctx,
+	}
+	return &TokenHoldStore{s}
+}
+
+func (c *Client) GetTokenHoldsStore() (*TokenHoldStore, bool) {
+	if c.stores == nil {
+		return nil, false
+	}
+	return c.stores.tokenHolds, true
+}
+
+func (s *TokenHoldStore) Get(id string) *mongo.SingleResult {
+	return s.FindOne(s.ctx, bson.D{{Key: "_id", Value: id}})
+}
+
+func (s *TokenHoldStore) Upsert(id string, hold any) error {
+	opts := options.Replace().SetUpsert(true)
+	_, err := s.ReplaceOne(s.ctx, bson.D{{Key: "_id", Value: id}}, hold, opts)
+	return err
+}
+
+func (s *TokenHoldStore) GetByMemberIdAndStatus(memberId, status string) (*mongo.Cursor, error) {
+	return s.Find(s.ctx, bson.D{{Key: "status", Value: status}, {Key: "member_id", Value: memberId}})
+}
+
+func (s *TokenHoldStore) GetByReference(reference string) (*mongo.Cursor, error) {
+	return s.Find(s.ctx, bson.D{{Key: "reference", Value: reference}})
+}
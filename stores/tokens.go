@@ -101,6 +101,14 @@ func (s *TokenStore) GetAllBalances() (*mongo.Cursor, error) {
 	return cursor, nil
 }
 
+func (s *TokenStore) GetByMemberId(memberId string) (*mongo.Cursor, error) {
+	cursor, err := s.Find(s.ctx, bson.D{{Key: "member_id", Value: memberId}})
+	if err != nil {
+		return nil, err
+	}
+	return cursor, nil
+}
+
 func (s *TokenStore) GetByMemberIdAndAttendanceId(memberId, attendanceId string) (*mongo.Cursor, error) {
 	filter := bson.D{
 		{Key: "member_id", Value: memberId},
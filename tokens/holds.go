@@ -0,0 +1,254 @@
+package tokens
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+type HoldStatus string
+
+const (
+	HoldActive   HoldStatus = "active"
+	HoldConsumed HoldStatus = "consumed"
+	HoldReleased HoldStatus = "released"
+)
+
+// Hold reserves part of a member's balance for something like a raffle entry.
+// Active holds reduce the available balance until they are consumed or released
+type Hold struct {
+	Id        string     `json:"id" bson:"_id"`
+	MemberId  string     `json:"member_id" bson:"member_id"`
+	Reference string     `json:"reference" bson:"reference"`
+	Amount    int        `json:"amount" bson:"amount"`
+	Status    HoldStatus `json:"status" bson:"status"`
+	CreatedAt time.Time  `json:"created_at" bson:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at" bson:"updated_at"`
+}
+
+var (
+	ErrHoldNotFound       = errors.New("hold not found")
+	ErrInsufficientTokens = errors.New("insufficient tokens")
+	ErrInvalidHoldAmount  = errors.New("hold amount must be at least 1")
+	ErrRecordExists       = errors.New("token record already exists")
+)
+
+// holdMu serializes balance checks and hold writes so two entries can't spend the same tokens
+var holdMu sync.Mutex
+
+// a member can only have one hold per reference
+func holdId(memberId, reference string) string {
+	return reference + ":" + memberId
+}
+
+func GetHeldByMemberId(memberId string) (int, error) {
+	return tokenStore.GetActiveHeldByMemberId(memberId)
+}
+
+// GetAvailableBalanceByMemberId returns the balance minus all active holds
+func GetAvailableBalanceByMemberId(memberId string) (int, error) {
+	balance, err := GetBalanceByMemberId(memberId)
+	if err != nil {
+		return 0, err
+	}
+
+	held, err := GetHeldByMemberId(memberId)
+	if err != nil {
+		return 0, err
+	}
+
+	return balance - held, nil
+}
+
+// GetAvailableForHold returns how much the member could hold against the reference,
+// counting tokens already held for it since a new hold replaces the old one
+func GetAvailableForHold(memberId, reference string) (int, error) {
+	available, err := GetAvailableBalanceByMemberId(memberId)
+	if err != nil {
+		return 0, err
+	}
+
+	hold, err := GetHold(memberId, reference)
+	if err != nil {
+		if errors.Is(err, ErrHoldNotFound) {
+			return available, nil
+		}
+		return 0, err
+	}
+
+	return available + hold.Amount, nil
+}
+
+// GetHold returns the member's active hold for the reference
+func GetHold(memberId, reference string) (*Hold, error) {
+	hold, err := tokenStore.GetHold(holdId(memberId, reference))
+	if err != nil {
+		return nil, err
+	}
+
+	if hold.Status != HoldActive {
+		return nil, ErrHoldNotFound
+	}
+
+	return hold, nil
+}
+
+// PlaceHold holds amount tokens against the reference, replacing any active hold the member already has on it
+func PlaceHold(memberId, reference string, amount int) (*Hold, error) {
+	if amount < 1 {
+		return nil, ErrInvalidHoldAmount
+	}
+
+	holdMu.Lock()
+	defer holdMu.Unlock()
+
+	available, err := GetAvailableForHold(memberId, reference)
+	if err != nil {
+		return nil, err
+	}
+
+	if amount > available {
+		return nil, ErrInsufficientTokens
+	}
+
+	now := time.Now().UTC()
+	hold := &Hold{
+		Id:        holdId(memberId, reference),
+		MemberId:  memberId,
+		Reference: reference,
+		Amount:    amount,
+		Status:    HoldActive,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if existing, err := tokenStore.GetHold(hold.Id); err == nil && existing.Status == HoldActive {
+		hold.CreatedAt = existing.CreatedAt
+	}
+
+	if err := tokenStore.SaveHold(hold); err != nil {
+		return nil, err
+	}
+
+	return hold, nil
+}
+
+// ReleaseHold returns the held tokens to the member. Releasing a missing hold is a no-op
+func ReleaseHold(memberId, reference string) error {
+	holdMu.Lock()
+	defer holdMu.Unlock()
+
+	return releaseHold(memberId, reference)
+}
+
+func releaseHold(memberId, reference string) error {
+	hold, err := GetHold(memberId, reference)
+	if err != nil {
+		if errors.Is(err, ErrHoldNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	hold.Status = HoldReleased
+	hold.UpdatedAt = time.Now().UTC()
+	return tokenStore.SaveHold(hold)
+}
+
+// ReleaseHolds releases every active hold against the reference
+func ReleaseHolds(reference string) error {
+	holdMu.Lock()
+	defer holdMu.Unlock()
+
+	holds, err := tokenStore.GetHoldsByReference(reference)
+	if err != nil {
+		return err
+	}
+
+	for _, hold := range holds {
+		if hold.Status != HoldActive {
+			continue
+		}
+
+		if err := releaseHold(hold.MemberId, reference); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// HasHold reports whether the member ever held tokens against the reference, whatever the hold's status
+func HasHold(memberId, reference string) (bool, error) {
+	if _, err := tokenStore.GetHold(holdId(memberId, reference)); err != nil {
+		if errors.Is(err, ErrHoldNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+// ConsumeHold spends the held tokens by recording a debit for the held amount.
+// Consuming a hold that was already consumed does nothing, so a failed consume can be retried
+func ConsumeHold(memberId, reference string, reason Reason, attendanceId *string) error {
+	holdMu.Lock()
+	defer holdMu.Unlock()
+
+	return consumeHold(memberId, reference, reason, attendanceId)
+}
+
+// ConsumeUnheld spends amount tokens against the reference for members who entered before holds existed.
+// The spend is recorded as a consumed hold so retrying never charges twice
+func ConsumeUnheld(memberId, reference string, amount int, reason Reason, attendanceId *string) error {
+	holdMu.Lock()
+	defer holdMu.Unlock()
+
+	id := holdId(memberId, reference)
+	if _, err := tokenStore.GetHold(id); err != nil {
+		if !errors.Is(err, ErrHoldNotFound) {
+			return err
+		}
+
+		now := time.Now().UTC()
+		if err := tokenStore.SaveHold(&Hold{
+			Id:        id,
+			MemberId:  memberId,
+			Reference: reference,
+			Amount:    amount,
+			Status:    HoldActive,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}); err != nil {
+			return err
+		}
+	}
+
+	return consumeHold(memberId, reference, reason, attendanceId)
+}
+
+func consumeHold(memberId, reference string, reason Reason, attendanceId *string) error {
+	hold, err := tokenStore.GetHold(holdId(memberId, reference))
+	if err != nil {
+		return err
+	}
+
+	switch hold.Status {
+	case HoldConsumed:
+		return nil
+	case HoldReleased:
+		return ErrHoldNotFound
+	}
+
+	// the debit is keyed by the hold so a retry after a failed save finds it instead of charging again
+	debit := New(memberId, hold.Amount*-1, reason, nil, attendanceId, nil)
+	debit.Id = "hold:" + hold.Id
+	if err := debit.Save(); err != nil && !errors.Is(err, ErrRecordExists) {
+		return err
+	}
+
+	hold.Status = HoldConsumed
+	hold.UpdatedAt = time.Now().UTC()
+	return tokenStore.SaveHold(hold)
+}
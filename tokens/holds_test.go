@@ -0,0 +1,69 @@
+package tokens
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestHolds(t *testing.T) {
+	if err := Setup(NewMemoryStore()); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := New("a", 10, ReasonOther, nil, nil, nil).Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := PlaceHold("a", "raffle:1", 6); err != nil {
+		t.Fatalf("placing hold: %v", err)
+	}
+
+	if _, err := PlaceHold("a", "raffle:2", 5); !errors.Is(err, ErrInsufficientTokens) {
+		t.Errorf("expected ErrInsufficientTokens holding more than available, got %v", err)
+	}
+
+	// replacing a hold counts the tokens already held for it
+	if _, err := PlaceHold("a", "raffle:1", 8); err != nil {
+		t.Fatalf("replacing hold: %v", err)
+	}
+
+	if available, _ := GetAvailableBalanceByMemberId("a"); available != 2 {
+		t.Errorf("expected 2 available, got %d", available)
+	}
+
+	if err := ConsumeHold("a", "raffle:1", ReasonWonRaffle, nil); err != nil {
+		t.Fatalf("consuming hold: %v", err)
+	}
+
+	if balance, _ := GetBalanceByMemberId("a"); balance != 2 {
+		t.Errorf("expected balance of 2 after consuming, got %d", balance)
+	}
+
+	if err := ConsumeHold("a", "raffle:1", ReasonWonRaffle, nil); err != nil {
+		t.Fatalf("consuming hold again: %v", err)
+	}
+
+	if balance, _ := GetBalanceByMemberId("a"); balance != 2 {
+		t.Errorf("expected consuming twice to charge once, got balance %d", balance)
+	}
+
+	if held, _ := GetHeldByMemberId("a"); held != 0 {
+		t.Errorf("expected nothing held after consuming, got %d", held)
+	}
+
+	if _, err := PlaceHold("a", "raffle:2", 2); err != nil {
+		t.Fatalf("placing hold: %v", err)
+	}
+
+	if err := ReleaseHolds("raffle:2"); err != nil {
+		t.Fatalf("releasing holds: %v", err)
+	}
+
+	if available, _ := GetAvailableBalanceByMemberId("a"); available != 2 {
+		t.Errorf("expected 2 available after release, got %d", available)
+	}
+
+	if err := ConsumeHold("a", "raffle:2", ReasonWonRaffle, nil); !errors.Is(err, ErrHoldNotFound) {
+		t.Errorf("expected ErrHoldNotFound consuming a released hold, got %v", err)
+	}
+}
@@ -0,0 +1,127 @@
+package tokens
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+type memoryStore struct {
+	mu      sync.RWMutex
+	records []TokenRecord
+	holds   map[string]Hold
+}
+
+var _ Store = (*memoryStore)(nil)
+
+// NewMemoryStore returns a Store that keeps token records in memory. Used for tests
+func NewMemoryStore() Store {
+	return &memoryStore{holds: map[string]Hold{}}
+}
+
+func (s *memoryStore) Insert(record *TokenRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if slices.ContainsFunc(s.records, func(r TokenRecord) bool { return r.Id == record.Id }) {
+		return ErrRecordExists
+	}
+
+	s.records = append(s.records, *record)
+	return nil
+}
+
+func (s *memoryStore) GetAll() ([]TokenRecord, error) {
+	return s.find(func(TokenRecord) bool { return true }), nil
+}
+
+func (s *memoryStore) GetByAttendanceId(attendanceId string) ([]TokenRecord, error) {
+	return s.find(func(r TokenRecord) bool {
+		return r.AttendanceId != nil && *r.AttendanceId == attendanceId
+	}), nil
+}
+
+func (s *memoryStore) GetByMemberId(memberId string) ([]TokenRecord, error) {
+	return s.find(func(r TokenRecord) bool { return r.MemberId == memberId }), nil
+}
+
+func (s *memoryStore) GetByMemberIdAndAttendanceId(memberId, attendanceId string) ([]TokenRecord, error) {
+	return s.find(func(r TokenRecord) bool {
+		return r.MemberId == memberId && r.AttendanceId != nil && *r.AttendanceId == attendanceId
+	}), nil
+}
+
+func (s *memoryStore) GetSince(t time.Time) ([]TokenRecord, error) {
+	records := s.find(func(r TokenRecord) bool { return r.CreatedAt.After(t) })
+	slices.SortStableFunc(records, func(a, b TokenRecord) int { return a.CreatedAt.Compare(b.CreatedAt) })
+	return records, nil
+}
+
+func (s *memoryStore) GetAllBalances() (map[string]int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	balances := map[string]int{}
+	for _, r := range s.records {
+		balances[r.MemberId] += r.Amount
+	}
+	return balances, nil
+}
+
+func (s *memoryStore) GetHold(id string) (*Hold, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	hold, ok := s.holds[id]
+	if !ok {
+		return nil, ErrHoldNotFound
+	}
+	return &hold, nil
+}
+
+func (s *memoryStore) SaveHold(hold *Hold) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.holds[hold.Id] = *hold
+	return nil
+}
+
+func (s *memoryStore) GetHoldsByReference(reference string) ([]Hold, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var holds []Hold
+	for _, hold := range s.holds {
+		if hold.Reference == reference {
+			holds = append(holds, hold)
+		}
+	}
+	return holds, nil
+}
+
+func (s *memoryStore) GetActiveHeldByMemberId(memberId string) (int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	held := 0
+	for _, hold := range s.holds {
+		if hold.MemberId == memberId && hold.Status == HoldActive {
+			held += hold.Amount
+		}
+	}
+	return held, nil
+}
+
+func (s *memoryStore) find(match func(TokenRecord) bool) []TokenRecord {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var records []TokenRecord
+	for _, r := range s.records {
+		if match(r) {
+			records = append(records, r)
+		}
+	}
+	return records
+}
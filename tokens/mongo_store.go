@@ -0,0 +1,184 @@
+package tokens
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/sol-armada/sol-bot/stores"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongoStore struct {
+	store *stores.TokenStore
+	holds *stores.TokenHoldStore
+}
+
+var _ Store = (*mongoStore)(nil)
+
+// NewMongoStore wraps the mongo tokens and token holds collections as a Store
+func NewMongoStore(store *stores.TokenStore, holds *stores.TokenHoldStore) Store {
+	if store == nil || holds == nil {
+		return nil
+	}
+	return &mongoStore{store: store, holds: holds}
+}
+
+func (s *mongoStore) Insert(record *TokenRecord) error {
+	if err := s.store.Insert(record); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return ErrRecordExists
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (s *mongoStore) GetAll() ([]TokenRecord, error) {
+	cur, err := s.store.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeRecords(cur)
+}
+
+func (s *mongoStore) GetByAttendanceId(attendanceId string) ([]TokenRecord, error) {
+	cur, err := s.store.GetByAttendanceId(attendanceId)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeRecords(cur)
+}
+
+func (s *mongoStore) GetByMemberId(memberId string) ([]TokenRecord, error) {
+	cur, err := s.store.GetByMemberId(memberId)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeRecords(cur)
+}
+
+func (s *mongoStore) GetByMemberIdAndAttendanceId(memberId, attendanceId string) ([]TokenRecord, error) {
+	cur, err := s.store.GetByMemberIdAndAttendanceId(memberId, attendanceId)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeRecords(cur)
+}
+
+func (s *mongoStore) GetSince(t time.Time) ([]TokenRecord, error) {
+	cur, err := s.store.Find(context.TODO(), bson.D{
+		{Key: "created_at", Value: bson.D{
+			{Key: "$gt", Value: t},
+		}},
+	}, options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeRecords(cur)
+}
+
+func (s *mongoStore) GetAllBalances() (map[string]int, error) {
+	cur, err := s.store.GetAllBalances()
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(context.TODO())
+
+	balances := map[string]int{}
+	for cur.Next(context.TODO()) {
+		var result struct {
+			Id      string `bson:"_id"`
+			Balance int    `bson:"balance"`
+		}
+
+		if err := cur.Decode(&result); err != nil {
+			return nil, err
+		}
+
+		balances[result.Id] = result.Balance
+	}
+
+	return balances, nil
+}
+
+func (s *mongoStore) GetHold(id string) (*Hold, error) {
+	hold := &Hold{}
+	if err := s.holds.Get(id).Decode(hold); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrHoldNotFound
+		}
+		return nil, err
+	}
+
+	return hold, nil
+}
+
+func (s *mongoStore) SaveHold(hold *Hold) error {
+	return s.holds.Upsert(hold.Id, hold)
+}
+
+func (s *mongoStore) GetHoldsByReference(reference string) ([]Hold, error) {
+	cur, err := s.holds.GetByReference(reference)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeHolds(cur)
+}
+
+func (s *mongoStore) GetActiveHeldByMemberId(memberId string) (int, error) {
+	cur, err := s.holds.GetByMemberIdAndStatus(memberId, string(HoldActive))
+	if err != nil {
+		return 0, err
+	}
+
+	holds, err := decodeHolds(cur)
+	if err != nil {
+		return 0, err
+	}
+
+	held := 0
+	for _, hold := range holds {
+		held += hold.Amount
+	}
+	return held, nil
+}
+
+func decodeHolds(cur *mongo.Cursor) ([]Hold, error) {
+	defer cur.Close(context.TODO())
+
+	var holds []Hold
+	for cur.Next(context.TODO()) {
+		var hold Hold
+		if err := cur.Decode(&hold); err != nil {
+			return nil, err
+		}
+		holds = append(holds, hold)
+	}
+
+	return holds, nil
+}
+
+func decodeRecords(cur *mongo.Cursor) ([]TokenRecord, error) {
+	defer cur.Close(context.TODO())
+
+	var tokenRecords []TokenRecord
+	for cur.Next(context.TODO()) {
+		var d TokenRecord
+		if err := cur.Decode(&d); err != nil {
+			return nil, err
+		}
+		tokenRecords = append(tokenRecords, d)
+	}
+
+	return tokenRecords, nil
+}
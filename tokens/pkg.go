@@ -1,12 +1,10 @@
 package tokens
 
 import (
-	"context"
 	"errors"
 	"time"
 
 	"github.com/rs/xid"
-	"github.com/sol-armada/sol-bot/stores"
 )
 
 type Reason string
@@ -16,6 +14,7 @@ const (
 	ReasonAttendanceFull  Reason = "Stayed for full event"
 	ReasonEventSuccessful Reason = "Event Successful"
 	ReasonWonRaffle       Reason = "Won Raffle"
+	ReasonGiveawayEntry   Reason = "Giveaway Entry"
 	ReasonOther           Reason = "Other"
 )
 
@@ -30,15 +29,13 @@ type TokenRecord struct {
 	CreatedAt    time.Time `json:"created_at" bson:"created_at"`
 }
 
-var tokenStore *stores.TokenStore
+var tokenStore Store
 
-func Setup() error {
-	storesClient := stores.Get()
-	ts, ok := storesClient.GetTokensStore()
-	if !ok {
+func Setup(store Store) error {
+	if store == nil {
 		return errors.New("token store not found")
 	}
-	tokenStore = ts
+	tokenStore = store
 
 	return nil
 }
@@ -61,91 +58,58 @@ func (d *TokenRecord) Save() error {
 }
 
 func GetAllGrouped() (map[string][]TokenRecord, error) {
-	cur, err := tokenStore.GetAll()
+	records, err := tokenStore.GetAll()
 	if err != nil {
 		return nil, err
 	}
 
-	type GroupedRecord struct {
-		Id           string        `json:"id" bson:"_id"`
-		TokenRecords []TokenRecord `json:"token_records" bson:"token_records"`
-	}
-
-	var groupedRecords []GroupedRecord
-	for cur.Next(context.TODO()) {
-		var d GroupedRecord
-		if err := cur.Decode(&d); err != nil {
-			return nil, err
-		}
-		groupedRecords = append(groupedRecords, d)
-	}
-
-	tokenRecords := make(map[string][]TokenRecord, len(groupedRecords))
-	for _, r := range groupedRecords {
-		tokenRecords[r.Id] = append(tokenRecords[r.Id], r.TokenRecords...)
+	tokenRecords := map[string][]TokenRecord{}
+	for _, r := range records {
+		tokenRecords[r.MemberId] = append(tokenRecords[r.MemberId], r)
 	}
 
 	return tokenRecords, nil
 }
 
 func GetByAttendanceId(attendanceId string) ([]TokenRecord, error) {
-	cur, err := tokenStore.GetByAttendanceId(attendanceId)
-	if err != nil {
-		return nil, err
-	}
-
-	var tokenRecords []TokenRecord
-	for cur.Next(context.TODO()) {
-		var d TokenRecord
-		if err := cur.Decode(&d); err != nil {
-			return nil, err
-		}
-		tokenRecords = append(tokenRecords, d)
-	}
+	return tokenStore.GetByAttendanceId(attendanceId)
+}
 
-	return tokenRecords, nil
+func GetByMemberId(memberId string) ([]TokenRecord, error) {
+	return tokenStore.GetByMemberId(memberId)
 }
 
 func GetByMemberIdAndAttendanceId(memberId, attendanceId string) ([]TokenRecord, error) {
-	cur, err := tokenStore.GetByMemberIdAndAttendanceId(memberId, attendanceId)
-	if err != nil {
-		return nil, err
-	}
+	return tokenStore.GetByMemberIdAndAttendanceId(memberId, attendanceId)
+}
 
-	var tokenRecords []TokenRecord
-	for cur.Next(context.TODO()) {
-		var d TokenRecord
-		if err := cur.Decode(&d); err != nil {
-			return nil, err
-		}
-		tokenRecords = append(tokenRecords, d)
+func GetBalanceByMemberId(memberId string) (int, error) {
+	balances, err := tokenStore.GetAllBalances()
+	if err != nil {
+		return 0, err
 	}
 
-	return tokenRecords, nil
+	return balances[memberId], nil
 }
 
-func GetBalanceByMemberId(memberId string) (int, error) {
-	cur, err := tokenStore.GetAllBalances()
+// GetSpentByMemberId totals what the member spent on raffles and giveaway entries since the time.
+// A zero time counts everything
+func GetSpentByMemberId(memberId string, since time.Time) (int, error) {
+	records, err := tokenStore.GetByMemberId(memberId)
 	if err != nil {
 		return 0, err
 	}
 
-	var balance int
-	for cur.Next(context.TODO()) {
-		var result struct {
-			Id      string `bson:"_id"`
-			Balance int    `bson:"balance"`
-		}
-
-		if err := cur.Decode(&result); err != nil {
-			return 0, err
+	spent := 0
+	for _, record := range records {
+		if record.Amount >= 0 || record.CreatedAt.Before(since) {
+			continue
 		}
 
-		if result.Id == memberId {
-			balance = result.Balance
-			break
+		if record.Reason == ReasonWonRaffle || record.Reason == ReasonGiveawayEntry {
+			spent -= record.Amount
 		}
 	}
 
-	return balance, nil
+	return spent, nil
 }
@@ -0,0 +1,24 @@
+package tokens
+
+import "time"
+
+// Store is the persistence layer the tokens package works against
+type Store interface {
+	// Insert returns ErrRecordExists if a record with the id was already inserted
+	Insert(record *TokenRecord) error
+	GetAll() ([]TokenRecord, error)
+	GetByAttendanceId(attendanceId string) ([]TokenRecord, error)
+	GetByMemberId(memberId string) ([]TokenRecord, error)
+	GetByMemberIdAndAttendanceId(memberId, attendanceId string) ([]TokenRecord, error)
+	// GetSince returns records created after t, oldest first
+	GetSince(t time.Time) ([]TokenRecord, error)
+	// GetAllBalances returns the summed token amount keyed by member id
+	GetAllBalances() (map[string]int, error)
+
+	// GetHold returns ErrHoldNotFound if there is no hold with the id
+	GetHold(id string) (*Hold, error)
+	SaveHold(hold *Hold) error
+	GetHoldsByReference(reference string) ([]Hold, error)
+	// GetActiveHeldByMemberId returns the summed amount of the member's active holds
+	GetActiveHeldByMemberId(memberId string) (int, error)
+}
@@ -3,8 +3,6 @@ package tokens
 import (
 	"context"
 	"time"
-
-	"go.mongodb.org/mongo-driver/bson"
 )
 
 func Watch(ctx context.Context, out chan TokenRecord) error {
@@ -18,21 +16,12 @@ func Watch(ctx context.Context, out chan TokenRecord) error {
 		default:
 		}
 
-		cur, err := tokenStore.Find(ctx, bson.D{
-			{Key: "created_at", Value: bson.D{
-				{Key: "$gt", Value: lastRecordTS},
-			}},
-		})
+		records, err := tokenStore.GetSince(lastRecordTS)
 		if err != nil {
 			return err
 		}
 
-		for cur.Next(ctx) {
-			var d TokenRecord
-			if err := cur.Decode(&d); err != nil {
-				return err
-			}
-
+		for _, d := range records {
 			out <- d
 			lastRecordTS = d.CreatedAt
 		}